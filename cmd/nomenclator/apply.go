@@ -0,0 +1,149 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adrianos93/nomenclator/internal/apply"
+	"github.com/adrianos93/nomenclator/internal/batch"
+	"github.com/adrianos93/nomenclator/internal/processor"
+)
+
+// runApply titles album folders and applies their titles, renaming the folders or writing sidecars and manifests
+// into them. Applied changes are recorded in a journal file, so that they can be undone with -undo.
+func runApply(args []string) {
+	fs := flag.NewFlagSet("apply", flag.ExitOnError)
+	mode := fs.String("mode", string(apply.Manifest), "how titles are applied, one or more of rename, xmp, manifest separated by commas")
+	dryRun := fs.Bool("dry-run", false, "print the changes as a diff without applying them")
+	journalFile := fs.String("journal", defaultJournal, "file recording the applied changes, so that they can be undone")
+	undo := fs.Bool("undo", false, "undo every change recorded in the journal, from the latest")
+	title := fs.String("title", "", "title to apply instead of titling the folders, which requires no API key")
+	config := newConfig(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "nomenclator apply FOLDER...")
+		fmt.Fprintln(os.Stderr, "nomenclator apply -undo")
+		fmt.Fprintln(os.Stderr, "\nEvery FOLDER is titled as an album from the images or photo metadata files it holds.")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if *undo {
+		undone, err := apply.Undo(*journalFile)
+		for _, change := range undone {
+			fmt.Printf("undid %s\n", describe(change))
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if fs.NArg() < 1 {
+		fmt.Fprintln(os.Stderr, "You must specify an album folder\n\nUsage:")
+		fs.Usage()
+		os.Exit(1)
+	}
+	modes, err := apply.ParseModes(*mode)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var albumProcessor *processor.Processor
+	if *title == "" {
+		locator, weatherman, err := config.apis()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		albumProcessor, err = config.processor(locator, weatherman)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	var journal *apply.Journal
+	if !*dryRun {
+		journal, err = apply.OpenJournal(*journalFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	failed := false
+	isAlbumFile := albumFileExcept(*journalFile)
+	for _, dir := range fs.Args() {
+		folder, err := albumFolder(dir, isAlbumFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed = true
+			continue
+		}
+		var result batch.Result
+		if *title != "" {
+			photos, errs := readFolder(folder)
+			result = batch.NewResult(folder.Path, photos, processor.Album{Title: *title, Photos: len(photos)}, errs)
+		} else {
+			result = titleFolder(albumProcessor, folder)
+		}
+		for _, err := range result.Errors {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", folder.Path, err)
+		}
+		changes, err := apply.Plan(result, modes)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("%s: %s\n", folder.Path, result.Title)
+		for _, change := range changes {
+			if *dryRun {
+				fmt.Print(change.Diff())
+				continue
+			}
+			if err := journal.Apply(change); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				failed = true
+				break
+			}
+			fmt.Printf("applied %s\n", describe(change))
+		}
+	}
+	if journal != nil {
+		journal.Close()
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// albumFolder lists the files of an album folder matching isAlbumFile, without looking into its subfolders.
+// Hidden files are skipped like batch.Discover does.
+func albumFolder(dir string, isAlbumFile func(file string) bool) (batch.Folder, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return batch.Folder{}, err
+	}
+	folder := batch.Folder{Path: dir}
+	for _, entry := range entries {
+		file := filepath.Join(dir, entry.Name())
+		if !entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") && isAlbumFile(file) {
+			folder.Files = append(folder.Files, file)
+		}
+	}
+	return folder, nil
+}
+
+// describe returns a short description of a change, e.g. "rename of a to b"
+func describe(change apply.Change) string {
+	switch {
+	case change.Operation == apply.RenameOperation:
+		return fmt.Sprintf("rename of %s to %s", change.From, change.To)
+	case change.Created:
+		return fmt.Sprintf("creation of %s", change.To)
+	}
+	return fmt.Sprintf("update of %s", change.To)
+}
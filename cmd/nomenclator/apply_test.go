@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles is a helper function used to write files, keyed by their path relative to a temporary folder
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+// chdir is a helper function used to change the working directory for the duration of a test
+func chdir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func TestNomenclator_albumFolder(t *testing.T) {
+	for name, test := range map[string]struct {
+		files   map[string]string
+		exclude []string
+
+		want []string
+	}{
+		"images and metadata files": {
+			files: map[string]string{"IMG_0001.jpg": "", "IMG_0002.JPEG": "", "day1.csv": "", "track.kml": "", "notes.txt": "", "2019/day2.csv": ""},
+			want:  []string{"IMG_0001.jpg", "IMG_0002.JPEG", "day1.csv", "track.kml"},
+		},
+		"default output files are skipped": {
+			files: map[string]string{"day1.csv": "", "nomenclator-report.csv": "", ".nomenclator-report.csv.checkpoint": "", ".nomenclator-journal.jsonl": ""},
+			want:  []string{"day1.csv"},
+		},
+		"provided output files are skipped": {
+			files:   map[string]string{"day1.csv": "", "titles.csv": "", "changes.jsonl": ""},
+			exclude: []string{"titles.csv", "changes.jsonl"},
+			want:    []string{"day1.csv"},
+		},
+		"empty folder": {
+			files: map[string]string{"notes.txt": ""},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			chdir(t, writeFiles(t, test.files))
+			got, err := albumFolder(".", albumFileExcept(test.exclude...))
+			require.NoError(t, err)
+			require.Equal(t, ".", got.Path)
+			require.Equal(t, test.want, got.Files)
+		})
+	}
+
+	t.Run("missing folder", func(t *testing.T) {
+		_, err := albumFolder(filepath.Join(t.TempDir(), "missing"), albumFile)
+		require.True(t, os.IsNotExist(err))
+	})
+}
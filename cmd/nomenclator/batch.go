@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+	"github.com/adrianos93/nomenclator/internal/cache"
+	"github.com/adrianos93/nomenclator/internal/processor"
+	"github.com/adrianos93/nomenclator/internal/ratelimit"
+)
+
+// runBatch titles every album folder below a root folder, writing a summary report.
+// An interrupted batch is resumed from its checkpoint file, which is removed once every folder is titled.
+func runBatch(args []string) {
+	fs := flag.NewFlagSet("batch", flag.ExitOnError)
+	concurrency := fs.Int("concurrency", 4, "how many album folders are titled at once")
+	rate := fs.Float64("rate", 5, "most requests a second sent to each API, shared by every folder, 0 disables the limit")
+	report := fs.String("report", defaultReport, "file the summary report is written to, as CSV or JSON depending on its extension")
+	checkpointFile := fs.String("checkpoint", "", "file recording the folders already titled, to resume an interrupted batch. Defaults to a hidden file named after the report, next to it")
+	config := newConfig(fs)
+	notifyConfig := newNotifyConfig(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "nomenclator batch ROOT")
+		fmt.Fprintln(os.Stderr, "\nEvery folder below ROOT holding images or photo metadata files is titled as an album.")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "You must specify the root folder of the albums\n\nUsage:")
+		fs.Usage()
+		os.Exit(1)
+	}
+	format, err := batch.ReportFormat(*report)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *checkpointFile == "" {
+		*checkpointFile = filepath.Join(filepath.Dir(*report), "."+filepath.Base(*report)+".checkpoint")
+	}
+
+	locator, weatherman, err := config.apis()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	albumProcessor, err := config.processor(
+		cache.NewLocator(locator, ratelimit.New(*rate)),
+		cache.NewWeatherman(weatherman, ratelimit.New(*rate)),
+	)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	folders, err := batch.Discover(fs.Arg(0), albumFileExcept(*report, *checkpointFile))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	checkpoint, err := batch.OpenCheckpoint(*checkpointFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	notifier := notifyConfig.notifier()
+	title := func(folder batch.Folder) batch.Result {
+		result := titleFolder(albumProcessor, folder)
+		fmt.Fprintf(os.Stderr, "%s: %s\n", folder.Path, result.Title)
+		if len(result.Errors) > 0 {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", folder.Path, strings.Join(result.Errors, "; "))
+		}
+		notifyTitled(ctx, notifier, "batch", result)
+		return result
+	}
+	results, runErr := batch.New(title, batch.WithConcurrency(*concurrency), batch.WithCheckpoint(checkpoint)).Run(ctx, folders)
+	checkpoint.Close()
+
+	if err := writeReport(*report, format, results); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if runErr != nil {
+		fmt.Fprintf(os.Stderr, "batch stopped after %d of %d folders: %s. Run it again to resume from %s\n", len(results), len(folders), runErr, *checkpointFile)
+		os.Exit(1)
+	}
+	var untitled int
+	for _, result := range results {
+		if result.Title == "" {
+			untitled++
+		}
+	}
+	if untitled > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d folders could not be titled. Run the batch again to retry them, resuming from %s\n", untitled, len(folders), *checkpointFile)
+		return
+	}
+	if err := os.Remove(*checkpointFile); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+// titleFolder reads the photos of an album folder and titles them with p
+func titleFolder(p *processor.Processor, folder batch.Folder) batch.Result {
+	photos, errs := readFolder(folder)
+	if len(photos) == 0 {
+		return batch.NewResult(folder.Path, nil, processor.Album{}, append(errs, errors.New("no photos found")))
+	}
+	processed, albumErrs := p.ProcessAlbum(photos)
+	return batch.NewResult(folder.Path, photos, processed, append(errs, albumErrs...))
+}
+
+// writeReport writes the results of a batch to file in format
+func writeReport(file, format string, results []batch.Result) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := batch.WriteReport(f, format, results); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
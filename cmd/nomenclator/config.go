@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/cache"
+	"github.com/adrianos93/nomenclator/internal/coast"
+	"github.com/adrianos93/nomenclator/internal/elevation"
+	"github.com/adrianos93/nomenclator/internal/i18n"
+	"github.com/adrianos93/nomenclator/internal/landmark"
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/processor"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+)
+
+// config is used to hold the flags configuring how albums are titled, shared by every command
+type config struct {
+	outlierDistance  *float64
+	weatherRulesFile *string
+	aggregation      *string
+	useCalendar      *bool
+	placeLevel       *string
+	lang             *string
+	suggestions      *int
+	explain          *bool
+	mixedWeather     *bool
+	travel           *bool
+	light            *bool
+	nightSky         *bool
+	useLandmarks     *bool
+	landmarksFile    *string
+	elevationDir     *string
+	useCoastline     *bool
+	coastlineFile    *string
+}
+
+// newConfig defines the flags configuring how albums are titled on fs
+func newConfig(fs *flag.FlagSet) *config {
+	return &config{
+		outlierDistance:  fs.Float64("outlier-distance", 0, "exclude from the title photos further than this many kilometres from every other photo, 0 disables it"),
+		weatherRulesFile: fs.String("weather-rules", "", "JSON file with the rules mapping weather conditions to title adjectives"),
+		aggregation:      fs.String("weather-aggregation", string(processor.PerPhoto), "how photos vote for the album weather, one of photo, hour, day, time"),
+		useCalendar:      fs.Bool("calendar", false, "mention holidays, named events and seasons in titles"),
+		placeLevel:       fs.String("place-level", string(processor.AutoPlace), "place named in titles, one of auto, city, neighbourhood, locality, county, region, country, continent"),
+		lang:             fs.String("lang", "en", "language of the titles, one of "+strings.Join(i18n.Languages(), ", ")),
+		suggestions:      fs.Int("suggestions", 0, "also list up to this many alternative titles, ranked by how well they cover the album"),
+		explain:          fs.Bool("explain", false, "report how each title was derived from the photos"),
+		mixedWeather:     fs.Bool("mixed-weather", false, "describe weather changes and mixed weather, e.g. rainy then sunny"),
+		travel:           fs.Bool("travel", false, "name albums after the way they moved through space, e.g. a road trip, a hike or a boat trip"),
+		light:            fs.Bool("light", false, "describe the natural light of albums taken within a part of the day, e.g. a golden-hour evening or a night out"),
+		nightSky:         fs.Bool("night-sky", false, "describe the moon and the stars over albums taken at night, e.g. under a full moon or a starry night"),
+		useLandmarks:     fs.Bool("landmarks", false, "name albums after the landmark, park or natural feature most photos were taken at, when the place level is auto"),
+		landmarksFile:    fs.String("landmarks-file", "", "JSON file with the landmarks to use instead of the builtin ones, implies -landmarks"),
+		elevationDir:     fs.String("elevation-dir", "", "directory of SRTM .hgt tiles used to look up elevations, describing albums taken in the mountains"),
+		useCoastline:     fs.Bool("coastline", false, "describe albums taken along the coast, when the place level is auto"),
+		coastlineFile:    fs.String("coastline-file", "", "GeoJSON file with the coastlines to use instead of the builtin ones, implies -coastline"),
+	}
+}
+
+// apis returns the clients of the geolocation and weather APIs, reading their keys from the environment
+func (c *config) apis() (*locator.Locator, *weatherman.Weatherman, error) {
+	mapsAPIKey, found := os.LookupEnv("LOCATOR_API_KEY")
+	if !found {
+		return nil, nil, errors.New("LOCATOR_API_KEY env var not set. Please set a valid API Key")
+	}
+	weatherAPIKey, found := os.LookupEnv("WEATHER_API_KEY")
+	if !found {
+		return nil, nil, errors.New("WEATHER_API_KEY env var not set. Please set a valid API Key")
+	}
+	catalog, err := i18n.Lookup(*c.lang)
+	if err != nil {
+		return nil, nil, err
+	}
+	locator := locator.New(mapsAPIKey, locator.WithDataLimit(1), locator.WithLanguage(catalog.Language))
+	weatherman := weatherman.New(weatherAPIKey, weatherman.WithFilter([]string{"datetime", "datetimeEpoch", "conditions", "cloudcover"}))
+	return locator, weatherman, nil
+}
+
+// processor returns a Processor configured by the flags, looking up photos with l and w, with the extra options on top
+func (c *config) processor(l processor.Locator, w processor.Weatherman, extra ...processor.ProcessorOptions) (*processor.Processor, error) {
+	catalog, err := i18n.Lookup(*c.lang)
+	if err != nil {
+		return nil, err
+	}
+	weatherAggregation, err := processor.ParseWeatherAggregation(*c.aggregation)
+	if err != nil {
+		return nil, err
+	}
+	level, err := processor.ParsePlaceLevel(*c.placeLevel)
+	if err != nil {
+		return nil, err
+	}
+	options := []processor.ProcessorOptions{
+		processor.WithOutlierDetection(*c.outlierDistance),
+		processor.WithWeatherAggregation(weatherAggregation),
+		processor.WithPlaceLevel(level),
+		processor.WithCatalog(catalog),
+	}
+	if *c.useCalendar {
+		options = append(options, processor.WithCalendar())
+	}
+	if *c.suggestions > 0 {
+		options = append(options, processor.WithSuggestions(*c.suggestions))
+	}
+	if *c.explain {
+		options = append(options, processor.WithExplain())
+	}
+	if *c.mixedWeather {
+		options = append(options, processor.WithMixedWeather())
+	}
+	if *c.light {
+		options = append(options, processor.WithLight())
+	}
+	if *c.nightSky {
+		options = append(options, processor.WithNightSky())
+	}
+	if *c.travel {
+		options = append(options, processor.WithTravel())
+	}
+	if *c.useLandmarks || *c.landmarksFile != "" {
+		landmarks := landmark.Builtin()
+		if *c.landmarksFile != "" {
+			landmarks, err = readLandmarks(*c.landmarksFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+		options = append(options, processor.WithLandmarks(landmarks))
+	}
+	if *c.elevationDir != "" {
+		options = append(options, processor.WithElevation(elevation.New(*c.elevationDir)))
+	}
+	if *c.useCoastline || *c.coastlineFile != "" {
+		coastline := coast.Builtin()
+		if *c.coastlineFile != "" {
+			coastline, err = readCoastline(*c.coastlineFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+		options = append(options, processor.WithCoastline(coastline))
+	}
+	if *c.weatherRulesFile != "" {
+		rules, err := readWeatherRules(*c.weatherRulesFile)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, processor.WithWeatherRules(rules))
+	}
+	return processor.New(l, w, append(options, extra...)...), nil
+}
+
+// cacheConfig is used to hold the flags bounding the lookups cached by long running commands
+type cacheConfig struct {
+	size *int
+	ttl  *time.Duration
+}
+
+// newCacheConfig defines the flags bounding the cached lookups on fs
+func newCacheConfig(fs *flag.FlagSet) *cacheConfig {
+	return &cacheConfig{
+		size: fs.Int("cache-size", 10000, "most places and forecasts each kept in the lookup cache, 0 keeps them all"),
+		ttl:  fs.Duration("cache-ttl", 24*time.Hour, "how long looked up places and forecasts are kept in the cache for, 0 keeps them forever"),
+	}
+}
+
+// options returns the cache options configured by the flags
+func (c *cacheConfig) options() []cache.Options {
+	return []cache.Options{cache.WithMaxEntries(*c.size), cache.WithTTL(*c.ttl)}
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+	"github.com/adrianos93/nomenclator/internal/coast"
+	"github.com/adrianos93/nomenclator/internal/decoder"
+	"github.com/adrianos93/nomenclator/internal/exif"
+	"github.com/adrianos93/nomenclator/internal/geo"
+	"github.com/adrianos93/nomenclator/internal/gpx"
+	"github.com/adrianos93/nomenclator/internal/importer"
+	"github.com/adrianos93/nomenclator/internal/landmark"
+	"github.com/adrianos93/nomenclator/internal/processor"
+)
+
+// stdin is the FILE_PATH argument used to read photo metadata from the standard input
+const stdin = "-"
+
+// mergedAlbum is the key used in the output for albums merged from several sources
+const mergedAlbum = "merged"
+
+// The files the batch and apply commands write to by default, in the working directory
+const (
+	defaultReport  = "nomenclator-report.csv"
+	defaultJournal = ".nomenclator-journal.jsonl"
+)
+
+// outputFiles lists the files nomenclator writes to by default which are not hidden, and are never read as photos of an album
+var outputFiles = []string{defaultReport}
+
+// imageExtensions lists the file extensions of the images EXIF data is read from
+var imageExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".tif": true, ".tiff": true}
+
+// album is used to group the photos read from one or more sources so that they are titled together
+type album struct {
+	key     string
+	sources []string
+	photos  []processor.Metadata
+	errs    []error
+}
+
+// expandArgs resolves the glob patterns in args, keeping the order they were provided in.
+// Arguments that are not patterns, or match nothing, are kept as is so that a missing file is reported when read.
+func expandArgs(args []string) ([]string, error) {
+	files := make([]string, 0, len(args))
+	seen := map[string]bool{}
+	for _, arg := range args {
+		matches := []string{arg}
+		if arg != stdin && strings.ContainsAny(arg, "*?[") {
+			found, err := filepath.Glob(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", arg, err)
+			}
+			if len(found) > 0 {
+				matches = found
+			}
+		}
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				files = append(files, match)
+			}
+		}
+	}
+	return files, nil
+}
+
+// readAlbums reads every file, returning either one album per file or a single album merging all of them.
+func readAlbums(files []string, format string, merge bool) ([]album, error) {
+	albums := make([]album, 0, len(files))
+	for _, file := range files {
+		photos, errs, err := readFile(file, format)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		albums = append(albums, album{key: file, sources: []string{file}, photos: photos, errs: errs})
+	}
+	if !merge || len(albums) < 2 {
+		return albums, nil
+	}
+	merged := album{key: mergedAlbum}
+	for _, a := range albums {
+		merged.sources = append(merged.sources, a.sources...)
+		merged.photos = append(merged.photos, a.photos...)
+		for _, err := range a.errs {
+			merged.errs = append(merged.errs, fmt.Errorf("%s: %w", a.key, err))
+		}
+	}
+	return []album{merged}, nil
+}
+
+// readFile decodes the photo metadata of file with the decoder registered for format,
+// or for the file extension when no format is provided. Folders are read as photo library exports
+// and the standard input as CSV unless told otherwise.
+func readFile(file, format string) ([]processor.Metadata, []error, error) {
+	if file == stdin {
+		if format == "" {
+			format = "csv"
+		}
+		return decode(os.Stdin, file, format)
+	}
+	if info, err := os.Stat(file); err == nil && info.IsDir() {
+		photos, errs := importer.Import(file, format)
+		return photos, errs, nil
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	return decode(f, file, format)
+}
+
+// decode is a helper function used to decode r with the decoder matching file and format
+func decode(r io.Reader, file, format string) ([]processor.Metadata, []error, error) {
+	d, err := decoder.ForFile(file, format)
+	if err != nil {
+		return nil, nil, err
+	}
+	photos, errs := d.Decode(r)
+	return photos, errs, nil
+}
+
+// readGPX positions the photos described by files on the GPX track.
+// Photos that cannot be dated or positioned are returned as errors, a broken track fails the whole album.
+func readGPX(trackFile string, files []string, options ...gpx.InterpolatorOptions) ([]processor.Metadata, []error, error) {
+	f, err := os.Open(trackFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	track, err := gpx.Read(f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var errs []error
+	dates := make([]time.Time, 0, len(files))
+	for _, file := range files {
+		fileDates, err := readPhotoDates(file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid photo %s: %w", file, err))
+			continue
+		}
+		dates = append(dates, fileDates...)
+	}
+	rows, rowErrs := gpx.NewInterpolator(track, options...).Rows(dates)
+	errs = append(errs, rowErrs...)
+	photos := make([]processor.Metadata, 0, len(rows))
+	for _, row := range rows {
+		metadata, err := processor.ParseRow(row)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid photo: %w", err))
+			continue
+		}
+		photos = append(photos, metadata)
+	}
+	return photos, errs, nil
+}
+
+// readPhotoDates returns the time an image was taken from its EXIF data, or
+// every timestamp listed in a plain text file.
+func readPhotoDates(file string) ([]time.Time, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if imageExtensions[strings.ToLower(filepath.Ext(file))] {
+		data, err := exif.Read(f)
+		if err != nil {
+			return nil, err
+		}
+		return []time.Time{data.DateTime}, nil
+	}
+	var dates []time.Time
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date: %w", err)
+		}
+		dates = append(dates, date)
+	}
+	return dates, scanner.Err()
+}
+
+// albumFile tells whether file holds photos of an album folder, either an image or a file with a registered decoder
+func albumFile(file string) bool {
+	if imageExtensions[strings.ToLower(filepath.Ext(file))] {
+		return true
+	}
+	_, err := decoder.ForFile(file, "")
+	return err == nil
+}
+
+// albumFileExcept returns a matcher telling whether a file holds photos of an album folder like albumFile, skipping
+// the output files written by nomenclator along with the files provided, e.g. a report written inside the albums root
+func albumFileExcept(files ...string) func(file string) bool {
+	excluded := make(map[string]bool, len(outputFiles)+len(files))
+	for _, file := range append(append([]string{}, outputFiles...), files...) {
+		if abs, err := filepath.Abs(file); err == nil {
+			excluded[abs] = true
+		}
+	}
+	return func(file string) bool {
+		if abs, err := filepath.Abs(file); err == nil && excluded[abs] {
+			return false
+		}
+		return albumFile(file)
+	}
+}
+
+// readFolder reads the photos of an album folder from its images, positioned by their EXIF GPS data,
+// or from its other files when it holds no images.
+func readFolder(folder batch.Folder) ([]processor.Metadata, []error) {
+	var images, others []string
+	for _, file := range folder.Files {
+		if imageExtensions[strings.ToLower(filepath.Ext(file))] {
+			images = append(images, file)
+		} else {
+			others = append(others, file)
+		}
+	}
+	var photos []processor.Metadata
+	var errs []error
+	if len(images) > 0 {
+		for _, file := range images {
+			photo, err := readImage(file)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid photo %s: %w", file, err))
+				continue
+			}
+			photos = append(photos, photo)
+		}
+		return photos, errs
+	}
+	for _, file := range others {
+		filePhotos, fileErrs, err := readFile(file, "")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", file, err))
+			continue
+		}
+		photos = append(photos, filePhotos...)
+		for _, fileErr := range fileErrs {
+			errs = append(errs, fmt.Errorf("%s: %w", file, fileErr))
+		}
+	}
+	return photos, errs
+}
+
+// readImage returns the metadata of an image from its EXIF data
+func readImage(file string) (processor.Metadata, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return processor.Metadata{}, err
+	}
+	defer f.Close()
+	data, err := exif.Read(f)
+	if err != nil {
+		return processor.Metadata{}, err
+	}
+	if !data.Located {
+		return processor.Metadata{}, errors.New("no gps data found in exif data")
+	}
+	date := data.DateTime
+	if !data.Zoned {
+		// cameras keep their clock in local time, so without a recorded offset the date is the local time the photo was taken at
+		date = geo.FromLocalTime(date, data.Longitude)
+	}
+	return processor.Metadata{Latitude: data.Latitude, Longitude: data.Longitude, Date: date}, nil
+}
+
+// readWeatherRules reads the weather classification rules from a JSON config file
+func readWeatherRules(file string) (*processor.WeatherRules, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return processor.LoadWeatherRules(f)
+}
+
+// readLandmarks reads a landmark dataset from a JSON file
+func readLandmarks(file string) (*landmark.Dataset, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return landmark.Load(f)
+}
+
+// readCoastline reads a coastline dataset from a GeoJSON file
+func readCoastline(file string) (*coast.Dataset, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return coast.Load(f)
+}
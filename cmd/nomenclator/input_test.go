@@ -0,0 +1,225 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+	"github.com/adrianos93/nomenclator/internal/processor"
+	"github.com/stretchr/testify/require"
+)
+
+// stdinFrom is a helper function used to replace the standard input with content for the duration of a test
+func stdinFrom(t *testing.T, content string) {
+	file := filepath.Join(t.TempDir(), "stdin")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	original := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = original
+		f.Close()
+	})
+}
+
+func TestNomenclator_expandArgs(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"day1.csv": "", "day2.csv": "", "track.kml": "", "[draft].csv": ""})
+	for name, test := range map[string]struct {
+		args []string
+
+		want    []string
+		wantErr bool
+	}{
+		"files are kept in order": {
+			args: []string{filepath.Join(dir, "track.kml"), filepath.Join(dir, "day1.csv")},
+			want: []string{filepath.Join(dir, "track.kml"), filepath.Join(dir, "day1.csv")},
+		},
+		"patterns are expanded": {
+			args: []string{filepath.Join(dir, "day*.csv"), filepath.Join(dir, "track.kml")},
+			want: []string{filepath.Join(dir, "day1.csv"), filepath.Join(dir, "day2.csv"), filepath.Join(dir, "track.kml")},
+		},
+		"duplicates are removed": {
+			args: []string{filepath.Join(dir, "day1.csv"), filepath.Join(dir, "*.csv")},
+			want: []string{filepath.Join(dir, "day1.csv"), filepath.Join(dir, "[draft].csv"), filepath.Join(dir, "day2.csv")},
+		},
+		"patterns matching nothing are kept": {
+			args: []string{filepath.Join(dir, "*.gpx"), filepath.Join(dir, "[draft].csv")},
+			want: []string{filepath.Join(dir, "*.gpx"), filepath.Join(dir, "[draft].csv")},
+		},
+		"standard input": {
+			args: []string{stdin, filepath.Join(dir, "track.kml")},
+			want: []string{stdin, filepath.Join(dir, "track.kml")},
+		},
+		"invalid pattern": {
+			args:    []string{filepath.Join(dir, "[day.csv")},
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := expandArgs(test.args)
+			if (err != nil) != test.wantErr {
+				t.Errorf("expandArgs() error = %v, wantErr %v", err, test.wantErr)
+			}
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestNomenclator_readAlbums(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"day1.csv":  "2019-10-31T18:52:59Z,40.627883,14.366858\nyesterday,40.6,14.3\n",
+		"day2.csv":  "2019-11-01T10:00:00Z,40.628197,14.367075\n",
+		"notes.txt": "",
+	})
+	day1, day2 := filepath.Join(dir, "day1.csv"), filepath.Join(dir, "day2.csv")
+	halloween := processor.Metadata{Date: time.Date(2019, 10, 31, 18, 52, 59, 0, time.UTC), Latitude: 40.627883, Longitude: 14.366858}
+	allSaints := processor.Metadata{Date: time.Date(2019, 11, 1, 10, 0, 0, 0, time.UTC), Latitude: 40.628197, Longitude: 14.367075}
+	for name, test := range map[string]struct {
+		files  []string
+		format string
+		merge  bool
+		stdin  string
+
+		want     []album
+		wantErrs [][]string
+		wantErr  bool
+	}{
+		"one album per file": {
+			files: []string{day1, day2},
+			want: []album{
+				{key: day1, sources: []string{day1}, photos: []processor.Metadata{halloween}},
+				{key: day2, sources: []string{day2}, photos: []processor.Metadata{allSaints}},
+			},
+			wantErrs: [][]string{{"yesterday"}, nil},
+		},
+		"merged albums": {
+			files: []string{day1, day2},
+			merge: true,
+			want: []album{
+				{key: mergedAlbum, sources: []string{day1, day2}, photos: []processor.Metadata{halloween, allSaints}},
+			},
+			wantErrs: [][]string{{day1 + ": "}},
+		},
+		"a single file is not merged": {
+			files: []string{day2},
+			merge: true,
+			want: []album{
+				{key: day2, sources: []string{day2}, photos: []processor.Metadata{allSaints}},
+			},
+			wantErrs: [][]string{nil},
+		},
+		"standard input as csv": {
+			files: []string{stdin},
+			stdin: "2019-11-01T10:00:00Z,40.628197,14.367075\n",
+			want: []album{
+				{key: stdin, sources: []string{stdin}, photos: []processor.Metadata{allSaints}},
+			},
+			wantErrs: [][]string{nil},
+		},
+		"standard input with a format": {
+			files:  []string{stdin},
+			format: "jsonl",
+			stdin:  `{"date":"2019-11-01T10:00:00Z","latitude":40.628197,"longitude":14.367075}` + "\n",
+			want: []album{
+				{key: stdin, sources: []string{stdin}, photos: []processor.Metadata{allSaints}},
+			},
+			wantErrs: [][]string{nil},
+		},
+		"missing file": {
+			files:   []string{day1, filepath.Join(dir, "missing.csv")},
+			wantErr: true,
+		},
+		"unknown format": {
+			files:   []string{filepath.Join(dir, "notes.txt")},
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			stdinFrom(t, test.stdin)
+			got, err := readAlbums(test.files, test.format, test.merge)
+			if (err != nil) != test.wantErr {
+				t.Errorf("readAlbums() error = %v, wantErr %v", err, test.wantErr)
+			}
+			require.Len(t, got, len(test.want))
+			for i, want := range test.want {
+				require.Equal(t, want.key, got[i].key)
+				require.Equal(t, want.sources, got[i].sources)
+				require.Equal(t, want.photos, got[i].photos)
+				require.Len(t, got[i].errs, len(test.wantErrs[i]))
+				for j, prefix := range test.wantErrs[i] {
+					require.Contains(t, got[i].errs[j].Error(), prefix)
+				}
+			}
+		})
+	}
+}
+
+func TestNomenclator_readFolder(t *testing.T) {
+	for name, test := range map[string]struct {
+		files map[string]string
+
+		want     []processor.Metadata
+		wantErrs int
+	}{
+		"metadata files": {
+			files: map[string]string{
+				"day1.csv": "2019-10-31T18:52:59Z,40.627883,14.366858\n",
+				"day2.csv": "2019-11-01T10:00:00Z,40.628197,14.367075\nyesterday,40.6,14.3\n",
+			},
+			want: []processor.Metadata{
+				{Date: time.Date(2019, 10, 31, 18, 52, 59, 0, time.UTC), Latitude: 40.627883, Longitude: 14.366858},
+				{Date: time.Date(2019, 11, 1, 10, 0, 0, 0, time.UTC), Latitude: 40.628197, Longitude: 14.367075},
+			},
+			wantErrs: 1,
+		},
+		"images are read instead of metadata files": {
+			files: map[string]string{
+				"IMG_0001.jpg": "not an image",
+				"day1.csv":     "2019-10-31T18:52:59Z,40.627883,14.366858\n",
+			},
+			wantErrs: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := writeFiles(t, test.files)
+			folder, err := albumFolder(dir, albumFile)
+			require.NoError(t, err)
+			got, errs := readFolder(folder)
+			require.Equal(t, test.want, got)
+			require.Len(t, errs, test.wantErrs)
+		})
+	}
+}
+
+func TestNomenclator_readImage(t *testing.T) {
+	// the photo was taken in Sydney at 18:52:59 local time, by a camera that did not record its UTC offset.
+	got, err := readImage(filepath.Join("testdata", "sydney.jpg"))
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2019, 10, 31, 8, 52, 59, 0, time.UTC), got.Date.UTC())
+	require.InDelta(t, -33.859, got.Latitude, 0.0001)
+	require.InDelta(t, 151.21, got.Longitude, 0.0001)
+}
+
+func TestNomenclator_albumFileExcept(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"2019/day1.csv":                   "",
+		"2020/day2.csv":                   "",
+		"titles.csv":                      "",
+		"changes.jsonl":                   "",
+		".titles.csv.checkpoint":          "",
+		".nomenclator-journal.jsonl":      "",
+		"2020/.nomenclator-journal.jsonl": "",
+		"2021/nomenclator-report.csv":     "",
+	})
+	chdir(t, root)
+	folders, err := batch.Discover(".", albumFileExcept("titles.csv", "changes.jsonl"))
+	require.NoError(t, err)
+	require.Equal(t, []batch.Folder{
+		{Path: "2019", Files: []string{filepath.Join("2019", "day1.csv")}},
+		{Path: "2020", Files: []string{filepath.Join("2020", "day2.csv")}},
+		{Path: "2021", Files: []string{filepath.Join("2021", "nomenclator-report.csv")}},
+	}, folders)
+}
@@ -1,66 +1,105 @@
 package main
 
 import (
-	"encoding/csv"
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
-	"github.com/adrianos93/nomenclator/internal/locator"
-	"github.com/adrianos93/nomenclator/internal/processor"
-	"github.com/adrianos93/nomenclator/internal/weatherman"
+	"github.com/adrianos93/nomenclator/internal/batch"
+	"github.com/adrianos93/nomenclator/internal/decoder"
+	"github.com/adrianos93/nomenclator/internal/gpx"
+	"github.com/adrianos93/nomenclator/internal/importer"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "batch":
+			runBatch(os.Args[2:])
+			return
+		case "apply":
+			runApply(os.Args[2:])
+			return
+		case "watch":
+			runWatch(os.Args[2:])
+			return
+		case "serve":
+			runServe(os.Args[2:])
+			return
+		}
+	}
+
+	format := flag.String("format", "", "input format, one of "+strings.Join(decoder.Formats(), ", ")+" for files or "+strings.Join(importer.Formats(), ", ")+" for export folders. Detected when not set")
+	merge := flag.Bool("merge", false, "title every FILE_PATH as a single album instead of one album per file")
+	output := flag.String("output", "text", "output format, one of text, json")
+	gpxFile := flag.String("gpx", "", "GPX track used to position photos; FILE_PATH arguments are then images or files listing one RFC3339 photo timestamp per line")
+	maxGap := flag.Duration("max-gap", 5*time.Minute, "largest gap between GPX track points a photo can be interpolated across")
+	clockOffset := flag.Duration("clock-offset", 0, "how far ahead of the GPS clock the camera clock was running, e.g. 1h or -90s")
+	config := newConfig(flag.CommandLine)
+	notifyConfig := newNotifyConfig(flag.CommandLine)
 	flag.Usage = func() {
-		fmt.Fprintln(os.Stderr, "nomenclator FILE_PATH")
+		fmt.Fprintln(os.Stderr, "nomenclator FILE_PATH...")
+		fmt.Fprintln(os.Stderr, "nomenclator -gpx TRACK_PATH FILE_PATH...")
+		fmt.Fprintln(os.Stderr, "nomenclator batch ROOT")
+		fmt.Fprintln(os.Stderr, "nomenclator apply FOLDER...")
+		fmt.Fprintln(os.Stderr, "nomenclator watch ROOT")
+		fmt.Fprintln(os.Stderr, "nomenclator serve")
+		fmt.Fprintln(os.Stderr, "\nFILE_PATH can be a glob pattern, or - to read from the standard input.")
 		flag.PrintDefaults()
 	}
 	flag.Parse()
-	if flag.NArg() != 1 {
-		fmt.Fprintln(os.Stderr, "You must specify a csv file to process\n\nUsage:")
+	if flag.NArg() < 1 {
+		fmt.Fprintln(os.Stderr, "You must specify a file to process\n\nUsage:")
 		flag.Usage()
 		os.Exit(1)
 	}
-	file := flag.Arg(0)
-
-	mapsAPIKey, found := os.LookupEnv("LOCATOR_API_KEY")
-	if !found {
-		fmt.Fprintln(os.Stderr, "LOCATOR_API_KEY env var not set. Please set a valid API Key")
+	if *output != "text" && *output != "json" {
+		fmt.Fprintf(os.Stderr, "Unknown output format %q\n\nUsage:\n", *output)
+		flag.Usage()
 		os.Exit(1)
 	}
 
-	weatherAPIKey, found := os.LookupEnv("WEATHER_API_KEY")
-	if !found {
-		fmt.Fprintln(os.Stderr, "WEATHER_API_KEY env var not set. Please set a valid API Key")
+	locator, weatherman, err := config.apis()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
-	data, err := readCSV(file)
+	files, err := expandArgs(flag.Args())
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
-	locator := locator.New(mapsAPIKey, locator.WithDataLimit(1))
-	weatherman := weatherman.New(weatherAPIKey, weatherman.WithFilter([]string{"datetime", "datetimeEpoch", "conditions"}))
-	processor := processor.New(locator, weatherman)
-
-	title, errs := processor.Process(data)
-	if len(errs) > 0 {
-		fmt.Fprintln(os.Stderr, errs)
+	var albums []album
+	if *gpxFile != "" {
+		photos, errs, gpxErr := readGPX(*gpxFile, files, gpx.WithMaxGap(*maxGap), gpx.WithClockOffset(*clockOffset))
+		albums = []album{{key: *gpxFile, sources: append([]string{*gpxFile}, files...), photos: photos, errs: errs}}
+		err = gpxErr
+	} else {
+		albums, err = readAlbums(files, *format, *merge)
 	}
-	fmt.Printf("Album title: %s", title)
-}
-
-func readCSV(file string) ([][]string, error) {
-	f, err := os.Open(file)
 	if err != nil {
-		return [][]string{}, err
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-	defer f.Close()
-	lines, err := csv.NewReader(f).ReadAll()
+	processor, err := config.processor(locator, weatherman)
 	if err != nil {
-		return [][]string{}, err
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 
-	return lines, nil
+	notifier := notifyConfig.notifier()
+	results := make([]result, 0, len(albums))
+	for _, album := range albums {
+		processed, errs := processor.ProcessAlbum(album.photos)
+		errs = append(album.errs, errs...)
+		results = append(results, newResult(album, processed, errs))
+		notifyTitled(context.Background(), notifier, "cli", batch.NewResult(album.key, album.photos, processed, errs))
+	}
+	if err := writeResults(os.Stdout, os.Stderr, *output, results); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+	"github.com/adrianos93/nomenclator/internal/notify"
+)
+
+// notifyConfig is used to hold the flags configuring the webhook notified when an album is titled
+type notifyConfig struct {
+	webhook *string
+	retries *int
+	timeout *time.Duration
+}
+
+// newNotifyConfig defines the flags configuring the webhook on fs
+func newNotifyConfig(fs *flag.FlagSet) *notifyConfig {
+	return &notifyConfig{
+		webhook: fs.String("webhook", "", "URL every titled album is posted to as JSON, signed with the WEBHOOK_SECRET env var when set"),
+		retries: fs.Int("webhook-retries", 3, "how many times a failed webhook delivery is retried"),
+		timeout: fs.Duration("webhook-timeout", 10*time.Second, "how long a webhook delivery can take"),
+	}
+}
+
+// notifier returns the Notifier configured by the flags, or nil when no webhook is set
+func (c *notifyConfig) notifier() notify.Notifier {
+	if *c.webhook == "" {
+		return nil
+	}
+	options := []notify.WebhookOptions{notify.WithRetries(*c.retries), notify.WithTimeout(*c.timeout)}
+	if secret, found := os.LookupEnv("WEBHOOK_SECRET"); found {
+		options = append(options, notify.WithSecret(secret))
+	}
+	return notify.NewWebhook(*c.webhook, options...)
+}
+
+// notifyTitled notifies n that album was titled by mode, reporting failed deliveries without stopping.
+// Albums that could not be titled are not notified.
+func notifyTitled(ctx context.Context, n notify.Notifier, mode string, album batch.Result) {
+	if n == nil || album.Title == "" {
+		return
+	}
+	if err := n.Notify(ctx, notify.Titled(mode, album)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+)
+
+// result is used to describe a titled album in the structured output
+type result struct {
+	key         string
+	Sources     []string               `json:"sources"`
+	Title       string                 `json:"title"`
+	Suggestions []processor.Suggestion `json:"suggestions,omitempty"`
+	Photos      int                    `json:"photos"`
+	Travel      *processor.Travel      `json:"travel,omitempty"`
+	Sky         *processor.Sky         `json:"sky,omitempty"`
+	Altitude    *processor.Altitude    `json:"altitude,omitempty"`
+	Errors      []string               `json:"errors,omitempty"`
+	Explanation *processor.Explanation `json:"explanation,omitempty"`
+}
+
+// newResult returns the result of titling a
+func newResult(a album, processed processor.Album, errs []error) result {
+	r := result{
+		key:         a.key,
+		Sources:     a.sources,
+		Title:       processed.Title,
+		Suggestions: processed.Suggestions,
+		Photos:      len(a.photos),
+		Travel:      processed.Travel,
+		Sky:         processed.Sky,
+		Altitude:    processed.Altitude,
+		Explanation: processed.Explanation,
+	}
+	for _, err := range errs {
+		r.Errors = append(r.Errors, err.Error())
+	}
+	return r
+}
+
+// writeResults writes the titled albums to stdout in the requested format, and their errors to stderr
+// for the text format. The JSON format keys albums by their source file.
+func writeResults(stdout, stderr io.Writer, format string, results []result) error {
+	if format == "json" {
+		albums := make(map[string]result, len(results))
+		for _, r := range results {
+			albums[r.key] = r
+		}
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			Albums map[string]result `json:"albums"`
+		}{albums})
+	}
+
+	for _, r := range results {
+		if len(r.Errors) > 0 {
+			fmt.Fprintln(stderr, r.Errors)
+		}
+		if len(results) == 1 {
+			fmt.Fprintf(stdout, "Album title: %s", r.Title)
+			for i, suggestion := range r.Suggestions {
+				fmt.Fprintf(stdout, "\n%d. %s (%.2f)", i+1, suggestion.Title, suggestion.Score)
+			}
+			if r.Explanation != nil {
+				fmt.Fprintln(stdout)
+				writeExplanation(stdout, r.Explanation)
+			}
+			continue
+		}
+		fmt.Fprintf(stdout, "%s: Album title: %s\n", r.key, r.Title)
+		for i, suggestion := range r.Suggestions {
+			fmt.Fprintf(stdout, "%s: %d. %s (%.2f)\n", r.key, i+1, suggestion.Title, suggestion.Score)
+		}
+		if r.Explanation != nil {
+			writeExplanation(stdout, r.Explanation)
+		}
+	}
+	return nil
+}
+
+// writeExplanation writes how a title was derived in the text format
+func writeExplanation(w io.Writer, e *processor.Explanation) {
+	fmt.Fprintln(w, "\nPhotos:")
+	for _, photo := range e.Photos {
+		place := photoPlace(photo.Places)
+		if photo.Landmark != "" {
+			place = photo.Landmark + ", " + place
+		}
+		if photo.Elevation != nil {
+			place += fmt.Sprintf(" at %.0f m", *photo.Elevation)
+		}
+		fmt.Fprintf(w, "  %s (local %s) %f,%f in %s: %q is %s",
+			photo.Date.Format(time.RFC3339), photo.LocalTime.Format("2006-01-02 15:04 MST"), photo.Latitude, photo.Longitude,
+			place, photo.Conditions, photo.Weather)
+		if photo.Sun != nil {
+			fmt.Fprintf(w, ", sun at %.1f° in %s", *photo.Sun, photo.Light)
+		}
+		if photo.Rule != "" {
+			fmt.Fprintf(w, " (matched %q)", photo.Rule)
+		} else {
+			fmt.Fprint(w, " (default)")
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "\nWeather votes, by %s: %s\n", e.Aggregation, tally(e.Weather))
+	fmt.Fprintln(w, "\nPlaces:")
+	for _, level := range []processor.PlaceLevel{processor.Neighbourhood, processor.Locality, processor.County, processor.Region, processor.Country, processor.Continent, processor.CityPlace} {
+		if places, found := e.Places[level]; found {
+			counts := make(map[string]float64, len(places))
+			for place, n := range places {
+				counts[place] = float64(n)
+			}
+			fmt.Fprintf(w, "  %s: %s\n", level, tally(counts))
+		}
+	}
+	parts := make(map[string]float64, len(e.Parts))
+	for part, n := range e.Parts {
+		parts[part] = float64(n)
+	}
+	fmt.Fprintf(w, "\nPeriod: %s over %d calendar days, by part of the day: %s\n", e.Span, e.Days, tally(parts))
+	fmt.Fprintln(w, "\nDecisions:")
+	for _, decision := range e.Decisions {
+		fmt.Fprintf(w, "  %s: %s, as %s\n", decision.Facet, decision.Value, decision.Reason)
+	}
+}
+
+// photoPlace is a helper function used to describe where a photo was taken, from the most to the least specific place
+func photoPlace(places map[processor.PlaceLevel]string) string {
+	names := make([]string, 0, len(places))
+	for _, level := range []processor.PlaceLevel{processor.Neighbourhood, processor.Locality, processor.County, processor.Region, processor.Country} {
+		if name, found := places[level]; found && (len(names) == 0 || names[len(names)-1] != name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return places[processor.CityPlace]
+	}
+	return strings.Join(names, ", ")
+}
+
+// tally is a helper function used to list counts from the highest, e.g. "sunny 4, rainy 1"
+func tally(counts map[string]float64) string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	entries := make([]string, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, fmt.Sprintf("%s %.3g", key, counts[key]))
+	}
+	return strings.Join(entries, ", ")
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNomenclator_writeResults(t *testing.T) {
+	day1 := newResult(album{key: "day1.csv", sources: []string{"day1.csv"}, photos: make([]processor.Metadata, 2)},
+		processor.Album{Title: "A sunny evening in Positano", Suggestions: []processor.Suggestion{{Title: "An evening in Italy", Score: 1}}},
+		[]error{errors.New("invalid photo: unknown location")})
+	day2 := newResult(album{key: "day2.csv", sources: []string{"day2.csv"}, photos: make([]processor.Metadata, 1)},
+		processor.Album{Title: "A rainy day in Positano"}, nil)
+	merged := newResult(album{key: mergedAlbum, sources: []string{"day1.csv", "day2.csv"}, photos: make([]processor.Metadata, 3)},
+		processor.Album{Title: "A rainy weekend in Positano"}, nil)
+	for name, test := range map[string]struct {
+		format  string
+		results []result
+
+		wantStdout string
+		wantStderr string
+	}{
+		"single album as text": {
+			format:     "text",
+			results:    []result{day1},
+			wantStdout: "Album title: A sunny evening in Positano\n1. An evening in Italy (1.00)",
+			wantStderr: "[invalid photo: unknown location]\n",
+		},
+		"several albums as text": {
+			format:  "text",
+			results: []result{day1, day2},
+			wantStdout: "day1.csv: Album title: A sunny evening in Positano\n" +
+				"day1.csv: 1. An evening in Italy (1.00)\n" +
+				"day2.csv: Album title: A rainy day in Positano\n",
+			wantStderr: "[invalid photo: unknown location]\n",
+		},
+		"albums as json keyed by source": {
+			format:  "json",
+			results: []result{day1, day2},
+			wantStdout: `{
+  "albums": {
+    "day1.csv": {
+      "sources": [
+        "day1.csv"
+      ],
+      "title": "A sunny evening in Positano",
+      "suggestions": [
+        {
+          "title": "An evening in Italy",
+          "score": 1
+        }
+      ],
+      "photos": 2,
+      "errors": [
+        "invalid photo: unknown location"
+      ]
+    },
+    "day2.csv": {
+      "sources": [
+        "day2.csv"
+      ],
+      "title": "A rainy day in Positano",
+      "photos": 1
+    }
+  }
+}
+`,
+		},
+		"merged album as json": {
+			format:  "json",
+			results: []result{merged},
+			wantStdout: `{
+  "albums": {
+    "merged": {
+      "sources": [
+        "day1.csv",
+        "day2.csv"
+      ],
+      "title": "A rainy weekend in Positano",
+      "photos": 3
+    }
+  }
+}
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			require.NoError(t, writeResults(stdout, stderr, test.format, test.results))
+			require.Equal(t, test.wantStdout, stdout.String())
+			require.Equal(t, test.wantStderr, stderr.String())
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+	"github.com/adrianos93/nomenclator/internal/cache"
+	"github.com/adrianos93/nomenclator/internal/decoder"
+	"github.com/adrianos93/nomenclator/internal/jobs"
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/processor"
+	"github.com/adrianos93/nomenclator/internal/ratelimit"
+	"github.com/adrianos93/nomenclator/internal/rpc"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+)
+
+// runServe serves an HTTP API titling the albums submitted to it as background jobs, which are kept on disk
+// so that they survive a restart, and optionally the Nomenclator gRPC service alongside it.
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "address the API listens on")
+	grpcAddr := fs.String("grpc-addr", "", "address the gRPC service listens on, e.g. :9090. Disabled when not set")
+	jobsDir := fs.String("jobs-dir", "nomenclator-jobs", "folder the jobs and their inputs are kept in")
+	workers := fs.Int("workers", 2, "how many jobs are run at once")
+	rate := fs.Float64("rate", 5, "most requests a second sent to each API, shared by every job, 0 disables the limit")
+	retention := fs.Duration("retention", 7*24*time.Hour, "how long finished jobs are kept for before they are removed, 0 keeps them forever")
+	maxBody := fs.Int64("max-body", jobs.DefaultMaxBody, "size in bytes of the largest album accepted by the API")
+	maxPhotos := fs.Int("max-photos", rpc.DefaultMaxPhotos, "most photos an album sent to the gRPC service can hold, 0 disables the limit")
+	config := newConfig(fs)
+	cacheConfig := newCacheConfig(fs)
+	notifyConfig := newNotifyConfig(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "nomenclator serve")
+		fmt.Fprintln(os.Stderr, "\nAlbums posted to /jobs are titled in the background, see the README for the API.")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	locator, weatherman, err := config.apis()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	// every job builds its own processor, this one only checks the flags before serving
+	if _, err := config.processor(locator, weatherman); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	store, err := jobs.OpenStore(*jobsDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	locatorLimit, weatherLimit := ratelimit.New(*rate), ratelimit.New(*rate)
+	run := titleJob(ctx, config, locator, weatherman, locatorLimit, weatherLimit, notifyConfig)
+	manager, err := jobs.NewManager(store, run, jobs.WithWorkers(*workers), jobs.WithRetention(*retention))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		manager.Run(ctx, func(err error) { fmt.Fprintln(os.Stderr, err) })
+	}()
+	if *grpcAddr != "" {
+		// the cache is shared by every request for as long as the server runs, so it is bounded
+		cachedLocator := cache.NewLocator(locator, locatorLimit, cacheConfig.options()...)
+		cachedWeatherman := cache.NewWeatherman(weatherman, weatherLimit, cacheConfig.options()...)
+		newProcessor := func(l processor.Locator, w processor.Weatherman) (*processor.Processor, error) {
+			return config.processor(l, w)
+		}
+		notifier := notifyConfig.notifier()
+		titled := func(ctx context.Context, result batch.Result) { notifyTitled(ctx, notifier, "grpc", result) }
+		service := rpc.NewServer(newProcessor, cachedLocator, cachedWeatherman, rpc.WithTitled(titled), rpc.WithMaxPhotos(*maxPhotos))
+		if err := serveGRPC(ctx, *grpcAddr, service); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	server := &http.Server{Addr: *addr, Handler: jobs.Handler(manager, jobFormat, jobs.WithMaxBody(*maxBody)), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdown)
+	}()
+	fmt.Fprintf(os.Stderr, "serving on %s\n", *addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintln(os.Stderr, err)
+		stop()
+		<-done
+		os.Exit(1)
+	}
+	<-done
+}
+
+// serveGRPC serves service on addr in the background, stopping gracefully once ctx is done
+func serveGRPC(ctx context.Context, addr string, service *rpc.Server) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	server := grpc.NewServer()
+	rpc.RegisterNomenclatorServer(server, service)
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}()
+	fmt.Fprintf(os.Stderr, "serving gRPC on %s\n", addr)
+	return nil
+}
+
+// titleJob returns the Runner titling the album of a job. Lookups are cached within each job, so that the
+// API calls of a job can be counted, and rate limited across every job.
+func titleJob(
+	serveCtx context.Context,
+	config *config,
+	locatorAPI cache.LocatorAPI,
+	weatherAPI cache.WeatherAPI,
+	locatorLimit, weatherLimit *ratelimit.Limiter,
+	notifyConfig *notifyConfig,
+) jobs.Runner {
+	notifier := notifyConfig.notifier()
+	return func(ctx context.Context, job jobs.Job, input io.Reader, tracker *jobs.Tracker) (*batch.Result, error) {
+		photos, errs, err := decode(input, job.Name, job.Format)
+		if err != nil {
+			return nil, err
+		}
+		tracker.Rows(len(photos) + len(errs))
+		for _, err := range errs {
+			tracker.Row(err)
+		}
+		if len(photos) == 0 {
+			result := batch.NewResult(job.Name, nil, processor.Album{}, errs)
+			return &result, errors.New("no photos found")
+		}
+		albumProcessor, err := config.processor(
+			cache.NewLocator(&jobLocator{ctx: ctx, api: locatorAPI, limiter: locatorLimit, tracker: tracker}, nil),
+			cache.NewWeatherman(&jobWeatherman{ctx: ctx, api: weatherAPI, limiter: weatherLimit, tracker: tracker}, nil),
+			processor.WithProgress(tracker.Row),
+		)
+		if err != nil {
+			return nil, err
+		}
+		album, albumErrs := albumProcessor.ProcessAlbum(photos)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		result := batch.NewResult(job.Name, photos, album, append(errs, albumErrs...))
+		if album.Title == "" {
+			return &result, errors.New("no photo could be titled")
+		}
+		notifyTitled(serveCtx, notifier, "serve", result)
+		return &result, nil
+	}
+}
+
+// jobFormat resolves the format of a submitted input from its name, reading inputs without an extension as CSV
+func jobFormat(name, format string) (string, error) {
+	if format == "" && filepath.Ext(name) == "" {
+		format = "csv"
+	}
+	if _, err := decoder.ForFile(name, format); err != nil {
+		return "", err
+	}
+	return format, nil
+}
+
+// jobLocator is a custom type used to rate limit and count the requests of a job to the geolocation API,
+// failing them without waiting once the job is cancelled
+type jobLocator struct {
+	ctx     context.Context
+	api     cache.LocatorAPI
+	limiter *ratelimit.Limiter
+	tracker *jobs.Tracker
+}
+
+func (l *jobLocator) Locate(latitude, longitude float64) (locator.Location, error) {
+	if err := l.ctx.Err(); err != nil {
+		return locator.Location{}, err
+	}
+	l.limiter.Wait()
+	l.tracker.APICall()
+	return l.api.Locate(latitude, longitude)
+}
+
+// jobWeatherman is a custom type used to rate limit and count the requests of a job to the weather API,
+// failing them without waiting once the job is cancelled
+type jobWeatherman struct {
+	ctx     context.Context
+	api     cache.WeatherAPI
+	limiter *ratelimit.Limiter
+	tracker *jobs.Tracker
+}
+
+func (w *jobWeatherman) CheckWeather(latitude, longitude float64, date time.Time) (weatherman.Forecast, error) {
+	if err := w.ctx.Err(); err != nil {
+		return weatherman.Forecast{}, err
+	}
+	w.limiter.Wait()
+	w.tracker.APICall()
+	return w.api.CheckWeather(latitude, longitude, date)
+}
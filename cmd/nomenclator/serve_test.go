@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/adrianos93/nomenclator/internal/jobs"
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/notify"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+)
+
+type mockLocator struct {
+	mock.Mock
+}
+
+func (l *mockLocator) Locate(latitude, longitude float64) (locator.Location, error) {
+	args := l.Called(latitude, longitude)
+	return args.Get(0).(locator.Location), args.Error(1)
+}
+
+type mockWeatherman struct {
+	mock.Mock
+}
+
+func (w *mockWeatherman) CheckWeather(latitude, longitude float64, date time.Time) (weatherman.Forecast, error) {
+	args := w.Called(latitude, longitude, date)
+	return args.Get(0).(weatherman.Forecast), args.Error(1)
+}
+
+func TestNomenclator_titleJob(t *testing.T) {
+	positano := "2019-10-31T10:52:59Z,40.627883,14.366858\n2019-10-31T12:53:00Z,40.628197,14.367075\n"
+	for name, test := range map[string]struct {
+		format     string
+		input      string
+		flags      []string
+		locateErr  error
+		notifyHook bool
+
+		expectState    jobs.State
+		expectTitle    string
+		expectProgress jobs.Progress
+		expectError    string
+		expectNotified bool
+		expectDetails  bool
+	}{
+		"titled and notified": {
+			input:          positano,
+			notifyHook:     true,
+			expectState:    jobs.Done,
+			expectTitle:    "A sunny day in Positano",
+			expectProgress: jobs.Progress{Rows: 2, Done: 2, APICalls: 3},
+			expectNotified: true,
+		},
+		"structured album": {
+			input:          positano,
+			flags:          []string{"-suggestions", "2", "-explain"},
+			notifyHook:     true,
+			expectState:    jobs.Done,
+			expectTitle:    "A sunny day in Positano",
+			expectProgress: jobs.Progress{Rows: 2, Done: 2, APICalls: 3},
+			expectNotified: true,
+			expectDetails:  true,
+		},
+		"invalid rows are counted": {
+			input:          positano + "yesterday,40.6,14.3\n",
+			expectState:    jobs.Done,
+			expectTitle:    "A sunny day in Positano",
+			expectProgress: jobs.Progress{Rows: 3, Done: 3, Errors: 1, APICalls: 3},
+		},
+		"no photos": {
+			input:          "yesterday,40.6,14.3\n",
+			notifyHook:     true,
+			expectState:    jobs.Failed,
+			expectProgress: jobs.Progress{Rows: 1, Done: 1, Errors: 1},
+			expectError:    "no photos found",
+		},
+		"no photo could be titled": {
+			input:          positano,
+			locateErr:      errors.New("geolocation API unavailable"),
+			notifyHook:     true,
+			expectState:    jobs.Failed,
+			expectProgress: jobs.Progress{Rows: 2, Done: 2, Errors: 2, APICalls: 2},
+			expectError:    "no photo could be titled",
+		},
+		"unknown format": {
+			format:      "xls",
+			input:       positano,
+			expectState: jobs.Failed,
+			expectError: `unknown input format "xls", use one of csv, geojson, jsonl, kml`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			notified := make(chan notify.Event, 1)
+			hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var event notify.Event
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+				notified <- event
+			}))
+			defer hook.Close()
+			fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+			config, notifyConfig := newConfig(fs), newNotifyConfig(fs)
+			args := append([]string{}, test.flags...)
+			if test.notifyHook {
+				args = append(args, "-webhook", hook.URL)
+			}
+			require.NoError(t, fs.Parse(args))
+
+			l := &mockLocator{}
+			w := &mockWeatherman{}
+			l.On("Locate", mock.Anything, mock.Anything).Return(locator.Location{City: "Positano", Country: "Italy"}, test.locateErr)
+			w.On("CheckWeather", mock.Anything, mock.Anything, mock.Anything).Return(weatherman.Forecast{Conditions: "Clear"}, nil)
+			store, err := jobs.OpenStore(t.TempDir())
+			require.NoError(t, err)
+			ctx, cancel := context.WithCancel(context.Background())
+			run := titleJob(ctx, config, l, w, nil, nil, notifyConfig)
+			m, err := jobs.NewManager(store, run)
+			require.NoError(t, err)
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				m.Run(ctx, func(err error) { t.Errorf("unexpected error: %v", err) })
+			}()
+			defer func() {
+				cancel()
+				<-done
+			}()
+
+			submitted, err := m.Submit("positano.csv", test.format, strings.NewReader(test.input))
+			require.NoError(t, err)
+			var job jobs.Job
+			require.Eventually(t, func() bool {
+				job, err = m.Get(submitted.ID)
+				require.NoError(t, err)
+				return job.State.Finished()
+			}, 5*time.Second, time.Millisecond)
+			require.Equal(t, test.expectState, job.State)
+			require.Equal(t, test.expectProgress, job.Progress)
+			require.Equal(t, test.expectError, job.Error)
+			if test.expectTitle != "" {
+				require.Equal(t, test.expectTitle, job.Result.Title)
+			}
+			if test.expectDetails {
+				require.NotEmpty(t, job.Result.Suggestions)
+				require.NotNil(t, job.Result.Explanation)
+				require.NotEmpty(t, job.Result.Explanation.Decisions)
+			}
+			if test.expectNotified {
+				event := <-notified
+				require.Equal(t, "serve", event.Mode)
+				require.Equal(t, test.expectTitle, event.Album.Title)
+				require.Equal(t, job.Result.Suggestions, event.Album.Suggestions)
+			} else {
+				require.Empty(t, notified)
+			}
+		})
+	}
+}
+
+func TestNomenclator_jobFormat(t *testing.T) {
+	for name, test := range map[string]struct {
+		name, format string
+
+		want    string
+		wantErr bool
+	}{
+		"detected from the name": {
+			name: "positano.kml",
+		},
+		"requested format": {
+			name:   "positano.txt",
+			format: "jsonl",
+			want:   "jsonl",
+		},
+		"csv without an extension": {
+			name: "positano",
+			want: "csv",
+		},
+		"csv without a name": {
+			want: "csv",
+		},
+		"unsupported extension": {
+			name:    "positano.xls",
+			wantErr: true,
+		},
+		"unsupported format": {
+			name:    "positano",
+			format:  "xls",
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := jobFormat(test.name, test.format)
+			if (err != nil) != test.wantErr {
+				t.Errorf("jobFormat() error = %v, wantErr %v", err, test.wantErr)
+			}
+			require.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+	"github.com/adrianos93/nomenclator/internal/cache"
+	"github.com/adrianos93/nomenclator/internal/ratelimit"
+	"github.com/adrianos93/nomenclator/internal/watch"
+)
+
+// runWatch monitors a root folder, retitling an album folder whenever its images or photo metadata files
+// are added, modified or removed. Updated results are written to the standard output or, once titled, posted to a webhook.
+func runWatch(args []string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	interval := fs.Duration("interval", 2*time.Second, "how often the folders are scanned for changes")
+	debounce := fs.Duration("debounce", 5*time.Second, "how long an album folder must be left unchanged before it is retitled")
+	output := fs.String("output", "text", "output format, one of text, json")
+	rate := fs.Float64("rate", 5, "most requests a second sent to each API, 0 disables the limit")
+	config := newConfig(fs)
+	cacheConfig := newCacheConfig(fs)
+	notifyConfig := newNotifyConfig(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "nomenclator watch ROOT")
+		fmt.Fprintln(os.Stderr, "\nEvery folder below ROOT holding images or photo metadata files is titled as an album, then retitled whenever its files change.")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "You must specify the root folder of the albums\n\nUsage:")
+		fs.Usage()
+		os.Exit(1)
+	}
+	if *output != "text" && *output != "json" {
+		fmt.Fprintf(os.Stderr, "Unknown output format %q\n\nUsage:\n", *output)
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	locator, weatherman, err := config.apis()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	// lookups are cached for the whole watch, so that retitling an album only looks up its new photos
+	albumProcessor, err := config.processor(
+		cache.NewLocator(locator, ratelimit.New(*rate), cacheConfig.options()...),
+		cache.NewWeatherman(weatherman, ratelimit.New(*rate), cacheConfig.options()...),
+	)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	notifier := notifyConfig.notifier()
+	isAlbumFile := albumFileExcept()
+	changed := func(folders []string) {
+		for _, dir := range folders {
+			folder, err := albumFolder(dir, isAlbumFile)
+			if err != nil {
+				if !os.IsNotExist(err) {
+					fmt.Fprintln(os.Stderr, err)
+				}
+				continue
+			}
+			if len(folder.Files) == 0 {
+				continue
+			}
+			result := titleFolder(albumProcessor, folder)
+			if len(result.Errors) > 0 {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", folder.Path, strings.Join(result.Errors, "; "))
+			}
+			// only titled albums are posted, untitled folders are still written out so that they are not lost
+			if notifier != nil && result.Title != "" {
+				notifyTitled(ctx, notifier, "watch", result)
+				continue
+			}
+			if err := writeWatchResult(os.Stdout, *output, result); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+	}
+	failed := func(err error) { fmt.Fprintln(os.Stderr, err) }
+	watch.New(fs.Arg(0), isAlbumFile, watch.WithInterval(*interval), watch.WithDebounce(*debounce)).Run(ctx, changed, failed)
+}
+
+// writeWatchResult writes the result of a retitled album folder to w in format
+func writeWatchResult(w io.Writer, format string, result batch.Result) error {
+	if format == "json" {
+		return json.NewEncoder(w).Encode(result)
+	}
+	_, err := fmt.Fprintf(w, "%s: %s\n", result.Folder, result.Title)
+	return err
+}
@@ -2,9 +2,20 @@ module github.com/adrianos93/nomenclator
 
 go 1.17
 
-require github.com/stretchr/testify v1.7.0
+require (
+	github.com/stretchr/testify v1.7.0
+	google.golang.org/grpc v1.53.0
+	google.golang.org/protobuf v1.28.1
+)
 
-require github.com/stretchr/objx v0.1.0 // indirect
+require (
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/stretchr/objx v0.1.0 // indirect
+	golang.org/x/net v0.5.0 // indirect
+	golang.org/x/sys v0.4.0 // indirect
+	golang.org/x/text v0.6.0 // indirect
+	google.golang.org/genproto v0.0.0-20230110181048-76db0878b65f // indirect
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.0 // indirect
@@ -0,0 +1,207 @@
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+)
+
+// Mode is the way a title is applied to its album folder
+type Mode string
+
+const (
+	// Rename renames the folder after its slugified title, e.g. a-sunny-weekend-in-positano
+	Rename Mode = "rename"
+	// XMP writes an album.xmp sidecar holding the title as dc:title
+	XMP Mode = "xmp"
+	// Manifest writes an album.json manifest holding the title and the stats of the album
+	Manifest Mode = "manifest"
+)
+
+// Sidecar and manifest file names written in album folders
+const (
+	SidecarFile  = "album.xmp"
+	ManifestFile = "album.json"
+)
+
+// Operation is the kind of change made to the file system
+type Operation string
+
+const (
+	RenameOperation Operation = "rename"
+	WriteOperation  Operation = "write"
+)
+
+// Change is a custom type used to describe a change applying a title, and how to undo it.
+// Renames move From to To, writes replace the content of To, which was Created unless it held Previous.
+type Change struct {
+	Operation Operation `json:"operation"`
+	From      string    `json:"from,omitempty"`
+	To        string    `json:"to"`
+	Content   []byte    `json:"-"`
+	Previous  []byte    `json:"previous,omitempty"`
+	Created   bool      `json:"created,omitempty"`
+}
+
+// manifest is used to describe an album in its album.json manifest
+type manifest struct {
+	Title  string     `json:"title"`
+	Photos int        `json:"photos"`
+	Start  *time.Time `json:"start,omitempty"`
+	End    *time.Time `json:"end,omitempty"`
+}
+
+// ParseModes is used to parse a comma separated list of modes, e.g. "xmp,rename"
+func ParseModes(value string) ([]Mode, error) {
+	var modes []Mode
+	for _, name := range strings.Split(value, ",") {
+		switch mode := Mode(strings.TrimSpace(name)); mode {
+		case Rename, XMP, Manifest:
+			modes = append(modes, mode)
+		default:
+			return nil, fmt.Errorf("unknown apply mode %q, use one of rename, xmp, manifest", name)
+		}
+	}
+	return modes, nil
+}
+
+// Plan is used to return the changes applying the title of an album to its folder in modes. Files are written before
+// the folder is renamed, and changes leaving the folder as it is are left out.
+func Plan(result batch.Result, modes []Mode) ([]Change, error) {
+	if result.Title == "" {
+		return nil, fmt.Errorf("%s has no title to apply", result.Folder)
+	}
+	folder, err := filepath.Abs(result.Folder)
+	if err != nil {
+		return nil, err
+	}
+	var changes []Change
+	for _, mode := range []Mode{XMP, Manifest, Rename} {
+		if !includes(modes, mode) {
+			continue
+		}
+		var change *Change
+		switch mode {
+		case XMP:
+			change, err = write(filepath.Join(folder, SidecarFile), sidecar(result.Title))
+		case Manifest:
+			content, _ := json.MarshalIndent(manifest{Title: result.Title, Photos: result.Photos, Start: result.Start, End: result.End}, "", "  ")
+			change, err = write(filepath.Join(folder, ManifestFile), append(content, '\n'))
+		case Rename:
+			change, err = rename(folder, Slug(result.Title))
+		}
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+	return changes, nil
+}
+
+// write is a helper function used to plan writing content to file, unless it already holds it
+func write(file string, content []byte) (*Change, error) {
+	previous, err := os.ReadFile(file)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return &Change{Operation: WriteOperation, To: file, Content: content, Created: true}, nil
+	case err != nil:
+		return nil, err
+	case bytes.Equal(previous, content):
+		return nil, nil
+	}
+	return &Change{Operation: WriteOperation, To: file, Content: content, Previous: previous}, nil
+}
+
+// rename is a helper function used to plan renaming folder to name, unless it is already named so.
+// When another folder already has the name, a counter is appended to it, e.g. a-sunny-day-in-rome-2.
+func rename(folder, name string) (*Change, error) {
+	if name == "" {
+		return nil, fmt.Errorf("the title of %s has no characters usable in a folder name", folder)
+	}
+	parent := filepath.Dir(folder)
+	target := filepath.Join(parent, name)
+	for i := 2; ; i++ {
+		if target == folder {
+			return nil, nil
+		}
+		_, err := os.Lstat(target)
+		if errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// the name is cut to leave room for the suffix, so that the folder name stays within MaxNameLength
+		suffix := fmt.Sprintf("-%d", i)
+		target = filepath.Join(parent, truncate(name, MaxNameLength-len(suffix))+suffix)
+	}
+	return &Change{Operation: RenameOperation, From: folder, To: target}, nil
+}
+
+// sidecar is a helper function used to return an XMP packet holding title as dc:title
+func sidecar(title string) []byte {
+	escaped := &bytes.Buffer{}
+	_ = xml.EscapeText(escaped, []byte(title))
+	return []byte(`<?xpacket begin="` + "\ufeff" + `" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/">
+   <dc:title>
+    <rdf:Alt>
+     <rdf:li xml:lang="x-default">` + escaped.String() + `</rdf:li>
+    </rdf:Alt>
+   </dc:title>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>
+`)
+}
+
+// includes is a helper function used to tell whether modes holds mode
+func includes(modes []Mode, mode Mode) bool {
+	for _, m := range modes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff is used to describe a change in the unified diff format, for dry runs
+func (c Change) Diff() string {
+	if c.Operation == RenameOperation {
+		return fmt.Sprintf("rename from %s\nrename to %s\n", c.From, c.To)
+	}
+	buf := &strings.Builder{}
+	from := c.To
+	if c.Created {
+		from = "/dev/null"
+	}
+	fmt.Fprintf(buf, "--- %s\n+++ %s\n", from, c.To)
+	for _, line := range lines(c.Previous) {
+		fmt.Fprintf(buf, "-%s\n", line)
+	}
+	for _, line := range lines(c.Content) {
+		fmt.Fprintf(buf, "+%s\n", line)
+	}
+	return buf.String()
+}
+
+// lines is a helper function used to split content into lines
+func lines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+}
@@ -0,0 +1,158 @@
+package apply
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseModes(t *testing.T) {
+	for name, test := range map[string]struct {
+		value string
+
+		expect  []Mode
+		wantErr bool
+	}{
+		"single":  {value: "rename", expect: []Mode{Rename}},
+		"several": {value: "xmp, manifest", expect: []Mode{XMP, Manifest}},
+		"unknown": {value: "xmp,exif", wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseModes(test.value)
+			if (err != nil) != test.wantErr {
+				t.Errorf("ParseModes() error = %v, wantErr %v", err, test.wantErr)
+			}
+			require.Equal(t, test.expect, got)
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	start := time.Date(2019, 7, 5, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2019, 7, 7, 18, 0, 0, 0, time.UTC)
+	manifestContent := `{
+  "title": "A sunny weekend in Positano",
+  "photos": 42,
+  "start": "2019-07-05T09:00:00Z",
+  "end": "2019-07-07T18:00:00Z"
+}
+`
+	for name, test := range map[string]struct {
+		title    string
+		modes    []Mode
+		existing map[string]string
+
+		expect  func(root string) []Change
+		wantErr bool
+	}{
+		"writes files before renaming": {
+			title: "A sunny weekend in Positano",
+			modes: []Mode{Rename, Manifest, XMP},
+			expect: func(root string) []Change {
+				return []Change{
+					{Operation: WriteOperation, To: filepath.Join(root, "IMG_2019", SidecarFile), Content: sidecar("A sunny weekend in Positano"), Created: true},
+					{Operation: WriteOperation, To: filepath.Join(root, "IMG_2019", ManifestFile), Content: []byte(manifestContent), Created: true},
+					{Operation: RenameOperation, From: filepath.Join(root, "IMG_2019"), To: filepath.Join(root, "a-sunny-weekend-in-positano")},
+				}
+			},
+		},
+		"replaces a manifest": {
+			title:    "A sunny weekend in Positano",
+			modes:    []Mode{Manifest},
+			existing: map[string]string{"IMG_2019/album.json": `{"title": "Positano"}`},
+			expect: func(root string) []Change {
+				return []Change{
+					{Operation: WriteOperation, To: filepath.Join(root, "IMG_2019", ManifestFile), Content: []byte(manifestContent), Previous: []byte(`{"title": "Positano"}`)},
+				}
+			},
+		},
+		"already applied": {
+			title:    "A sunny weekend in Positano",
+			modes:    []Mode{Manifest},
+			existing: map[string]string{"IMG_2019/album.json": manifestContent},
+			expect:   func(root string) []Change { return nil },
+		},
+		"name taken": {
+			title: "A sunny weekend in Positano",
+			modes: []Mode{Rename},
+			existing: map[string]string{
+				"a-sunny-weekend-in-positano/album.json":   "{}",
+				"a-sunny-weekend-in-positano-2/album.json": "{}",
+			},
+			expect: func(root string) []Change {
+				return []Change{
+					{Operation: RenameOperation, From: filepath.Join(root, "IMG_2019"), To: filepath.Join(root, "a-sunny-weekend-in-positano-3")},
+				}
+			},
+		},
+		"long name taken": {
+			title: "A sunny week through" + strings.Repeat(" Positano", 26),
+			modes: []Mode{Rename},
+			existing: map[string]string{
+				"a-sunny-week-through" + strings.Repeat("-positano", 26) + "/album.json": "{}",
+			},
+			expect: func(root string) []Change {
+				name := "a-sunny-week-through" + strings.Repeat("-positano", 25) + "-2"
+				return []Change{{Operation: RenameOperation, From: filepath.Join(root, "IMG_2019"), To: filepath.Join(root, name)}}
+			},
+		},
+		"no title": {
+			modes:   []Mode{Rename},
+			wantErr: true,
+		},
+		"no usable characters": {
+			title:   "東京",
+			modes:   []Mode{Rename},
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			require.NoError(t, os.Mkdir(filepath.Join(root, "IMG_2019"), 0o755))
+			for file, content := range test.existing {
+				path := filepath.Join(root, filepath.FromSlash(file))
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+			}
+			result := batch.Result{Folder: filepath.Join(root, "IMG_2019"), Title: test.title, Photos: 42, Read: 42, Start: &start, End: &end}
+			got, err := Plan(result, test.modes)
+			if (err != nil) != test.wantErr {
+				t.Errorf("Plan() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			require.Equal(t, test.expect(root), got)
+		})
+	}
+}
+
+func TestChange_Diff(t *testing.T) {
+	for name, test := range map[string]struct {
+		change Change
+
+		expect string
+	}{
+		"rename": {
+			change: Change{Operation: RenameOperation, From: "/archive/IMG_2019", To: "/archive/a-sunny-weekend-in-positano"},
+			expect: "rename from /archive/IMG_2019\nrename to /archive/a-sunny-weekend-in-positano\n",
+		},
+		"created": {
+			change: Change{Operation: WriteOperation, To: "/archive/IMG_2019/album.json", Content: []byte("{\n  \"title\": \"A sunny weekend\"\n}\n"), Created: true},
+			expect: "--- /dev/null\n+++ /archive/IMG_2019/album.json\n+{\n+  \"title\": \"A sunny weekend\"\n+}\n",
+		},
+		"replaced": {
+			change: Change{Operation: WriteOperation, To: "/archive/IMG_2019/album.json", Content: []byte("{\"title\": \"A sunny weekend\"}\n"), Previous: []byte("{\"title\": \"Positano\"}")},
+			expect: "--- /archive/IMG_2019/album.json\n+++ /archive/IMG_2019/album.json\n-{\"title\": \"Positano\"}\n+{\"title\": \"A sunny weekend\"}\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expect, test.change.Diff())
+		})
+	}
+}
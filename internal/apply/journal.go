@@ -0,0 +1,159 @@
+package apply
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Journal is a custom type used to record the changes applied, one JSON change per line, so that they can be undone
+type Journal struct {
+	file *os.File
+}
+
+// OpenJournal is used to open the journal file at path for appending changes, creating it when missing
+func OpenJournal(path string) (*Journal, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	return &Journal{file: file}, nil
+}
+
+// Apply is used to make a change and record it in the journal. Files are replaced atomically, and folders are
+// never renamed over existing ones.
+func (j *Journal) Apply(change Change) error {
+	switch change.Operation {
+	case RenameOperation:
+		if _, err := os.Lstat(change.To); !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("cannot rename %s: %s already exists", change.From, change.To)
+		}
+		if err := os.Rename(change.From, change.To); err != nil {
+			return err
+		}
+	case WriteOperation:
+		if err := writeFile(change.To, change.Content); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown operation %q", change.Operation)
+	}
+	line, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to record %s in the journal: %w", change.To, err)
+	}
+	return j.file.Sync()
+}
+
+// Close is used to close the journal file
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
+
+// Undo is used to revert every change recorded in the journal file at path, from the latest, removing the file once
+// done. When a change cannot be reverted the journal keeps it and the changes before it, so that undo can be retried.
+func Undo(path string) ([]Change, error) {
+	changes, err := readJournal(path)
+	if err != nil {
+		return nil, err
+	}
+	undone := make([]Change, 0, len(changes))
+	for i := len(changes) - 1; i >= 0; i-- {
+		if err := revert(changes[i]); err != nil {
+			if writeErr := writeJournal(path, changes[:i+1]); writeErr != nil {
+				return undone, fmt.Errorf("%v, and failed to update the journal: %w", err, writeErr)
+			}
+			return undone, err
+		}
+		undone = append(undone, changes[i])
+	}
+	return undone, os.Remove(path)
+}
+
+// revert is a helper function used to undo a change
+func revert(change Change) error {
+	switch change.Operation {
+	case RenameOperation:
+		if _, err := os.Lstat(change.From); !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("cannot rename %s back: %s already exists", change.To, change.From)
+		}
+		return os.Rename(change.To, change.From)
+	case WriteOperation:
+		if change.Created {
+			if err := os.Remove(change.To); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			return nil
+		}
+		return writeFile(change.To, change.Previous)
+	}
+	return fmt.Errorf("unknown operation %q", change.Operation)
+}
+
+// readJournal is a helper function used to read the changes recorded in a journal file
+func readJournal(path string) ([]Change, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer file.Close()
+	var changes []Change
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var change Change
+		if err := json.Unmarshal(scanner.Bytes(), &change); err != nil {
+			return nil, fmt.Errorf("invalid journal entry: %w", err)
+		}
+		changes = append(changes, change)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	return changes, nil
+}
+
+// writeJournal is a helper function used to replace the changes recorded in a journal file
+func writeJournal(path string, changes []Change) error {
+	var content []byte
+	for _, change := range changes {
+		line, err := json.Marshal(change)
+		if err != nil {
+			return err
+		}
+		content = append(append(content, line...), '\n')
+	}
+	return writeFile(path, content)
+}
+
+// writeFile is a helper function used to replace the content of file atomically, through a temporary file
+// in the same folder
+func writeFile(file string, content []byte) error {
+	temp, err := os.CreateTemp(filepath.Dir(file), "."+filepath.Base(file)+"-*")
+	if err != nil {
+		return err
+	}
+	if _, err := temp.Write(content); err != nil {
+		temp.Close()
+		os.Remove(temp.Name())
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		os.Remove(temp.Name())
+		return err
+	}
+	if err := os.Chmod(temp.Name(), 0o644); err != nil {
+		os.Remove(temp.Name())
+		return err
+	}
+	return os.Rename(temp.Name(), file)
+}
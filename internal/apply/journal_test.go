@@ -0,0 +1,94 @@
+package apply
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal_Apply_undo(t *testing.T) {
+	root := t.TempDir()
+	folder := filepath.Join(root, "IMG_2019")
+	require.NoError(t, os.Mkdir(folder, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, ManifestFile), []byte(`{"title": "Positano"}`), 0o644))
+	journalFile := filepath.Join(root, "journal.jsonl")
+
+	changes, err := Plan(batch.Result{Folder: folder, Title: "A sunny weekend in Positano", Photos: 42}, []Mode{XMP, Manifest, Rename})
+	require.NoError(t, err)
+	journal, err := OpenJournal(journalFile)
+	require.NoError(t, err)
+	for _, change := range changes {
+		require.NoError(t, journal.Apply(change))
+	}
+	require.NoError(t, journal.Close())
+
+	renamed := filepath.Join(root, "a-sunny-weekend-in-positano")
+	require.NoDirExists(t, folder)
+	manifest, err := os.ReadFile(filepath.Join(renamed, ManifestFile))
+	require.NoError(t, err)
+	require.Contains(t, string(manifest), `"title": "A sunny weekend in Positano"`)
+	require.FileExists(t, filepath.Join(renamed, SidecarFile))
+
+	undone, err := Undo(journalFile)
+	require.NoError(t, err)
+	require.Len(t, undone, 3)
+	require.NoDirExists(t, renamed)
+	manifest, err = os.ReadFile(filepath.Join(folder, ManifestFile))
+	require.NoError(t, err)
+	require.Equal(t, `{"title": "Positano"}`, string(manifest))
+	require.NoFileExists(t, filepath.Join(folder, SidecarFile))
+	require.NoFileExists(t, journalFile)
+}
+
+func TestJournal_Apply_collision(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"IMG_2019", "a-sunny-weekend-in-positano"} {
+		require.NoError(t, os.Mkdir(filepath.Join(root, dir), 0o755))
+	}
+	journal, err := OpenJournal(filepath.Join(root, "journal.jsonl"))
+	require.NoError(t, err)
+	defer journal.Close()
+
+	err = journal.Apply(Change{Operation: RenameOperation, From: filepath.Join(root, "IMG_2019"), To: filepath.Join(root, "a-sunny-weekend-in-positano")})
+	require.Error(t, err)
+	require.DirExists(t, filepath.Join(root, "IMG_2019"))
+}
+
+func TestUndo_conflict(t *testing.T) {
+	root := t.TempDir()
+	folder := filepath.Join(root, "IMG_2019")
+	renamed := filepath.Join(root, "a-sunny-weekend-in-positano")
+	require.NoError(t, os.Mkdir(folder, 0o755))
+	journalFile := filepath.Join(root, "journal.jsonl")
+
+	journal, err := OpenJournal(journalFile)
+	require.NoError(t, err)
+	require.NoError(t, journal.Apply(Change{Operation: WriteOperation, To: filepath.Join(folder, ManifestFile), Content: []byte("{}"), Created: true}))
+	require.NoError(t, journal.Apply(Change{Operation: RenameOperation, From: folder, To: renamed}))
+	require.NoError(t, journal.Close())
+
+	// a new folder took the original name since, so the rename cannot be reverted.
+	require.NoError(t, os.Mkdir(folder, 0o755))
+	undone, err := Undo(journalFile)
+	require.Error(t, err)
+	require.Empty(t, undone)
+	require.FileExists(t, filepath.Join(renamed, ManifestFile))
+
+	changes, err := readJournal(journalFile)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	require.NoError(t, os.Remove(folder))
+	undone, err = Undo(journalFile)
+	require.NoError(t, err)
+	require.Len(t, undone, 2)
+	require.NoFileExists(t, filepath.Join(folder, ManifestFile))
+}
+
+func TestUndo_missingJournal(t *testing.T) {
+	_, err := Undo(filepath.Join(t.TempDir(), "journal.jsonl"))
+	require.Error(t, err)
+}
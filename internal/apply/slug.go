@@ -0,0 +1,62 @@
+package apply
+
+import (
+	"strings"
+	"unicode"
+)
+
+// folds spells accented Latin letters without their accents, e.g. è as e
+var folds = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+	'ç': "c",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'ñ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'œ': "oe",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u",
+	'ý': "y", 'ÿ': "y",
+	'ß': "ss",
+}
+
+// MaxNameLength is the length in bytes of the longest folder name most file systems allow, i.e. NAME_MAX
+const MaxNameLength = 255
+
+// Slug is used to turn a title into a folder name made of lowercase ASCII letters and digits separated by hyphens,
+// e.g. "Una sera all'ora d'oro a Positano" into una-sera-all-ora-d-oro-a-positano. Names longer than MaxNameLength
+// are cut after their last whole word that fits.
+func Slug(title string) string {
+	slug := &strings.Builder{}
+	hyphen := false
+	for _, r := range strings.ToLower(title) {
+		if fold, found := folds[r]; found {
+			slug.WriteString(fold)
+			hyphen = false
+			continue
+		}
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			slug.WriteRune(r)
+			hyphen = false
+			continue
+		}
+		if !hyphen && slug.Len() > 0 {
+			slug.WriteByte('-')
+			hyphen = true
+		}
+	}
+	return truncate(strings.TrimSuffix(slug.String(), "-"), MaxNameLength)
+}
+
+// truncate is a helper function used to cut a slug to at most n bytes after its last whole word that fits,
+// or within its first word when even that one does not
+func truncate(slug string, n int) string {
+	if len(slug) <= n {
+		return slug
+	}
+	if slug[n] == '-' {
+		return slug[:n]
+	}
+	if i := strings.LastIndexByte(slug[:n], '-'); i > 0 {
+		return slug[:i]
+	}
+	return slug[:n]
+}
@@ -0,0 +1,36 @@
+package apply
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlug(t *testing.T) {
+	for name, test := range map[string]struct {
+		title string
+
+		expect string
+	}{
+		"english":      {title: "A sunny weekend in Positano", expect: "a-sunny-weekend-in-positano"},
+		"apostrophes":  {title: "Una sera all'ora d'oro a Positano", expect: "una-sera-all-ora-d-oro-a-positano"},
+		"accents":      {title: "Un día soleado en Málaga", expect: "un-dia-soleado-en-malaga"},
+		"german":       {title: "Ein sonniges Wochenende in Düsseldorf an der Straße", expect: "ein-sonniges-wochenende-in-dusseldorf-an-der-strasse"},
+		"punctuation":  {title: "  A walking tour through Naples, Amalfi and Positano!  ", expect: "a-walking-tour-through-naples-amalfi-and-positano"},
+		"digits":       {title: "A rainy 4th of July in New York", expect: "a-rainy-4th-of-july-in-new-york"},
+		"no usable":    {title: "東京", expect: ""},
+		"mixed script": {title: "A sunny day in 東京 Tokyo", expect: "a-sunny-day-in-tokyo"},
+		"long title": {
+			title:  "A sunny week through" + strings.Repeat(" Positano", 31) + "!",
+			expect: "a-sunny-week-through" + strings.Repeat("-positano", 26),
+		},
+		"long word": {title: strings.Repeat("a", 300), expect: strings.Repeat("a", MaxNameLength)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got := Slug(test.title)
+			require.Equal(t, test.expect, got)
+			require.LessOrEqual(t, len(got), MaxNameLength)
+		})
+	}
+}
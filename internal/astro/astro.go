@@ -0,0 +1,142 @@
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// Light is the kind of natural light a photo was taken in, depending on the elevation of the sun
+type Light string
+
+const (
+	// Night is when the sun is more than 6° below the horizon, after civil twilight
+	Night Light = "night"
+	// BlueHour is when the sun is between 6° and 4° below the horizon
+	BlueHour Light = "blue hour"
+	// GoldenHour is when the sun is between 4° below and 6° above the horizon
+	GoldenHour Light = "golden hour"
+	// Daylight is when the sun is more than 6° above the horizon
+	Daylight Light = "daylight"
+)
+
+// Position is a custom type used to describe where the sun is in the sky, in degrees
+type Position struct {
+	// Elevation is the angle above the horizon, negative below it
+	Elevation float64
+	// Azimuth is the angle clockwise from north
+	Azimuth float64
+}
+
+// Sun is used to return the position of the sun at an instant, seen from coordinates. It follows the low precision
+// algorithm of the Astronomical Almanac, accurate to about a hundredth of a degree between 1950 and 2050.
+func Sun(t time.Time, latitude, longitude float64) Position {
+	n := days(t)
+	return horizontal(n, latitude, longitude, sunLongitude(n), 0)
+}
+
+// days is a helper function used to return the days elapsed since the J2000.0 epoch
+func days(t time.Time) float64 {
+	return float64(t.UTC().UnixNano())/float64(24*time.Hour) + 2440587.5 - 2451545.0
+}
+
+// sunLongitude is a helper function used to return the ecliptic longitude of the sun in degrees
+func sunLongitude(n float64) float64 {
+	meanLongitude := 280.460 + 0.9856474*n
+	meanAnomaly := radians(normalise(357.528 + 0.9856003*n))
+	return normalise(meanLongitude + 1.915*math.Sin(meanAnomaly) + 0.020*math.Sin(2*meanAnomaly))
+}
+
+// horizontal is a helper function used to convert ecliptic coordinates in degrees to the position in the sky seen from
+// coordinates
+func horizontal(n, latitude, longitude, eclipticLongitude, eclipticLatitude float64) Position {
+	lambda, beta := radians(eclipticLongitude), radians(eclipticLatitude)
+	obliquity := radians(23.439 - 0.0000004*n)
+
+	rightAscension := math.Atan2(math.Sin(lambda)*math.Cos(obliquity)-math.Tan(beta)*math.Sin(obliquity), math.Cos(lambda))
+	declination := math.Asin(math.Sin(beta)*math.Cos(obliquity) + math.Cos(beta)*math.Sin(obliquity)*math.Sin(lambda))
+
+	siderealTime := normalise(280.46061837 + 360.98564736629*n)
+	hourAngle := radians(siderealTime+longitude) - rightAscension
+	phi := radians(latitude)
+
+	elevation := math.Asin(math.Sin(phi)*math.Sin(declination) + math.Cos(phi)*math.Cos(declination)*math.Cos(hourAngle))
+	azimuth := math.Atan2(-math.Sin(hourAngle), math.Tan(declination)*math.Cos(phi)-math.Sin(phi)*math.Cos(hourAngle))
+	return Position{
+		Elevation: degrees(elevation),
+		Azimuth:   normalise(degrees(azimuth)),
+	}
+}
+
+// MoonPhase is a custom type used to describe the moon at an instant, seen from coordinates
+type MoonPhase struct {
+	Position
+	// Phase is the fraction of the lunar month elapsed since the new moon, 0.5 being the full moon
+	Phase float64
+	// Illumination is the fraction of the disc of the moon lit by the sun
+	Illumination float64
+	// Name is the name of the phase, e.g. "waxing crescent" or "full moon"
+	Name string
+}
+
+// phases are the names of the eight phases of the moon, starting from the new moon
+var phases = [...]string{
+	"new moon", "waxing crescent", "first quarter", "waxing gibbous",
+	"full moon", "waning gibbous", "last quarter", "waning crescent",
+}
+
+// Moon is used to return the phase and position of the moon at an instant, seen from coordinates. It follows the low
+// precision algorithm of the Astronomical Almanac, accurate to a few tenths of a degree, and ignores parallax.
+func Moon(t time.Time, latitude, longitude float64) MoonPhase {
+	n := days(t)
+	meanLongitude := 218.316 + 13.176396*n
+	meanAnomaly := radians(normalise(134.963 + 13.064993*n))
+	argument := radians(normalise(93.272 + 13.229350*n))
+	eclipticLongitude := normalise(meanLongitude + 6.289*math.Sin(meanAnomaly))
+	eclipticLatitude := 5.128 * math.Sin(argument)
+
+	elongation := normalise(eclipticLongitude - sunLongitude(n))
+	phase := elongation / 360
+	return MoonPhase{
+		Position:     horizontal(n, latitude, longitude, eclipticLongitude, eclipticLatitude),
+		Phase:        phase,
+		Illumination: (1 - math.Cos(radians(elongation))) / 2,
+		Name:         phases[int(phase*8+0.5)%8],
+	}
+}
+
+// LightAt is used to return the kind of natural light at an instant, seen from coordinates
+func LightAt(t time.Time, latitude, longitude float64) Light {
+	return Classify(Sun(t, latitude, longitude).Elevation)
+}
+
+// Classify is used to return the kind of natural light for an elevation of the sun in degrees
+func Classify(elevation float64) Light {
+	switch {
+	case elevation < -6:
+		return Night
+	case elevation < -4:
+		return BlueHour
+	case elevation < 6:
+		return GoldenHour
+	}
+	return Daylight
+}
+
+// normalise is a helper function used to return an angle in degrees between 0 and 360
+func normalise(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	return angle
+}
+
+// radians is a helper function used to convert degrees to radians
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// degrees is a helper function used to convert radians to degrees
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
@@ -0,0 +1,129 @@
+package astro
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSun(t *testing.T) {
+	for name, test := range map[string]struct {
+		date                string
+		latitude, longitude float64
+
+		expect Position
+	}{
+		"equinox noon on the equator": {
+			date:     "2019-03-20T12:07:00Z",
+			expect:   Position{Elevation: 89.9},
+			latitude: 0, longitude: 0,
+		},
+		"summer solstice noon in las vegas": {
+			date:     "2019-06-21T19:42:00Z",
+			latitude: 36.102825, longitude: -115.173813,
+			expect: Position{Elevation: 77.3, Azimuth: 180},
+		},
+		"sunset in positano": {
+			date:     "2019-10-31T15:59:00Z",
+			latitude: 40.627883, longitude: 14.366858,
+			expect: Position{Elevation: -0.8, Azimuth: 253},
+		},
+		"midnight in positano": {
+			date:     "2019-10-31T23:00:00Z",
+			latitude: 40.627883, longitude: 14.366858,
+			expect: Position{Elevation: -63},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			date, err := time.Parse(time.RFC3339, test.date)
+			require.NoError(t, err)
+			got := Sun(date, test.latitude, test.longitude)
+			require.InDelta(t, test.expect.Elevation, got.Elevation, 1)
+			if test.expect.Azimuth != 0 {
+				require.InDelta(t, test.expect.Azimuth, got.Azimuth, 3)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	for name, test := range map[string]struct {
+		elevation float64
+
+		expect Light
+	}{
+		"deep night":      {elevation: -30, expect: Night},
+		"civil twilight":  {elevation: -5, expect: BlueHour},
+		"sunset":          {elevation: -0.8, expect: GoldenHour},
+		"low sun":         {elevation: 5.9, expect: GoldenHour},
+		"daylight":        {elevation: 6, expect: Daylight},
+		"blue hour start": {elevation: -6, expect: BlueHour},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expect, Classify(test.elevation))
+		})
+	}
+}
+
+func TestLightAt(t *testing.T) {
+	for name, test := range map[string]struct {
+		date string
+
+		expect Light
+	}{
+		"halloween evening": {date: "2019-10-31T18:26:12Z", expect: Night},
+		"late morning":      {date: "2019-10-31T10:28:56Z", expect: Daylight},
+		"sunset":            {date: "2019-10-31T15:45:00Z", expect: GoldenHour},
+		"dusk":              {date: "2019-10-31T16:25:00Z", expect: BlueHour},
+	} {
+		t.Run(name, func(t *testing.T) {
+			date, err := time.Parse(time.RFC3339, test.date)
+			require.NoError(t, err)
+			require.Equal(t, test.expect, LightAt(date, 40.627883, 14.366858))
+		})
+	}
+}
+
+func TestMoon(t *testing.T) {
+	for name, test := range map[string]struct {
+		date string
+
+		expectName         string
+		expectIllumination float64
+		expectUp           bool
+	}{
+		"new moon": {
+			date:               "2019-07-02T19:16:00Z",
+			expectName:         "new moon",
+			expectIllumination: 0,
+		},
+		"first quarter": {
+			date:               "2019-07-09T10:55:00Z",
+			expectName:         "first quarter",
+			expectIllumination: 0.5,
+		},
+		"full moon during the lunar eclipse": {
+			date:               "2019-07-16T21:38:00Z",
+			expectName:         "full moon",
+			expectIllumination: 1,
+			expectUp:           true,
+		},
+		"waning crescent": {
+			date:               "2019-07-29T12:00:00Z",
+			expectName:         "waning crescent",
+			expectIllumination: 0.07,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			date, err := time.Parse(time.RFC3339, test.date)
+			require.NoError(t, err)
+			got := Moon(date, 40.627883, 14.366858)
+			require.Equal(t, test.expectName, got.Name)
+			require.InDelta(t, test.expectIllumination, got.Illumination, 0.03)
+			if test.expectUp {
+				require.Greater(t, got.Elevation, 0.0)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+)
+
+// Folder is a custom type used to describe an album folder and the files its photos are read from
+type Folder struct {
+	Path  string
+	Files []string
+}
+
+// Result is a custom type used to describe the title of an album folder along with its stats, and the structured
+// details of the album enabled on the processor, e.g. its suggestions or travel mode
+type Result struct {
+	Folder      string                 `json:"folder"`
+	Title       string                 `json:"title"`
+	Suggestions []processor.Suggestion `json:"suggestions,omitempty"`
+	// Photos is the number of photos the title is based on, out of the Read ones
+	Photos int `json:"photos"`
+	Read   int `json:"read"`
+	// Start and End are the dates of the first and the last photo
+	Start       *time.Time             `json:"start,omitempty"`
+	End         *time.Time             `json:"end,omitempty"`
+	Travel      *processor.Travel      `json:"travel,omitempty"`
+	Sky         *processor.Sky         `json:"sky,omitempty"`
+	Altitude    *processor.Altitude    `json:"altitude,omitempty"`
+	Errors      []string               `json:"errors,omitempty"`
+	Explanation *processor.Explanation `json:"explanation,omitempty"`
+}
+
+// Titler is a function titling the photos of an album folder
+type Titler func(folder Folder) Result
+
+// Runner is a custom type used to title album folders concurrently
+type Runner struct {
+	title       Titler
+	concurrency int
+	checkpoint  *Checkpoint
+}
+
+type RunnerOptions func(*Runner)
+
+// WithConcurrency sets how many folders are titled at once, one unless set
+func WithConcurrency(concurrency int) RunnerOptions {
+	return func(r *Runner) {
+		if concurrency > 0 {
+			r.concurrency = concurrency
+		}
+	}
+}
+
+// WithCheckpoint enables skipping the folders recorded in checkpoint, and recording every folder titled
+func WithCheckpoint(checkpoint *Checkpoint) RunnerOptions {
+	return func(r *Runner) {
+		r.checkpoint = checkpoint
+	}
+}
+
+// New returns a new Runner titling folders with title
+func New(title Titler, options ...RunnerOptions) *Runner {
+	runner := &Runner{title: title, concurrency: 1}
+	for _, option := range options {
+		option(runner)
+	}
+	return runner
+}
+
+// Discover is used to find the album folders below root, which are the folders directly holding files matching
+// album, e.g. CSV files or images. Hidden folders and files are skipped, e.g. the checkpoint of a batch written
+// inside root. Folders are returned in lexical order.
+func Discover(root string, album func(file string) bool) ([]Folder, error) {
+	var folders []Folder
+	index := map[string]int{}
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(entry.Name(), ".") || !album(path) {
+			return nil
+		}
+		dir := filepath.Dir(path)
+		i, found := index[dir]
+		if !found {
+			i = len(folders)
+			index[dir] = i
+			folders = append(folders, Folder{Path: dir})
+		}
+		folders[i].Files = append(folders[i].Files, path)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+	sort.Slice(folders, func(i, j int) bool { return folders[i].Path < folders[j].Path })
+	return folders, nil
+}
+
+// Run is used to title folders, returning their results in the same order. Folders found in the checkpoint are not
+// titled again. When ctx is cancelled no more folders are started, and the results of the finished and checkpointed
+// ones are returned along with the error of ctx.
+func (r *Runner) Run(ctx context.Context, folders []Folder) ([]Result, error) {
+	results := make([]*Result, len(folders))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var recordErr error
+	for w := 0; w < r.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := r.title(folders[i])
+				results[i] = &result
+				if r.checkpoint == nil || result.Title == "" {
+					continue
+				}
+				if err := r.checkpoint.Record(result); err != nil {
+					mu.Lock()
+					recordErr = err
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i, folder := range folders {
+		if r.checkpoint != nil {
+			if result, done := r.checkpoint.Done(folder.Path); done {
+				results[i] = &result
+				continue
+			}
+		}
+		if ctx.Err() != nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case jobs <- i:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	finished := make([]Result, 0, len(results))
+	for _, result := range results {
+		if result != nil {
+			finished = append(finished, *result)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return finished, err
+	}
+	return finished, recordErr
+}
+
+// NewResult returns the result of titling the photos read from folder, along with the errors met reading them
+func NewResult(folder string, photos []processor.Metadata, album processor.Album, errs []error) Result {
+	result := Result{
+		Folder:      folder,
+		Title:       album.Title,
+		Suggestions: album.Suggestions,
+		Photos:      album.Photos,
+		Read:        len(photos),
+		Travel:      album.Travel,
+		Sky:         album.Sky,
+		Altitude:    album.Altitude,
+		Explanation: album.Explanation,
+	}
+	for _, photo := range photos {
+		date := photo.Date
+		if result.Start == nil || date.Before(*result.Start) {
+			result.Start = &date
+		}
+		if result.End == nil || date.After(*result.End) {
+			end := date
+			result.End = &end
+		}
+	}
+	for _, err := range errs {
+		result.Errors = append(result.Errors, err.Error())
+	}
+	return result
+}
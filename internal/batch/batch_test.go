@@ -0,0 +1,167 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{
+		"2019/amalfi/photos.csv",
+		"2019/amalfi/notes.txt",
+		"2019/rome/IMG_0001.jpg",
+		"2019/rome/IMG_0002.JPG",
+		"2019/rome/day two/IMG_0003.jpg",
+		"2019/.thumbnails/IMG_0001.jpg",
+		"2019/amalfi/.checkpoint.csv",
+		"empty/readme.txt",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, nil, 0o644))
+	}
+	album := func(file string) bool {
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".csv", ".jpg":
+			return true
+		}
+		return false
+	}
+
+	got, err := Discover(root, album)
+	require.NoError(t, err)
+	require.Equal(t, []Folder{
+		{Path: filepath.Join(root, "2019", "amalfi"), Files: []string{filepath.Join(root, "2019", "amalfi", "photos.csv")}},
+		{Path: filepath.Join(root, "2019", "rome"), Files: []string{filepath.Join(root, "2019", "rome", "IMG_0001.jpg"), filepath.Join(root, "2019", "rome", "IMG_0002.JPG")}},
+		{Path: filepath.Join(root, "2019", "rome", "day two"), Files: []string{filepath.Join(root, "2019", "rome", "day two", "IMG_0003.jpg")}},
+	}, got)
+
+	_, err = Discover(filepath.Join(root, "missing"), album)
+	require.Error(t, err)
+}
+
+func TestRunner_Run(t *testing.T) {
+	folders := []Folder{{Path: "amalfi"}, {Path: "naples"}, {Path: "positano"}, {Path: "rome"}}
+	for name, test := range map[string]struct {
+		options    []RunnerOptions
+		checkpoint []Result
+
+		expect       []Result
+		expectTitled []string
+	}{
+		"titles every folder in order": {
+			options: []RunnerOptions{WithConcurrency(3)},
+			expect: []Result{
+				{Folder: "amalfi", Title: "A sunny day in amalfi"},
+				{Folder: "naples", Title: "A sunny day in naples"},
+				{Folder: "positano", Title: "A sunny day in positano"},
+				{Folder: "rome", Title: ""},
+			},
+			expectTitled: []string{"amalfi", "naples", "positano", "rome"},
+		},
+		"resumes from the checkpoint": {
+			checkpoint: []Result{{Folder: "amalfi", Title: "A rainy day in Amalfi"}, {Folder: "positano", Title: "A rainy day in Positano"}},
+			expect: []Result{
+				{Folder: "amalfi", Title: "A rainy day in Amalfi"},
+				{Folder: "naples", Title: "A sunny day in naples"},
+				{Folder: "positano", Title: "A rainy day in Positano"},
+				{Folder: "rome", Title: ""},
+			},
+			expectTitled: []string{"naples", "rome"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+			checkpoint, err := OpenCheckpoint(path)
+			require.NoError(t, err)
+			for _, result := range test.checkpoint {
+				require.NoError(t, checkpoint.Record(result))
+			}
+
+			var mu sync.Mutex
+			var titled []string
+			title := func(folder Folder) Result {
+				mu.Lock()
+				titled = append(titled, folder.Path)
+				mu.Unlock()
+				if folder.Path == "rome" {
+					return Result{Folder: folder.Path, Errors: []string{"quota exceeded"}}
+				}
+				return Result{Folder: folder.Path, Title: "A sunny day in " + folder.Path}
+			}
+			got, err := New(title, append(test.options, WithCheckpoint(checkpoint))...).Run(context.Background(), folders)
+			require.NoError(t, err)
+			for i := range got {
+				got[i].Errors = nil
+			}
+			require.Equal(t, test.expect, got)
+			require.ElementsMatch(t, test.expectTitled, titled)
+			require.NoError(t, checkpoint.Close())
+
+			// folders without a title are not recorded, so that they are retried when resuming.
+			reopened, err := OpenCheckpoint(path)
+			require.NoError(t, err)
+			defer reopened.Close()
+			for _, result := range test.expect {
+				_, done := reopened.Done(result.Folder)
+				require.Equal(t, result.Title != "", done)
+			}
+		})
+	}
+}
+
+func TestRunner_Run_cancelled(t *testing.T) {
+	checkpoint, err := OpenCheckpoint(filepath.Join(t.TempDir(), "checkpoint.jsonl"))
+	require.NoError(t, err)
+	defer checkpoint.Close()
+	require.NoError(t, checkpoint.Record(Result{Folder: "naples", Title: "A rainy day in Naples"}))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	title := func(folder Folder) Result {
+		t.Errorf("folder %s titled after cancellation", folder.Path)
+		return Result{}
+	}
+	got, err := New(title, WithCheckpoint(checkpoint)).Run(ctx, []Folder{{Path: "amalfi"}, {Path: "naples"}, {Path: "positano"}})
+	require.True(t, errors.Is(err, context.Canceled))
+	require.Equal(t, []Result{{Folder: "naples", Title: "A rainy day in Naples"}}, got)
+}
+
+func TestNewResult(t *testing.T) {
+	start := time.Date(2019, 7, 4, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2019, 7, 6, 18, 0, 0, 0, time.UTC)
+	photos := []processor.Metadata{
+		{Latitude: 40.627883, Longitude: 14.366858, Date: end},
+		{Latitude: 40.627808, Longitude: 14.364933, Date: start},
+	}
+	album := processor.Album{
+		Title:       "A sunny weekend in Positano",
+		Suggestions: []processor.Suggestion{{Title: "A sunny weekend in Campania", Score: 0.5}},
+		Photos:      1,
+		Altitude:    &processor.Altitude{Min: 12, Max: 34},
+		Explanation: &processor.Explanation{Decisions: []processor.Decision{{Facet: "weather", Value: "sunny"}}},
+	}
+	got := NewResult("positano", photos, album, []error{errors.New("invalid photo: quota exceeded")})
+	require.Equal(t, Result{
+		Folder:      "positano",
+		Title:       "A sunny weekend in Positano",
+		Suggestions: album.Suggestions,
+		Photos:      1,
+		Read:        2,
+		Start:       &start,
+		End:         &end,
+		Altitude:    album.Altitude,
+		Errors:      []string{"invalid photo: quota exceeded"},
+		Explanation: album.Explanation,
+	}, got)
+}
@@ -0,0 +1,69 @@
+package batch
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Checkpoint is a custom type used to record the album folders already titled, one JSON result per line, so that
+// an interrupted batch can be resumed. It is safe for concurrent use.
+type Checkpoint struct {
+	mu   sync.Mutex
+	file *os.File
+	done map[string]Result
+}
+
+// OpenCheckpoint is used to open the checkpoint file at path, creating it when missing.
+// Lines that cannot be decoded, e.g. one cut short by an interruption, are ignored.
+func OpenCheckpoint(path string) (*Checkpoint, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint: %w", err)
+	}
+	checkpoint := &Checkpoint{file: file, done: map[string]Result{}}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var result Result
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil || result.Folder == "" {
+			continue
+		}
+		checkpoint.done[result.Folder] = result
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	return checkpoint, nil
+}
+
+// Done is used to return the result recorded for folder, if any
+func (c *Checkpoint) Done(folder string) (Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result, found := c.done[folder]
+	return result, found
+}
+
+// Record is used to append the result of a folder to the checkpoint file
+func (c *Checkpoint) Record(result Result) error {
+	line, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to record checkpoint: %w", err)
+	}
+	c.done[result.Folder] = result
+	return nil
+}
+
+// Close is used to close the checkpoint file
+func (c *Checkpoint) Close() error {
+	return c.file.Close()
+}
@@ -0,0 +1,66 @@
+package batch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenCheckpoint(t *testing.T) {
+	for name, test := range map[string]struct {
+		content string
+
+		expect  map[string]Result
+		wantErr bool
+	}{
+		"missing file": {
+			expect: map[string]Result{},
+		},
+		"recorded folders": {
+			content: `{"folder":"amalfi","title":"A sunny day in Amalfi","photos":3,"read":3}
+{"folder":"naples","title":"A rainy day in Naples","photos":2,"read":2}
+`,
+			expect: map[string]Result{
+				"amalfi": {Folder: "amalfi", Title: "A sunny day in Amalfi", Photos: 3, Read: 3},
+				"naples": {Folder: "naples", Title: "A rainy day in Naples", Photos: 2, Read: 2},
+			},
+		},
+		"interrupted while recording": {
+			content: `{"folder":"amalfi","title":"A sunny day in Amalfi","photos":3,"read":3}
+{"folder":"naples","title":"A rai`,
+			expect: map[string]Result{
+				"amalfi": {Folder: "amalfi", Title: "A sunny day in Amalfi", Photos: 3, Read: 3},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+			if test.content != "" {
+				require.NoError(t, os.WriteFile(path, []byte(test.content), 0o644))
+			}
+			got, err := OpenCheckpoint(path)
+			if (err != nil) != test.wantErr {
+				t.Errorf("OpenCheckpoint() error = %v, wantErr %v", err, test.wantErr)
+			}
+			defer got.Close()
+			require.Equal(t, test.expect, got.done)
+		})
+	}
+}
+
+func TestCheckpoint_Record(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+	checkpoint, err := OpenCheckpoint(path)
+	require.NoError(t, err)
+	require.NoError(t, checkpoint.Record(Result{Folder: "amalfi", Title: "A sunny day in Amalfi", Photos: 3, Read: 3}))
+	got, done := checkpoint.Done("amalfi")
+	require.True(t, done)
+	require.Equal(t, "A sunny day in Amalfi", got.Title)
+	require.NoError(t, checkpoint.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, `{"folder":"amalfi","title":"A sunny day in Amalfi","photos":3,"read":3}`+"\n", string(content))
+}
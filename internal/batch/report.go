@@ -0,0 +1,59 @@
+package batch
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// reportHeader lists the columns of the CSV report
+var reportHeader = []string{"folder", "title", "photos", "read", "start", "end", "errors"}
+
+// ReportFormat is used to return the format of a report from the extension of its file, either csv or json
+func ReportFormat(file string) (string, error) {
+	switch format := strings.TrimPrefix(strings.ToLower(filepath.Ext(file)), "."); format {
+	case "csv", "json":
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown report format for %s, use a .csv or .json file", file)
+}
+
+// WriteReport is used to write the results of a batch in format, either csv or json.
+// The CSV report counts the errors of every folder, while the JSON one lists them.
+func WriteReport(w io.Writer, format string, results []Result) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			Albums []Result `json:"albums"`
+		}{results})
+	case "csv":
+		writer := csv.NewWriter(w)
+		if err := writer.Write(reportHeader); err != nil {
+			return err
+		}
+		for _, r := range results {
+			record := []string{r.Folder, r.Title, strconv.Itoa(r.Photos), strconv.Itoa(r.Read), formatDate(r.Start), formatDate(r.End), strconv.Itoa(len(r.Errors))}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+	return fmt.Errorf("unknown report format %q, use one of csv, json", format)
+}
+
+// formatDate is a helper function used to format an optional date for the CSV report
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(time.RFC3339)
+}
@@ -0,0 +1,94 @@
+package batch
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportFormat(t *testing.T) {
+	for name, test := range map[string]struct {
+		file string
+
+		expect  string
+		wantErr bool
+	}{
+		"csv":     {file: "report.csv", expect: "csv"},
+		"json":    {file: "archive/REPORT.JSON", expect: "json"},
+		"unknown": {file: "report.txt", wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := ReportFormat(test.file)
+			if (err != nil) != test.wantErr {
+				t.Errorf("ReportFormat() error = %v, wantErr %v", err, test.wantErr)
+			}
+			require.Equal(t, test.expect, got)
+		})
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	start := time.Date(2019, 7, 4, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2019, 7, 6, 18, 0, 0, 0, time.UTC)
+	results := []Result{
+		{Folder: "2019/positano", Title: "A sunny weekend in Positano", Photos: 2, Read: 3, Start: &start, End: &end, Errors: []string{"invalid photo: quota exceeded"}},
+		{Folder: "2019/empty", Errors: []string{"no photos found"}},
+	}
+	for name, test := range map[string]struct {
+		format string
+
+		expect  string
+		wantErr bool
+	}{
+		"csv": {
+			format: "csv",
+			expect: `folder,title,photos,read,start,end,errors
+2019/positano,A sunny weekend in Positano,2,3,2019-07-04T09:00:00Z,2019-07-06T18:00:00Z,1
+2019/empty,,0,0,,,1
+`,
+		},
+		"json": {
+			format: "json",
+			expect: `{
+  "albums": [
+    {
+      "folder": "2019/positano",
+      "title": "A sunny weekend in Positano",
+      "photos": 2,
+      "read": 3,
+      "start": "2019-07-04T09:00:00Z",
+      "end": "2019-07-06T18:00:00Z",
+      "errors": [
+        "invalid photo: quota exceeded"
+      ]
+    },
+    {
+      "folder": "2019/empty",
+      "title": "",
+      "photos": 0,
+      "read": 0,
+      "errors": [
+        "no photos found"
+      ]
+    }
+  ]
+}
+`,
+		},
+		"unknown": {
+			format:  "xml",
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := WriteReport(buf, test.format, results)
+			if (err != nil) != test.wantErr {
+				t.Errorf("WriteReport() error = %v, wantErr %v", err, test.wantErr)
+			}
+			require.Equal(t, test.expect, buf.String())
+		})
+	}
+}
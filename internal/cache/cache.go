@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/ratelimit"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+)
+
+// LocatorAPI is an interface for the geolocation API, e.g. the locator package
+type LocatorAPI interface {
+	Locate(latitude, longitude float64) (locator.Location, error)
+}
+
+// WeatherAPI is an interface for the weather API, e.g. the weatherman package
+type WeatherAPI interface {
+	CheckWeather(latitude, longitude float64, date time.Time) (weatherman.Forecast, error)
+}
+
+// Locator is a custom type used to remember the places looked up by a LocatorAPI, so that photos taken close
+// to each other, in any album, only cost one request. It is safe for concurrent use.
+type Locator struct {
+	api     LocatorAPI
+	limiter *ratelimit.Limiter
+	entries *store
+}
+
+// Weatherman is a custom type used to remember the forecasts looked up by a WeatherAPI, so that photos taken
+// close to each other on the same day, in any album, only cost one request. It is safe for concurrent use.
+type Weatherman struct {
+	api     WeatherAPI
+	limiter *ratelimit.Limiter
+	entries *store
+}
+
+// Options is a custom type used to configure how many values a Locator or a Weatherman remembers, and for how long
+type Options func(*store)
+
+// WithMaxEntries is used to remember at most n values, forgetting the least recently used ones first.
+// Values are never forgotten for lack of room by default.
+func WithMaxEntries(n int) Options {
+	return func(s *store) {
+		s.maxEntries = n
+	}
+}
+
+// WithTTL is used to forget values once they were looked up for longer than ttl, so that they are looked up again.
+// Values are never forgotten for their age by default.
+func WithTTL(ttl time.Duration) Options {
+	return func(s *store) {
+		s.ttl = ttl
+	}
+}
+
+// NewLocator returns a new Locator calling api at the pace allowed by limiter, which may be nil
+func NewLocator(api LocatorAPI, limiter *ratelimit.Limiter, options ...Options) *Locator {
+	return &Locator{api: api, limiter: limiter, entries: newStore(options...)}
+}
+
+// NewWeatherman returns a new Weatherman calling api at the pace allowed by limiter, which may be nil
+func NewWeatherman(api WeatherAPI, limiter *ratelimit.Limiter, options ...Options) *Weatherman {
+	return &Weatherman{api: api, limiter: limiter, entries: newStore(options...)}
+}
+
+// Locate is used to return the place at coordinates, rounded to about 10 metres
+func (l *Locator) Locate(latitude, longitude float64) (locator.Location, error) {
+	value, err := l.entries.get(fmt.Sprintf("%.4f,%.4f", latitude, longitude), func() (interface{}, error) {
+		l.limiter.Wait()
+		return l.api.Locate(latitude, longitude)
+	})
+	if err != nil {
+		return locator.Location{}, err
+	}
+	return value.(locator.Location), nil
+}
+
+// CheckWeather is used to return the weather on the day of date at coordinates, rounded to about a kilometre
+func (w *Weatherman) CheckWeather(latitude, longitude float64, date time.Time) (weatherman.Forecast, error) {
+	key := fmt.Sprintf("%.2f,%.2f,%s", latitude, longitude, date.Format("2006-01-02"))
+	value, err := w.entries.get(key, func() (interface{}, error) {
+		w.limiter.Wait()
+		return w.api.CheckWeather(latitude, longitude, date)
+	})
+	if err != nil {
+		return weatherman.Forecast{}, err
+	}
+	return value.(weatherman.Forecast), nil
+}
+
+// store is used to hold cached values by key. Concurrent lookups of the same key wait for the first one,
+// and failed lookups are forgotten so that they are retried.
+type store struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	// recent holds the entries from the most to the least recently used
+	recent     *list.List
+	maxEntries int
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+// entry is used to hold a value once its lookup is done
+type entry struct {
+	key     string
+	element *list.Element
+	done    chan struct{}
+	value   interface{}
+	err     error
+	// expires is when the value is forgotten, zero while it is looked up or when it never is
+	expires time.Time
+}
+
+// newStore is a helper function used to return an empty store configured by options
+func newStore(options ...Options) *store {
+	s := &store{entries: map[string]*entry{}, recent: list.New(), now: time.Now}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// get is a helper function used to return the value cached for key, looking it up when missing or expired
+func (s *store) get(key string, lookup func() (interface{}, error)) (interface{}, error) {
+	s.mu.Lock()
+	e, found := s.entries[key]
+	if found && !e.expires.IsZero() && !s.now().Before(e.expires) {
+		s.remove(e)
+		found = false
+	}
+	if found {
+		s.recent.MoveToFront(e.element)
+	} else {
+		e = &entry{key: key, done: make(chan struct{})}
+		s.add(e)
+	}
+	s.mu.Unlock()
+	if found {
+		<-e.done
+		return e.value, e.err
+	}
+
+	e.value, e.err = lookup()
+	s.mu.Lock()
+	switch {
+	case e.err != nil:
+		s.remove(e)
+	case s.ttl > 0:
+		e.expires = s.now().Add(s.ttl)
+	}
+	s.mu.Unlock()
+	close(e.done)
+	return e.value, e.err
+}
+
+// add is a helper function used to cache e, forgetting the least recently used entry when the store is full.
+// It must be called with mu held.
+func (s *store) add(e *entry) {
+	s.entries[e.key] = e
+	e.element = s.recent.PushFront(e)
+	if s.maxEntries > 0 && s.recent.Len() > s.maxEntries {
+		s.remove(s.recent.Back().Value.(*entry))
+	}
+}
+
+// remove is a helper function used to forget e, unless it was already replaced. It must be called with mu held.
+func (s *store) remove(e *entry) {
+	if s.entries[e.key] != e {
+		return
+	}
+	delete(s.entries, e.key)
+	s.recent.Remove(e.element)
+}
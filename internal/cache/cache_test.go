@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockLocator struct {
+	mock.Mock
+}
+
+func (l *mockLocator) Locate(latitude, longitude float64) (locator.Location, error) {
+	args := l.Called(latitude, longitude)
+	return args.Get(0).(locator.Location), args.Error(1)
+}
+
+type mockWeatherman struct {
+	mock.Mock
+}
+
+func (w *mockWeatherman) CheckWeather(latitude, longitude float64, date time.Time) (weatherman.Forecast, error) {
+	args := w.Called(latitude, longitude, date)
+	return args.Get(0).(weatherman.Forecast), args.Error(1)
+}
+
+func TestLocator_Locate(t *testing.T) {
+	positano := locator.Location{Locality: "Positano"}
+	for name, test := range map[string]struct {
+		lookups [][2]float64
+		calls   [][2]float64
+		err     error
+
+		expect    locator.Location
+		expectErr bool
+	}{
+		"looks up nearby photos once": {
+			lookups: [][2]float64{{40.627883, 14.366858}, {40.627880, 14.366860}, {40.627883, 14.366858}},
+			calls:   [][2]float64{{40.627883, 14.366858}},
+			expect:  positano,
+		},
+		"looks up distant photos": {
+			lookups: [][2]float64{{40.627883, 14.366858}, {40.628197, 14.367075}},
+			calls:   [][2]float64{{40.627883, 14.366858}, {40.628197, 14.367075}},
+			expect:  positano,
+		},
+		"retries failed lookups": {
+			lookups:   [][2]float64{{40.627883, 14.366858}, {40.627883, 14.366858}},
+			calls:     [][2]float64{{40.627883, 14.366858}, {40.627883, 14.366858}},
+			err:       errors.New("quota exceeded"),
+			expectErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			api := &mockLocator{}
+			api.Test(t)
+			for _, call := range test.calls {
+				api.On("Locate", call[0], call[1]).Return(positano, test.err).Once()
+			}
+			l := NewLocator(api, nil)
+			for _, lookup := range test.lookups {
+				got, err := l.Locate(lookup[0], lookup[1])
+				require.Equal(t, test.expectErr, err != nil)
+				require.Equal(t, test.expect, got)
+			}
+			api.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLocator_Locate_concurrent(t *testing.T) {
+	api := &mockLocator{}
+	api.Test(t)
+	api.On("Locate", 40.627883, 14.366858).After(10*time.Millisecond).Return(locator.Location{Locality: "Positano"}, nil).Once()
+	l := NewLocator(api, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := l.Locate(40.627883, 14.366858)
+			require.NoError(t, err)
+			require.Equal(t, "Positano", got.Locality)
+		}()
+	}
+	wg.Wait()
+	api.AssertExpectations(t)
+}
+
+func TestLocator_Locate_forgets(t *testing.T) {
+	positano, amalfi, ravello := [2]float64{40.6279, 14.3669}, [2]float64{40.634, 14.6027}, [2]float64{40.6491, 14.6116}
+	for name, test := range map[string]struct {
+		options []Options
+		lookups [][2]float64
+		// elapsed is the time between lookups
+		elapsed time.Duration
+
+		calls [][2]float64
+	}{
+		"forgets the least recently used places": {
+			options: []Options{WithMaxEntries(2)},
+			lookups: [][2]float64{positano, amalfi, positano, ravello, positano, amalfi},
+			calls:   [][2]float64{positano, amalfi, ravello, amalfi},
+		},
+		"remembers places within their ttl": {
+			options: []Options{WithTTL(time.Hour)},
+			lookups: [][2]float64{positano, positano},
+			elapsed: 59 * time.Minute,
+			calls:   [][2]float64{positano},
+		},
+		"forgets places after their ttl": {
+			options: []Options{WithTTL(time.Hour)},
+			lookups: [][2]float64{positano, positano, positano},
+			elapsed: time.Hour,
+			calls:   [][2]float64{positano, positano, positano},
+		},
+		"remembers every place by default": {
+			lookups: [][2]float64{positano, amalfi, ravello, positano},
+			elapsed: 24 * time.Hour,
+			calls:   [][2]float64{positano, amalfi, ravello},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			api := &mockLocator{}
+			api.Test(t)
+			for _, call := range test.calls {
+				api.On("Locate", call[0], call[1]).Return(locator.Location{}, nil).Once()
+			}
+			l := NewLocator(api, nil, test.options...)
+			now := time.Date(2019, 7, 4, 12, 0, 0, 0, time.UTC)
+			l.entries.now = func() time.Time { return now }
+			for _, lookup := range test.lookups {
+				_, err := l.Locate(lookup[0], lookup[1])
+				require.NoError(t, err)
+				now = now.Add(test.elapsed)
+			}
+			api.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWeatherman_CheckWeather(t *testing.T) {
+	morning := time.Date(2019, 7, 4, 9, 0, 0, 0, time.UTC)
+	for name, test := range map[string]struct {
+		dates []time.Time
+		calls []time.Time
+	}{
+		"looks up a day once": {
+			dates: []time.Time{morning, morning.Add(6 * time.Hour)},
+			calls: []time.Time{morning},
+		},
+		"looks up every day": {
+			dates: []time.Time{morning, morning.Add(24 * time.Hour)},
+			calls: []time.Time{morning, morning.Add(24 * time.Hour)},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			api := &mockWeatherman{}
+			api.Test(t)
+			for _, call := range test.calls {
+				api.On("CheckWeather", 40.627883, 14.366858, call).Return(weatherman.Forecast{Conditions: "Clear"}, nil).Once()
+			}
+			w := NewWeatherman(api, nil)
+			for _, date := range test.dates {
+				got, err := w.CheckWeather(40.627883, 14.366858, date)
+				require.NoError(t, err)
+				require.Equal(t, "Clear", got.Conditions)
+			}
+			api.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package calendar
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// Holiday is a custom type used to describe a holiday or named event on a given date
+type Holiday struct {
+	// ID is a stable identifier for the holiday, e.g. christmas
+	ID   string
+	Name string
+	Date time.Time
+	// Night is set for holidays mostly celebrated after dark, e.g. Halloween
+	Night bool
+	// Weekend is set for holidays commonly making up a long weekend, e.g. Easter
+	Weekend bool
+}
+
+// rule is used to describe how to compute the date of a holiday for a given year
+type rule struct {
+	id, name       string
+	night, weekend bool
+	date           func(year int) time.Time
+}
+
+// common lists the holidays observed in every country unless overridden
+var common = []rule{
+	{id: "new-year", name: "New Year's Day", date: fixed(time.January, 1)},
+	{id: "valentines-day", name: "Valentine's Day", date: fixed(time.February, 14)},
+	{id: "easter", name: "Easter", weekend: true, date: Easter},
+	{id: "halloween", name: "Halloween", night: true, date: fixed(time.October, 31)},
+	{id: "christmas-eve", name: "Christmas Eve", night: true, date: fixed(time.December, 24)},
+	{id: "christmas", name: "Christmas", date: fixed(time.December, 25)},
+	{id: "new-years-eve", name: "New Year's Eve", night: true, date: fixed(time.December, 31)},
+}
+
+// countries lists the holidays specific to a country, keyed by ISO 3166-1 alpha-2 code.
+// A rule sharing its id with a common rule replaces it.
+var countries = map[string][]rule{
+	"US": {
+		{id: "st-patricks-day", name: "St. Patrick's Day", date: fixed(time.March, 17)},
+		{id: "independence-day", name: "Fourth of July", night: true, date: fixed(time.July, 4)},
+		{id: "thanksgiving", name: "Thanksgiving", weekend: true, date: nthWeekday(time.November, time.Thursday, 4)},
+	},
+	"CA": {
+		{id: "canada-day", name: "Canada Day", date: fixed(time.July, 1)},
+		{id: "thanksgiving", name: "Thanksgiving", weekend: true, date: nthWeekday(time.October, time.Monday, 2)},
+	},
+	"GB": {
+		{id: "bonfire-night", name: "Bonfire Night", night: true, date: fixed(time.November, 5)},
+		{id: "boxing-day", name: "Boxing Day", date: fixed(time.December, 26)},
+	},
+	"IE": {
+		{id: "st-patricks-day", name: "St. Patrick's Day", date: fixed(time.March, 17)},
+	},
+	"IT": {
+		{id: "epiphany", name: "Epiphany", date: fixed(time.January, 6)},
+		{id: "carnival", name: "Carnival", date: easterOffset(-47)},
+		{id: "ferragosto", name: "Ferragosto", date: fixed(time.August, 15)},
+	},
+	"DE": {
+		{id: "carnival", name: "Carnival", date: easterOffset(-48)},
+		{id: "german-unity-day", name: "German Unity Day", date: fixed(time.October, 3)},
+	},
+	"ES": {
+		{id: "epiphany", name: "Three Kings' Day", date: fixed(time.January, 6)},
+		{id: "holy-week", name: "Holy Week", weekend: true, date: easterOffset(-3)},
+	},
+	"FR": {
+		{id: "bastille-day", name: "Bastille Day", night: true, date: fixed(time.July, 14)},
+	},
+	"MX": {
+		{id: "day-of-the-dead", name: "Day of the Dead", date: fixed(time.November, 2)},
+	},
+	"BR": {
+		{id: "carnival", name: "Carnival", date: easterOffset(-47)},
+	},
+	"AU": {
+		{id: "australia-day", name: "Australia Day", date: fixed(time.January, 26)},
+	},
+	"GR": {
+		{id: "easter", name: "Easter", weekend: true, date: OrthodoxEaster},
+	},
+	"RU": {
+		{id: "easter", name: "Easter", weekend: true, date: OrthodoxEaster},
+		{id: "christmas", name: "Christmas", date: fixed(time.January, 7)},
+	},
+}
+
+// aliases maps the country names and codes returned by geocoding providers to ISO 3166-1 alpha-2 codes
+var aliases = map[string]string{
+	"united states": "US", "united states of america": "US", "usa": "US", "us": "US",
+	"canada": "CA", "can": "CA", "ca": "CA",
+	"united kingdom": "GB", "great britain": "GB", "gbr": "GB", "gb": "GB", "uk": "GB",
+	"ireland": "IE", "irl": "IE", "ie": "IE",
+	"italy": "IT", "italia": "IT", "ita": "IT", "it": "IT",
+	"germany": "DE", "deutschland": "DE", "deu": "DE", "de": "DE",
+	"spain": "ES", "españa": "ES", "esp": "ES", "es": "ES",
+	"france": "FR", "fra": "FR", "fr": "FR",
+	"mexico": "MX", "méxico": "MX", "mex": "MX", "mx": "MX",
+	"brazil": "BR", "brasil": "BR", "bra": "BR", "br": "BR",
+	"australia": "AU", "aus": "AU", "au": "AU",
+	"greece": "GR", "grc": "GR", "gr": "GR",
+	"russia": "RU", "russian federation": "RU", "rus": "RU", "ru": "RU",
+}
+
+// CountryCode is used to return the ISO 3166-1 alpha-2 code of a country name or code.
+// An empty string is returned for countries the calendar knows nothing specific about.
+func CountryCode(country string) string {
+	return aliases[strings.ToLower(strings.TrimSpace(country))]
+}
+
+// Holidays is used to return every holiday observed in a country during a year
+func Holidays(country string, year int) []Holiday {
+	specific := countries[CountryCode(country)]
+	overridden := make(map[string]bool, len(specific))
+	for _, r := range specific {
+		overridden[r.id] = true
+	}
+	rules := make([]rule, 0, len(common)+len(specific))
+	for _, r := range common {
+		if !overridden[r.id] {
+			rules = append(rules, r)
+		}
+	}
+	holidays := make([]Holiday, 0, len(rules)+len(specific))
+	for _, r := range append(rules, specific...) {
+		holidays = append(holidays, Holiday{
+			ID:      r.id,
+			Name:    r.name,
+			Date:    r.date(year),
+			Night:   r.night,
+			Weekend: r.weekend,
+		})
+	}
+	return holidays
+}
+
+// On is used to return the holiday observed in a country on the calendar day of date, if any
+func On(country string, date time.Time) (Holiday, bool) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	for _, holiday := range Holidays(country, date.Year()) {
+		if holiday.Date.Equal(day) {
+			return holiday, true
+		}
+	}
+	return Holiday{}, false
+}
+
+// Season is used to return the meteorological season of date at a latitude, swapping seasons in the
+// southern hemisphere. The tropics have no temperate seasons, so nothing is returned for them.
+func Season(date time.Time, latitude float64) string {
+	if math.Abs(latitude) < 23.44 {
+		return ""
+	}
+	seasons := []string{"winter", "spring", "summer", "autumn"}
+	index := int(date.Month()) % 12 / 3
+	if latitude < 0 {
+		index = (index + 2) % 4
+	}
+	return seasons[index]
+}
+
+// Easter is used to return the date of Western Easter Sunday, using the anonymous Gregorian algorithm
+func Easter(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// OrthodoxEaster is used to return the date of Orthodox Easter Sunday in the Gregorian calendar,
+// using the Meeus Julian algorithm. Valid between 1900 and 2099.
+func OrthodoxEaster(year int) time.Time {
+	a, b, c := year%4, year%7, year%19
+	d := (19*c + 15) % 30
+	e := (2*a + 4*b - d + 34) % 7
+	month := (d + e + 114) / 31
+	day := (d+e+114)%31 + 1
+	return time.Date(year, time.Month(month), day+13, 0, 0, 0, 0, time.UTC)
+}
+
+// fixed is a helper function used to describe holidays falling on the same day every year
+func fixed(month time.Month, day int) func(int) time.Time {
+	return func(year int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// nthWeekday is a helper function used to describe holidays falling on the nth weekday of a month
+func nthWeekday(month time.Month, weekday time.Weekday, n int) func(int) time.Time {
+	return func(year int) time.Time {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+		return first.AddDate(0, 0, offset+7*(n-1))
+	}
+}
+
+// easterOffset is a helper function used to describe holidays falling a number of days from Easter
+func easterOffset(days int) func(int) time.Time {
+	return func(year int) time.Time {
+		return Easter(year).AddDate(0, 0, days)
+	}
+}
@@ -0,0 +1,136 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCalendar_Easter(t *testing.T) {
+	for year, want := range map[int]time.Time{
+		2019: date(2019, time.April, 21),
+		2020: date(2020, time.April, 12),
+		2021: date(2021, time.April, 4),
+		2024: date(2024, time.March, 31),
+		2038: date(2038, time.April, 25),
+	} {
+		require.Equal(t, want, Easter(year), "western easter %d", year)
+	}
+	for year, want := range map[int]time.Time{
+		2019: date(2019, time.April, 28),
+		2020: date(2020, time.April, 19),
+		2021: date(2021, time.May, 2),
+		2024: date(2024, time.May, 5),
+	} {
+		require.Equal(t, want, OrthodoxEaster(year), "orthodox easter %d", year)
+	}
+}
+
+func TestCalendar_On(t *testing.T) {
+	for name, test := range map[string]struct {
+		country string
+		date    time.Time
+
+		want  string
+		found bool
+	}{
+		"christmas everywhere": {
+			country: "Japan",
+			date:    time.Date(2019, time.December, 25, 20, 0, 0, 0, time.UTC),
+			want:    "christmas",
+			found:   true,
+		},
+		"halloween in italy": {
+			country: "Italy",
+			date:    date(2019, time.October, 31),
+			want:    "halloween",
+			found:   true,
+		},
+		"us thanksgiving": {
+			country: "United States",
+			date:    date(2019, time.November, 28),
+			want:    "thanksgiving",
+			found:   true,
+		},
+		"canadian thanksgiving": {
+			country: "CAN",
+			date:    date(2019, time.October, 14),
+			want:    "thanksgiving",
+			found:   true,
+		},
+		"no thanksgiving in italy": {
+			country: "Italy",
+			date:    date(2019, time.November, 28),
+		},
+		"ferragosto": {
+			country: "ITA",
+			date:    date(2020, time.August, 15),
+			want:    "ferragosto",
+			found:   true,
+		},
+		"orthodox easter in greece": {
+			country: "Greece",
+			date:    date(2020, time.April, 19),
+			want:    "easter",
+			found:   true,
+		},
+		"no western easter in greece": {
+			country: "Greece",
+			date:    date(2020, time.April, 12),
+		},
+		"russian christmas": {
+			country: "Russia",
+			date:    date(2020, time.January, 7),
+			want:    "christmas",
+			found:   true,
+		},
+		"no december christmas in russia": {
+			country: "Russia",
+			date:    date(2020, time.December, 25),
+		},
+		"ordinary day": {
+			country: "USA",
+			date:    date(2020, time.March, 30),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, found := On(test.country, test.date)
+			require.Equal(t, test.found, found)
+			require.Equal(t, test.want, got.ID)
+		})
+	}
+}
+
+func TestCalendar_Season(t *testing.T) {
+	for name, test := range map[string]struct {
+		date     time.Time
+		latitude float64
+
+		want string
+	}{
+		"northern winter":         {date: date(2020, time.February, 11), latitude: 40.7, want: "winter"},
+		"northern december":       {date: date(2019, time.December, 1), latitude: 36.1, want: "winter"},
+		"northern spring":         {date: date(2020, time.March, 30), latitude: 40.7, want: "spring"},
+		"northern summer":         {date: date(2020, time.August, 15), latitude: 40.6, want: "summer"},
+		"northern autumn":         {date: date(2019, time.October, 31), latitude: 40.6, want: "autumn"},
+		"southern summer":         {date: date(2019, time.December, 25), latitude: -33.9, want: "summer"},
+		"southern autumn":         {date: date(2020, time.April, 12), latitude: -33.9, want: "autumn"},
+		"tropics have no seasons": {date: date(2020, time.April, 12), latitude: 1.3},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.want, Season(test.date, test.latitude))
+		})
+	}
+}
+
+func TestCalendar_CountryCode(t *testing.T) {
+	require.Equal(t, "US", CountryCode("United States"))
+	require.Equal(t, "US", CountryCode(" usa "))
+	require.Equal(t, "IT", CountryCode("Italia"))
+	require.Equal(t, "", CountryCode("Atlantis"))
+}
@@ -0,0 +1,174 @@
+package coast
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// kilometresPerDegree is the length of a degree of latitude in kilometres
+const kilometresPerDegree = 111.195
+
+// Coastline is a custom type used to describe a stretch of coast as a line through its points, as latitude and longitude
+type Coastline struct {
+	Name   string
+	Points [][2]float64
+	// south, west, north and east bound the points
+	south, west, north, east float64
+}
+
+// Dataset is a custom type used to tell how far coordinates are from the coast
+type Dataset struct {
+	coastlines []Coastline
+}
+
+//go:embed coastline.geojson
+var builtin []byte
+
+// New returns a new Dataset of coastlines
+func New(coastlines []Coastline) *Dataset {
+	d := &Dataset{coastlines: make([]Coastline, 0, len(coastlines))}
+	for _, coastline := range coastlines {
+		if len(coastline.Points) == 0 {
+			continue
+		}
+		coastline.south, coastline.west = coastline.Points[0][0], coastline.Points[0][1]
+		coastline.north, coastline.east = coastline.south, coastline.west
+		for _, point := range coastline.Points {
+			coastline.south, coastline.north = math.Min(coastline.south, point[0]), math.Max(coastline.north, point[0])
+			coastline.west, coastline.east = math.Min(coastline.west, point[1]), math.Max(coastline.east, point[1])
+		}
+		d.coastlines = append(d.coastlines, coastline)
+	}
+	return d
+}
+
+// featureCollection is used to unmarshal the GeoJSON coastlines are read from
+type featureCollection struct {
+	Type     string `json:"type"`
+	Features []struct {
+		Properties struct {
+			Name string `json:"name"`
+		} `json:"properties"`
+		Geometry struct {
+			Type        string          `json:"type"`
+			Coordinates json.RawMessage `json:"coordinates"`
+		} `json:"geometry"`
+	} `json:"features"`
+}
+
+// Load is used to read a Dataset from a GeoJSON FeatureCollection of coastlines, e.g. the Natural Earth coastline.
+// LineString and MultiLineString features are coastlines, as are the rings of Polygon and MultiPolygon features
+// outlining land.
+func Load(r io.Reader) (*Dataset, error) {
+	var collection featureCollection
+	if err := json.NewDecoder(r).Decode(&collection); err != nil {
+		return nil, fmt.Errorf("failed to decode coastlines: %w", err)
+	}
+	if collection.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("coastlines must be a FeatureCollection, not %q", collection.Type)
+	}
+	var coastlines []Coastline
+	for i, feature := range collection.Features {
+		var lines [][][]float64
+		var err error
+		switch feature.Geometry.Type {
+		case "LineString":
+			var line [][]float64
+			err = json.Unmarshal(feature.Geometry.Coordinates, &line)
+			lines = [][][]float64{line}
+		case "MultiLineString", "Polygon":
+			err = json.Unmarshal(feature.Geometry.Coordinates, &lines)
+		case "MultiPolygon":
+			var polygons [][][][]float64
+			err = json.Unmarshal(feature.Geometry.Coordinates, &polygons)
+			for _, polygon := range polygons {
+				lines = append(lines, polygon...)
+			}
+		default:
+			return nil, fmt.Errorf("coastline %d has unsupported geometry %q", i, feature.Geometry.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("coastline %d has invalid coordinates: %w", i, err)
+		}
+		for _, line := range lines {
+			coastline := Coastline{Name: feature.Properties.Name, Points: make([][2]float64, 0, len(line))}
+			for _, position := range line {
+				if len(position) < 2 {
+					return nil, fmt.Errorf("coastline %d has invalid position %v", i, position)
+				}
+				// GeoJSON positions are longitude first
+				coastline.Points = append(coastline.Points, [2]float64{position[1], position[0]})
+			}
+			coastlines = append(coastlines, coastline)
+		}
+	}
+	return New(coastlines), nil
+}
+
+// Builtin is used to return the Dataset bundled with nomenclator. It is a coarse outline of popular stretches of coast,
+// accurate to about a kilometre, rather than a complete coastline.
+func Builtin() *Dataset {
+	dataset, err := Load(bytes.NewReader(builtin))
+	if err != nil {
+		panic(fmt.Errorf("invalid builtin coastlines: %w", err))
+	}
+	return dataset
+}
+
+// Distance is used to return the distance in kilometres from coordinates to the nearest coastline, along with its name,
+// or an infinite distance when the Dataset is empty
+func (d *Dataset) Distance(latitude, longitude float64) (float64, string) {
+	best, name := math.Inf(1), ""
+	for _, coastline := range d.coastlines {
+		if boundsDistance(latitude, longitude, coastline) >= best {
+			continue
+		}
+		points := coastline.Points
+		for i := range points {
+			from, to := points[i], points[i]
+			if i > 0 {
+				from = points[i-1]
+			}
+			if distance := segmentDistance(latitude, longitude, from, to); distance < best {
+				best, name = distance, coastline.Name
+			}
+		}
+	}
+	return best, name
+}
+
+// project is a helper function used to project a point onto a plane tangent to the Earth at latitude and longitude,
+// in kilometres east and north of it. Distances are accurate to a few percent up to a few hundred kilometres.
+func project(latitude, longitude float64, point [2]float64) (x, y float64) {
+	dLon := math.Mod(point[1]-longitude+540, 360) - 180
+	return dLon * math.Cos(latitude*math.Pi/180) * kilometresPerDegree, (point[0] - latitude) * kilometresPerDegree
+}
+
+// segmentDistance is a helper function used to return the distance in kilometres from coordinates to a segment
+func segmentDistance(latitude, longitude float64, from, to [2]float64) float64 {
+	ax, ay := project(latitude, longitude, from)
+	bx, by := project(latitude, longitude, to)
+	dx, dy := bx-ax, by-ay
+	t := 0.0
+	if length := dx*dx + dy*dy; length > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/length))
+	}
+	return math.Hypot(ax+t*dx, ay+t*dy)
+}
+
+// boundsDistance is a helper function used to return a lower bound of the distance in kilometres from coordinates
+// to a coastline, from the box bounding its points
+func boundsDistance(latitude, longitude float64, coastline Coastline) float64 {
+	if coastline.east-coastline.west > 180 {
+		return 0
+	}
+	nearestLat := math.Max(coastline.south, math.Min(coastline.north, latitude))
+	nearestLon := math.Max(coastline.west, math.Min(coastline.east, longitude))
+	dLat := (latitude - nearestLat) * kilometresPerDegree
+	dLon := math.Abs(math.Mod(longitude-nearestLon+540, 360)-180) * math.Cos(math.Max(math.Abs(latitude), math.Abs(nearestLat))*math.Pi/180) * kilometresPerDegree
+	return math.Hypot(dLat, dLon)
+}
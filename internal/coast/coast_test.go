@@ -0,0 +1,138 @@
+package coast
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltin(t *testing.T) {
+	for name, test := range map[string]struct {
+		latitude, longitude float64
+
+		expectNear bool
+		expectName string
+	}{
+		"positano": {
+			latitude:   40.628197,
+			longitude:  14.367075,
+			expectNear: true,
+			expectName: "Amalfi Coast",
+		},
+		"santa monica pier": {
+			latitude:   34.0086,
+			longitude:  -118.4986,
+			expectNear: true,
+			expectName: "Malibu and Santa Monica Bay",
+		},
+		"copacabana": {
+			latitude:   -22.9711,
+			longitude:  -43.1822,
+			expectNear: true,
+			expectName: "Rio de Janeiro",
+		},
+		"rome": {
+			latitude:  41.8905,
+			longitude: 12.4925,
+		},
+		"las vegas": {
+			latitude:  36.1126,
+			longitude: -115.1767,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			distance, coastline := Builtin().Distance(test.latitude, test.longitude)
+			require.Equal(t, test.expectNear, distance <= 2, distance)
+			if test.expectNear {
+				require.Equal(t, test.expectName, coastline)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	for name, test := range map[string]struct {
+		input string
+
+		expect  float64
+		wantErr bool
+	}{
+		"line string": {
+			input:  `{"type": "FeatureCollection", "features": [{"type": "Feature", "properties": {"name": "Equator"}, "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 0]]}}]}`,
+			expect: 11.12,
+		},
+		"multi line string": {
+			input:  `{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": {"type": "MultiLineString", "coordinates": [[[5, 5], [6, 5]], [[0, 0], [1, 0]]]}}]}`,
+			expect: 11.12,
+		},
+		"polygon": {
+			input:  `{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, -1], [0, 0]]]}}]}`,
+			expect: 11.12,
+		},
+		"multi polygon": {
+			input:  `{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": {"type": "MultiPolygon", "coordinates": [[[[0, 0], [1, 0], [1, -1], [0, 0]]]]}}]}`,
+			expect: 11.12,
+		},
+		"empty": {
+			input:  `{"type": "FeatureCollection", "features": []}`,
+			expect: math.Inf(1),
+		},
+		"not a feature collection": {
+			input:   `{"type": "Feature"}`,
+			wantErr: true,
+		},
+		"unsupported geometry": {
+			input:   `{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": {"type": "Point", "coordinates": [0, 0]}}]}`,
+			wantErr: true,
+		},
+		"invalid position": {
+			input:   `{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[0], [1, 0]]}}]}`,
+			wantErr: true,
+		},
+		"invalid json": {
+			input:   `{`,
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := Load(strings.NewReader(test.input))
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			distance, _ := got.Distance(0.1, 0.5)
+			if math.IsInf(test.expect, 1) {
+				require.True(t, math.IsInf(distance, 1))
+				return
+			}
+			require.InDelta(t, test.expect, distance, 0.1)
+		})
+	}
+}
+
+func TestDataset_Distance(t *testing.T) {
+	dataset := New([]Coastline{
+		{Name: "North", Points: [][2]float64{{1, 0}, {1, 1}}},
+		{Name: "Antimeridian", Points: [][2]float64{{-10, 179.5}, {-10, -179.5}}},
+		{Name: "Empty"},
+	})
+	for name, test := range map[string]struct {
+		latitude, longitude float64
+
+		expect     float64
+		expectName string
+	}{
+		"beside a segment":        {latitude: 0.9, longitude: 0.5, expect: 11.12, expectName: "North"},
+		"beyond the end":          {latitude: 1, longitude: 1.1, expect: 11.12, expectName: "North"},
+		"across the antimeridian": {latitude: -10.1, longitude: 180, expect: 11.12, expectName: "Antimeridian"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, coastline := dataset.Distance(test.latitude, test.longitude)
+			require.InDelta(t, test.expect, got, 0.1)
+			require.Equal(t, test.expectName, coastline)
+		})
+	}
+}
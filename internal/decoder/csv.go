@@ -0,0 +1,28 @@
+package decoder
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+)
+
+// decodeCSV is used to decode the date,latitude,longitude CSV schema
+func decodeCSV(r io.Reader) ([]processor.Metadata, []error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, []error{err}
+	}
+	errs := make([]error, 0, len(rows))
+	album := make([]processor.Metadata, 0, len(rows))
+	for _, row := range rows {
+		metadata, err := processor.ParseRow(row)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid photo: %w", err))
+			continue
+		}
+		album = append(album, metadata)
+	}
+	return album, errs
+}
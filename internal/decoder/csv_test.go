@@ -0,0 +1,44 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_decodeCSV(t *testing.T) {
+	for name, test := range map[string]struct {
+		input string
+
+		want     []processor.Metadata
+		wantErrs int
+	}{
+		"valid rows": {
+			input: "2020-03-30T14:12:19Z,40.728808,-73.996106\n2020-03-30T14:20:10Z,40.728656,-73.998790\n",
+			want: []processor.Metadata{
+				{Latitude: 40.728808, Longitude: -73.996106, Date: time.Date(2020, 3, 30, 14, 12, 19, 0, time.UTC)},
+				{Latitude: 40.728656, Longitude: -73.998790, Date: time.Date(2020, 3, 30, 14, 20, 10, 0, time.UTC)},
+			},
+		},
+		"invalid row is skipped": {
+			input: "2020-03-30 14:12:19Z,40.728808,-73.996106\n2020-03-30T14:20:10Z,40.728656,-73.998790\n",
+			want: []processor.Metadata{
+				{Latitude: 40.728656, Longitude: -73.998790, Date: time.Date(2020, 3, 30, 14, 20, 10, 0, time.UTC)},
+			},
+			wantErrs: 1,
+		},
+		"malformed csv": {
+			input:    "2020-03-30T14:12:19Z,40.728808\n2020-03-30T14:20:10Z,40.728656,-73.998790\n",
+			wantErrs: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, errs := decodeCSV(strings.NewReader(test.input))
+			require.Len(t, errs, test.wantErrs)
+			require.ElementsMatch(t, test.want, got)
+		})
+	}
+}
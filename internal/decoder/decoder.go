@@ -0,0 +1,92 @@
+package decoder
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+)
+
+// Decoder is an interface for turning an input file into photo metadata the processor understands.
+// Records that cannot be decoded are returned as errors without stopping the rest of the input.
+type Decoder interface {
+	Decode(r io.Reader) ([]processor.Metadata, []error)
+}
+
+// DecoderFunc is an adapter allowing plain functions to be used as a Decoder
+type DecoderFunc func(r io.Reader) ([]processor.Metadata, []error)
+
+// Decode calls f(r)
+func (f DecoderFunc) Decode(r io.Reader) ([]processor.Metadata, []error) {
+	return f(r)
+}
+
+var (
+	decoders   = map[string]Decoder{}
+	extensions = map[string]string{}
+)
+
+func init() {
+	Register("csv", DecoderFunc(decodeCSV), ".csv")
+	Register("geojson", &GeoJSON{}, ".geojson")
+	Register("kml", DecoderFunc(decodeKML), ".kml")
+	Register("jsonl", DecoderFunc(decodeJSONLines), ".jsonl", ".ndjson")
+}
+
+// Register is used to make a Decoder available under a format name, and optionally
+// to select it automatically for files with the provided extensions.
+func Register(format string, decoder Decoder, exts ...string) {
+	decoders[format] = decoder
+	for _, ext := range exts {
+		extensions[strings.ToLower(ext)] = format
+	}
+}
+
+// Formats returns the names of every registered format
+func Formats() []string {
+	formats := make([]string, 0, len(decoders))
+	for format := range decoders {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// ForFile is used to return the Decoder for a file. An explicit format takes precedence over
+// the file extension.
+func ForFile(file, format string) (Decoder, error) {
+	if format == "" {
+		detected, found := extensions[strings.ToLower(filepath.Ext(file))]
+		if !found {
+			return nil, fmt.Errorf("unknown input format for %q, use one of %s", file, strings.Join(Formats(), ", "))
+		}
+		format = detected
+	}
+	decoder, found := decoders[format]
+	if !found {
+		return nil, fmt.Errorf("unknown input format %q, use one of %s", format, strings.Join(Formats(), ", "))
+	}
+	return decoder, nil
+}
+
+// parseTime is a helper function used to parse the timestamps found in the JSON based formats.
+// Strings must follow RFC3339, numbers are treated as seconds since the Unix epoch.
+func parseTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case string:
+		date, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date: %w", err)
+		}
+		return date, nil
+	case float64:
+		return time.Unix(int64(v), 0).UTC(), nil
+	case nil:
+		return time.Time{}, fmt.Errorf("missing date")
+	}
+	return time.Time{}, fmt.Errorf("invalid date: %v", value)
+}
@@ -0,0 +1,104 @@
+package decoder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_ForFile(t *testing.T) {
+	for name, test := range map[string]struct {
+		file, format string
+
+		expect  Decoder
+		wantErr bool
+	}{
+		"csv by extension": {
+			file:   "data/1.csv",
+			expect: decoders["csv"],
+		},
+		"geojson by extension": {
+			file:   "photos.GeoJSON",
+			expect: decoders["geojson"],
+		},
+		"ndjson by extension": {
+			file:   "photos.ndjson",
+			expect: decoders["jsonl"],
+		},
+		"format overrides extension": {
+			file:   "photos.txt",
+			format: "kml",
+			expect: decoders["kml"],
+		},
+		"unknown extension": {
+			file:    "photos.txt",
+			wantErr: true,
+		},
+		"unknown format": {
+			file:    "photos.csv",
+			format:  "xlsx",
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := ForFile(test.file, test.format)
+			if (err != nil) != test.wantErr {
+				t.Errorf("decoder.ForFile() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.expect != nil {
+				require.NotNil(t, got)
+				require.IsType(t, test.expect, got)
+			}
+		})
+	}
+}
+
+func TestDecoder_Register(t *testing.T) {
+	defer func() {
+		delete(decoders, "test")
+		delete(extensions, ".test")
+	}()
+	Register("test", DecoderFunc(decodeCSV), ".TEST")
+	got, err := ForFile("photos.test", "")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	require.Contains(t, Formats(), "test")
+}
+
+func TestDecoder_parseTime(t *testing.T) {
+	for name, test := range map[string]struct {
+		input interface{}
+
+		want    time.Time
+		wantErr bool
+	}{
+		"rfc3339": {
+			input: "2019-10-31T18:52:59Z",
+			want:  time.Date(2019, 10, 31, 18, 52, 59, 0, time.UTC),
+		},
+		"unix seconds": {
+			input: float64(1572548000),
+			want:  time.Date(2019, 10, 31, 18, 53, 20, 0, time.UTC),
+		},
+		"missing": {
+			wantErr: true,
+		},
+		"invalid string": {
+			input:   "last halloween",
+			wantErr: true,
+		},
+		"invalid type": {
+			input:   true,
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseTime(test.input)
+			if (err != nil) != test.wantErr {
+				t.Errorf("decoder.parseTime() error = %v, wantErr %v", err, test.wantErr)
+			}
+			require.True(t, test.want.Equal(got))
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package decoder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+)
+
+// GeoJSON is used to decode GeoJSON FeatureCollections of Points. see: https://datatracker.ietf.org/doc/html/rfc7946
+type GeoJSON struct {
+	// TimeProperty is the feature property holding the time the photo was taken.
+	// When empty, the time, timestamp, datetime and date properties are tried in that order.
+	TimeProperty string
+}
+
+// featureCollection is used to unmarshal a GeoJSON document
+type featureCollection struct {
+	Type     string `json:"type"`
+	Features []struct {
+		Geometry *struct {
+			Type        string          `json:"type"`
+			Coordinates json.RawMessage `json:"coordinates"`
+		} `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	} `json:"features"`
+}
+
+var defaultTimeProperties = []string{"time", "timestamp", "datetime", "date"}
+
+// Decode is used to return the metadata of every Point feature of a FeatureCollection
+func (g *GeoJSON) Decode(r io.Reader) ([]processor.Metadata, []error) {
+	collection := featureCollection{}
+	if err := json.NewDecoder(r).Decode(&collection); err != nil {
+		return nil, []error{fmt.Errorf("failed to decode geojson: %w", err)}
+	}
+	if collection.Type != "FeatureCollection" {
+		return nil, []error{fmt.Errorf("unsupported geojson type %q", collection.Type)}
+	}
+	properties := defaultTimeProperties
+	if g.TimeProperty != "" {
+		properties = []string{g.TimeProperty}
+	}
+
+	errs := make([]error, 0, len(collection.Features))
+	album := make([]processor.Metadata, 0, len(collection.Features))
+	for i, feature := range collection.Features {
+		var position []float64
+		if feature.Geometry == nil || feature.Geometry.Type != "Point" {
+			errs = append(errs, fmt.Errorf("invalid feature %d: %w", i, errors.New("geometry must be a Point")))
+			continue
+		}
+		if err := json.Unmarshal(feature.Geometry.Coordinates, &position); err != nil || len(position) < 2 {
+			errs = append(errs, fmt.Errorf("invalid feature %d: %w", i, errors.New("invalid Point coordinates")))
+			continue
+		}
+		var value interface{}
+		for _, property := range properties {
+			if v, found := feature.Properties[property]; found {
+				value = v
+				break
+			}
+		}
+		date, err := parseTime(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid feature %d: %w", i, err))
+			continue
+		}
+		// GeoJSON positions are longitude first.
+		album = append(album, processor.Metadata{
+			Latitude:  position[1],
+			Longitude: position[0],
+			Date:      date,
+		})
+	}
+	return album, errs
+}
@@ -0,0 +1,64 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoJSON_Decode(t *testing.T) {
+	for name, test := range map[string]struct {
+		input        string
+		timeProperty string
+
+		want     []processor.Metadata
+		wantErrs int
+	}{
+		"point features": {
+			input: `{"type":"FeatureCollection","features":[
+				{"type":"Feature","geometry":{"type":"Point","coordinates":[14.366858,40.627883]},"properties":{"time":"2019-10-31T18:52:59Z"}},
+				{"type":"Feature","geometry":{"type":"Point","coordinates":[14.364933,40.627808,12.5]},"properties":{"date":"2019-10-31T18:35:23Z"}}
+			]}`,
+			want: []processor.Metadata{
+				{Latitude: 40.627883, Longitude: 14.366858, Date: time.Date(2019, 10, 31, 18, 52, 59, 0, time.UTC)},
+				{Latitude: 40.627808, Longitude: 14.364933, Date: time.Date(2019, 10, 31, 18, 35, 23, 0, time.UTC)},
+			},
+		},
+		"custom time property": {
+			input: `{"type":"FeatureCollection","features":[
+				{"type":"Feature","geometry":{"type":"Point","coordinates":[14.366858,40.627883]},"properties":{"taken":"2019-10-31T18:52:59Z","time":"what"}}
+			]}`,
+			timeProperty: "taken",
+			want: []processor.Metadata{
+				{Latitude: 40.627883, Longitude: 14.366858, Date: time.Date(2019, 10, 31, 18, 52, 59, 0, time.UTC)},
+			},
+		},
+		"non point and undated features": {
+			input: `{"type":"FeatureCollection","features":[
+				{"type":"Feature","geometry":{"type":"LineString","coordinates":[[14.36,40.62],[14.37,40.63]]},"properties":{"time":"2019-10-31T18:52:59Z"}},
+				{"type":"Feature","geometry":null,"properties":{"time":"2019-10-31T18:52:59Z"}},
+				{"type":"Feature","geometry":{"type":"Point","coordinates":[14.366858,40.627883]},"properties":{}}
+			]}`,
+			want:     []processor.Metadata{},
+			wantErrs: 3,
+		},
+		"not a feature collection": {
+			input:    `{"type":"Feature"}`,
+			wantErrs: 1,
+		},
+		"not json": {
+			input:    `what`,
+			wantErrs: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := &GeoJSON{TimeProperty: test.timeProperty}
+			got, errs := g.Decode(strings.NewReader(test.input))
+			require.Len(t, errs, test.wantErrs)
+			require.ElementsMatch(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package decoder
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+)
+
+// jsonLine is used to unmarshal a single JSON Lines record. The short coordinate names are accepted too.
+type jsonLine struct {
+	Time      interface{} `json:"time"`
+	Date      interface{} `json:"date"`
+	Latitude  *float64    `json:"latitude"`
+	Lat       *float64    `json:"lat"`
+	Longitude *float64    `json:"longitude"`
+	Lon       *float64    `json:"lon"`
+	Lng       *float64    `json:"lng"`
+}
+
+// decodeJSONLines is used to decode newline delimited JSON objects, one photo per line. see: https://jsonlines.org
+func decodeJSONLines(r io.Reader) ([]processor.Metadata, []error) {
+	var errs []error
+	var album []processor.Metadata
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		metadata, err := decodeJSONLine([]byte(text))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid line %d: %w", line, err))
+			continue
+		}
+		album = append(album, metadata)
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to read json lines: %w", err))
+	}
+	return album, errs
+}
+
+// decodeJSONLine is a helper function used to map a single record to the processor metadata
+func decodeJSONLine(text []byte) (processor.Metadata, error) {
+	record := jsonLine{}
+	if err := json.Unmarshal(text, &record); err != nil {
+		return processor.Metadata{}, err
+	}
+	value := record.Time
+	if value == nil {
+		value = record.Date
+	}
+	date, err := parseTime(value)
+	if err != nil {
+		return processor.Metadata{}, err
+	}
+	latitude := firstOf(record.Latitude, record.Lat)
+	longitude := firstOf(record.Longitude, record.Lon, record.Lng)
+	if latitude == nil || longitude == nil {
+		return processor.Metadata{}, errors.New("missing coordinates")
+	}
+	return processor.Metadata{Latitude: *latitude, Longitude: *longitude, Date: date}, nil
+}
+
+// firstOf is a helper function used to return the first value that was set
+func firstOf(values ...*float64) *float64 {
+	for _, value := range values {
+		if value != nil {
+			return value
+		}
+	}
+	return nil
+}
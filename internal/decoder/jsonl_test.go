@@ -0,0 +1,49 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_decodeJSONLines(t *testing.T) {
+	for name, test := range map[string]struct {
+		input string
+
+		want     []processor.Metadata
+		wantErrs int
+	}{
+		"long and short field names": {
+			input: `{"time":"2020-03-30T14:12:19Z","latitude":40.728808,"longitude":-73.996106}
+
+{"date":"2020-03-30T14:20:10Z","lat":40.728656,"lng":-73.998790}
+{"time":1585577522,"lat":40.72716,"lon":-73.996044}
+`,
+			want: []processor.Metadata{
+				{Latitude: 40.728808, Longitude: -73.996106, Date: time.Date(2020, 3, 30, 14, 12, 19, 0, time.UTC)},
+				{Latitude: 40.728656, Longitude: -73.998790, Date: time.Date(2020, 3, 30, 14, 20, 10, 0, time.UTC)},
+				{Latitude: 40.72716, Longitude: -73.996044, Date: time.Date(2020, 3, 30, 14, 12, 2, 0, time.UTC)},
+			},
+		},
+		"invalid lines are skipped": {
+			input: `{"time":"2020-03-30T14:12:19Z","latitude":40.728808}
+what
+{"latitude":40.728808,"longitude":-73.996106}
+{"time":"2020-03-30T14:20:10Z","lat":40.728656,"lng":-73.998790}
+`,
+			want: []processor.Metadata{
+				{Latitude: 40.728656, Longitude: -73.998790, Date: time.Date(2020, 3, 30, 14, 20, 10, 0, time.UTC)},
+			},
+			wantErrs: 3,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, errs := decodeJSONLines(strings.NewReader(test.input))
+			require.Len(t, errs, test.wantErrs)
+			require.ElementsMatch(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package decoder
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+)
+
+// placemark is used to unmarshal a KML Placemark. see: https://developers.google.com/kml/documentation/kmlreference#placemark
+type placemark struct {
+	Name        string `xml:"name"`
+	When        string `xml:"TimeStamp>when"`
+	Coordinates string `xml:"Point>coordinates"`
+}
+
+// decodeKML is used to return the metadata of every Placemark with a Point and a TimeStamp,
+// wherever it is nested in the document.
+func decodeKML(r io.Reader) ([]processor.Metadata, []error) {
+	var errs []error
+	var album []processor.Metadata
+	decoder := xml.NewDecoder(r)
+	for i := 0; ; {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return album, append(errs, fmt.Errorf("failed to decode kml: %w", err))
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "Placemark" {
+			continue
+		}
+		mark := placemark{}
+		if err := decoder.DecodeElement(&mark, &start); err != nil {
+			return album, append(errs, fmt.Errorf("failed to decode kml: %w", err))
+		}
+		metadata, err := mark.metadata()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid placemark %d: %w", i, err))
+		} else {
+			album = append(album, metadata)
+		}
+		i++
+	}
+	return album, errs
+}
+
+// metadata is a helper function used to map a Placemark to the processor metadata
+func (p placemark) metadata() (processor.Metadata, error) {
+	if p.When == "" {
+		return processor.Metadata{}, errors.New("missing TimeStamp")
+	}
+	date, err := time.Parse(time.RFC3339, strings.TrimSpace(p.When))
+	if err != nil {
+		return processor.Metadata{}, fmt.Errorf("invalid date: %w", err)
+	}
+	// KML coordinates are longitude,latitude[,altitude]
+	parts := strings.Split(strings.TrimSpace(p.Coordinates), ",")
+	if len(parts) < 2 {
+		return processor.Metadata{}, errors.New("missing Point coordinates")
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return processor.Metadata{}, err
+	}
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return processor.Metadata{}, err
+	}
+	return processor.Metadata{Latitude: latitude, Longitude: longitude, Date: date}, nil
+}
@@ -0,0 +1,60 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_decodeKML(t *testing.T) {
+	for name, test := range map[string]struct {
+		input string
+
+		want     []processor.Metadata
+		wantErrs int
+	}{
+		"nested placemarks": {
+			input: `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+    <Folder>
+      <Placemark>
+        <name>IMG_0001</name>
+        <TimeStamp><when>2019-12-01T05:16:45Z</when></TimeStamp>
+        <Point><coordinates>-115.173813,36.102825,0</coordinates></Point>
+      </Placemark>
+    </Folder>
+    <Placemark>
+      <TimeStamp><when>2019-11-30T19:30:02Z</when></TimeStamp>
+      <Point><coordinates> -115.173888, 36.103417 </coordinates></Point>
+    </Placemark>
+  </Document>
+</kml>`,
+			want: []processor.Metadata{
+				{Latitude: 36.102825, Longitude: -115.173813, Date: time.Date(2019, 12, 1, 5, 16, 45, 0, time.UTC)},
+				{Latitude: 36.103417, Longitude: -115.173888, Date: time.Date(2019, 11, 30, 19, 30, 2, 0, time.UTC)},
+			},
+		},
+		"placemarks without time or point": {
+			input: `<kml><Document>
+    <Placemark><Point><coordinates>-115.173813,36.102825</coordinates></Point></Placemark>
+    <Placemark><TimeStamp><when>2019-11-30T19:30:02Z</when></TimeStamp></Placemark>
+    <Placemark><TimeStamp><when>2019-11-30T19:30:02Z</when></TimeStamp><Point><coordinates>west,36.1</coordinates></Point></Placemark>
+</Document></kml>`,
+			wantErrs: 3,
+		},
+		"broken document": {
+			input:    `<kml><Document><Placemark>`,
+			wantErrs: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, errs := decodeKML(strings.NewReader(test.input))
+			require.Len(t, errs, test.wantErrs)
+			require.ElementsMatch(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package elevation
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// void is the value SRTM tiles hold for samples without elevation data
+const void = -32768
+
+// SRTM is a custom type used to look up elevations offline from SRTM tiles in the HGT format,
+// e.g. N40E014.hgt, covering one degree of latitude and longitude from their south west corner.
+// Both the 1 arc second (3601x3601 samples) and the 3 arc second (1201x1201 samples) resolutions are supported.
+type SRTM struct {
+	dir   string
+	mu    sync.Mutex
+	tiles map[string]*tile
+}
+
+// tile is used to store the samples of an SRTM tile, from the north west corner, row by row
+type tile struct {
+	size    int
+	samples []int16
+}
+
+// New returns a new SRTM reading tiles from dir
+func New(dir string) *SRTM {
+	return &SRTM{dir: dir, tiles: make(map[string]*tile)}
+}
+
+// Elevation is used to return the elevation in metres above sea level at coordinates, interpolated between the
+// nearest samples of their tile. Tiles are read once and kept in memory.
+func (s *SRTM) Elevation(latitude, longitude float64) (float64, error) {
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return 0, fmt.Errorf("invalid coordinates %f,%f", latitude, longitude)
+	}
+	south, west := math.Floor(latitude), math.Floor(longitude)
+	t, err := s.tile(TileName(latitude, longitude))
+	if err != nil {
+		return 0, err
+	}
+	last := float64(t.size - 1)
+	row := (south + 1 - latitude) * last
+	col := (longitude - west) * last
+	elevation, found := t.interpolate(row, col)
+	if !found {
+		return 0, fmt.Errorf("no elevation data at %f,%f", latitude, longitude)
+	}
+	return elevation, nil
+}
+
+// TileName is used to return the name of the SRTM tile covering coordinates, e.g. N40E014.hgt
+func TileName(latitude, longitude float64) string {
+	south, west := int(math.Floor(latitude)), int(math.Floor(longitude))
+	ns, ew := 'N', 'E'
+	if south < 0 {
+		ns, south = 'S', -south
+	}
+	if west < 0 {
+		ew, west = 'W', -west
+	}
+	return fmt.Sprintf("%c%02d%c%03d.hgt", ns, south, ew, west)
+}
+
+// tile is a helper function used to return a tile by name, reading it from the directory the first time
+func (s *SRTM) tile(name string) (*tile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, found := s.tiles[name]; found {
+		if t == nil {
+			return nil, fmt.Errorf("no elevation tile %s", name)
+		}
+		return t, nil
+	}
+	t, err := readTile(filepath.Join(s.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		s.tiles[name] = nil
+		return nil, fmt.Errorf("no elevation tile %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.tiles[name] = t
+	return t, nil
+}
+
+// readTile is a helper function used to read the big endian samples of an HGT file
+func readTile(file string) (*tile, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	size := int(math.Sqrt(float64(len(data) / 2)))
+	if size < 2 || size*size*2 != len(data) {
+		return nil, fmt.Errorf("invalid elevation tile %s: %d bytes", filepath.Base(file), len(data))
+	}
+	samples := make([]int16, size*size)
+	for i := range samples {
+		samples[i] = int16(binary.BigEndian.Uint16(data[i*2:]))
+	}
+	return &tile{size: size, samples: samples}, nil
+}
+
+// interpolate is a helper function used to return the bilinear interpolation of the samples around a fractional
+// row and column, or the nearest sample when any of them is void
+func (t *tile) interpolate(row, col float64) (float64, bool) {
+	r0, c0 := int(math.Floor(row)), int(math.Floor(col))
+	r1, c1 := r0+1, c0+1
+	if r1 >= t.size {
+		r1 = r0
+	}
+	if c1 >= t.size {
+		c1 = c0
+	}
+	nw, ne := t.sample(r0, c0), t.sample(r0, c1)
+	sw, se := t.sample(r1, c0), t.sample(r1, c1)
+	if nw == void || ne == void || sw == void || se == void {
+		nearest := t.sample(int(math.Round(row)), int(math.Round(col)))
+		return float64(nearest), nearest != void
+	}
+	dr, dc := row-float64(r0), col-float64(c0)
+	north := float64(nw)*(1-dc) + float64(ne)*dc
+	south := float64(sw)*(1-dc) + float64(se)*dc
+	return north*(1-dr) + south*dr, true
+}
+
+// sample is a helper function used to return the sample at a row and column of the tile
+func (t *tile) sample(row, col int) int16 {
+	if row >= t.size {
+		row = t.size - 1
+	}
+	if col >= t.size {
+		col = t.size - 1
+	}
+	return t.samples[row*t.size+col]
+}
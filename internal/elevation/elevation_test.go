@@ -0,0 +1,88 @@
+package elevation
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeTile is a helper function used to write a 3x3 HGT tile, from the north west corner, row by row
+func writeTile(t *testing.T, dir, name string, samples [9]int16) {
+	data := make([]byte, len(samples)*2)
+	for i, sample := range samples {
+		binary.BigEndian.PutUint16(data[i*2:], uint16(sample))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0o644))
+}
+
+func TestTileName(t *testing.T) {
+	for name, test := range map[string]struct {
+		latitude, longitude float64
+
+		expect string
+	}{
+		"north east":    {latitude: 40.628197, longitude: 14.367075, expect: "N40E014.hgt"},
+		"north west":    {latitude: 40.728808, longitude: -73.996106, expect: "N40W074.hgt"},
+		"south east":    {latitude: -33.8568, longitude: 151.2153, expect: "S34E151.hgt"},
+		"south west":    {latitude: -22.9519, longitude: -43.2105, expect: "S23W044.hgt"},
+		"on the corner": {latitude: 46, longitude: 7, expect: "N46E007.hgt"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expect, TileName(test.latitude, test.longitude))
+		})
+	}
+}
+
+func TestSRTM_Elevation(t *testing.T) {
+	dir := t.TempDir()
+	writeTile(t, dir, "N46E007.hgt", [9]int16{
+		4000, 3000, 2000,
+		3000, 2000, 1000,
+		2000, 1000, void,
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "N45E007.hgt"), []byte{1, 2, 3}, 0o644))
+
+	for name, test := range map[string]struct {
+		latitude, longitude float64
+
+		expect  float64
+		wantErr bool
+	}{
+		"north west corner": {latitude: 46.9999, longitude: 7, expect: 4000},
+		"centre":            {latitude: 46.5, longitude: 7.5, expect: 2000},
+		"interpolated":      {latitude: 46.75, longitude: 7.25, expect: 3000},
+		"next to a void":    {latitude: 46.1, longitude: 7.6, expect: 1000},
+		"void":              {latitude: 46.01, longitude: 7.99, wantErr: true},
+		"missing tile":      {latitude: 40.6, longitude: 14.4, wantErr: true},
+		"invalid tile":      {latitude: 45.5, longitude: 7.5, wantErr: true},
+		"invalid latitude":  {latitude: 91, longitude: 7.5, wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srtm := New(dir)
+			got, err := srtm.Elevation(test.latitude, test.longitude)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.InDelta(t, test.expect, got, 0.5)
+
+			// tiles are read once
+			got, err = srtm.Elevation(test.latitude, test.longitude)
+			require.NoError(t, err)
+			require.InDelta(t, test.expect, got, 0.5)
+		})
+	}
+}
+
+func TestFake_Elevation(t *testing.T) {
+	fake := Fake{46.5: 2000}
+	got, err := fake.Elevation(46.5, 7.5)
+	require.NoError(t, err)
+	require.Equal(t, 2000.0, got)
+	_, err = fake.Elevation(40.6, 14.4)
+	require.Error(t, err)
+}
@@ -0,0 +1,16 @@
+package elevation
+
+import "fmt"
+
+// Fake is a custom type used to look up fixed elevations in metres by latitude, e.g. in tests.
+// Latitudes without an elevation return an error, as when an SRTM tile is missing.
+type Fake map[float64]float64
+
+// Elevation is used to return the elevation set for a latitude
+func (f Fake) Elevation(latitude, longitude float64) (float64, error) {
+	elevation, found := f[latitude]
+	if !found {
+		return 0, fmt.Errorf("no elevation data at %f,%f", latitude, longitude)
+	}
+	return elevation, nil
+}
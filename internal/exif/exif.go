@@ -0,0 +1,230 @@
+package exif
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Data is a custom type used to describe the EXIF fields this program understands to other packages
+type Data struct {
+	DateTime time.Time
+	// Zoned is set when the camera recorded the UTC offset of DateTime, otherwise DateTime holds its wall clock as UTC
+	Zoned bool
+	// Latitude and Longitude are the coordinates the photo was taken at in decimal degrees, when Located is set
+	Latitude, Longitude float64
+	Located             bool
+}
+
+// EXIF tags used by this package. see: https://exiftool.org/TagNames/EXIF.html
+const (
+	tagDateTime           = 0x0132
+	tagExifIFD            = 0x8769
+	tagGPSIFD             = 0x8825
+	tagGPSLatitudeRef     = 0x0001
+	tagGPSLatitude        = 0x0002
+	tagGPSLongitudeRef    = 0x0003
+	tagGPSLongitude       = 0x0004
+	tagDateTimeOriginal   = 0x9003
+	tagOffsetTimeOriginal = 0x9011
+)
+
+// ErrNoExif is returned when a file does not carry an EXIF block
+var ErrNoExif = errors.New("no exif data found")
+
+// Read is used to extract EXIF data from a JPEG or TIFF based image.
+// EXIF dates carry no timezone, so unless the camera recorded an offset the
+// date is returned as UTC, without Zoned, and it is up to the caller to correct the camera clock.
+func Read(r io.Reader) (Data, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil {
+		return Data{}, fmt.Errorf("failed to read image: %w", err)
+	}
+	var tiff []byte
+	switch string(magic) {
+	case "\xff\xd8":
+		tiff, err = jpegExif(br)
+	case "II", "MM":
+		tiff, err = io.ReadAll(br)
+	default:
+		return Data{}, errors.New("unsupported image format")
+	}
+	if err != nil {
+		return Data{}, err
+	}
+	return parseTIFF(tiff)
+}
+
+// jpegExif is a helper function used to walk the JPEG segments until the APP1 EXIF segment is found
+func jpegExif(r *bufio.Reader) ([]byte, error) {
+	if _, err := r.Discard(2); err != nil {
+		return nil, err
+	}
+	for {
+		var header [4]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			return nil, ErrNoExif
+		}
+		if header[0] != 0xff {
+			return nil, errors.New("corrupt jpeg segment")
+		}
+		marker := header[1]
+		// start of scan or end of image, no more metadata segments past this point.
+		if marker == 0xda || marker == 0xd9 {
+			return nil, ErrNoExif
+		}
+		length := int(binary.BigEndian.Uint16(header[2:])) - 2
+		if length < 0 {
+			return nil, errors.New("corrupt jpeg segment")
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return nil, fmt.Errorf("failed to read jpeg segment: %w", err)
+		}
+		if marker == 0xe1 && bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+			return payload[6:], nil
+		}
+	}
+}
+
+// ifd is used to store the entries of a single TIFF image file directory
+type ifd map[uint16][]byte
+
+// parseTIFF is a helper function used to decode the TIFF structure embedded in the EXIF block
+func parseTIFF(tiff []byte) (Data, error) {
+	if len(tiff) < 8 {
+		return Data{}, ErrNoExif
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return Data{}, errors.New("invalid tiff byte order")
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return Data{}, errors.New("invalid tiff header")
+	}
+	ifd0, err := readIFD(tiff, order, order.Uint32(tiff[4:]))
+	if err != nil {
+		return Data{}, err
+	}
+	entries := ifd0
+	if pointer, ok := ifd0[tagExifIFD]; ok && len(pointer) >= 4 {
+		exifIFD, err := readIFD(tiff, order, order.Uint32(pointer))
+		if err != nil {
+			return Data{}, err
+		}
+		entries = exifIFD
+	}
+
+	raw, ok := entries[tagDateTimeOriginal]
+	if !ok {
+		raw, ok = ifd0[tagDateTime]
+	}
+	if !ok {
+		return Data{}, errors.New("no date found in exif data")
+	}
+	location, zoned := time.UTC, false
+	if offset, ok := entries[tagOffsetTimeOriginal]; ok {
+		if t, err := time.Parse("-07:00", asciiValue(offset)); err == nil {
+			location, zoned = t.Location(), true
+		}
+	}
+	date, err := time.ParseInLocation("2006:01:02 15:04:05", asciiValue(raw), location)
+	if err != nil {
+		return Data{}, fmt.Errorf("invalid exif date: %w", err)
+	}
+	data := Data{DateTime: date, Zoned: zoned}
+	if pointer, ok := ifd0[tagGPSIFD]; ok && len(pointer) >= 4 {
+		gpsIFD, err := readIFD(tiff, order, order.Uint32(pointer))
+		if err != nil {
+			return Data{}, err
+		}
+		latitude, latOK := coordinate(gpsIFD[tagGPSLatitude], gpsIFD[tagGPSLatitudeRef], "S", order)
+		longitude, lonOK := coordinate(gpsIFD[tagGPSLongitude], gpsIFD[tagGPSLongitudeRef], "W", order)
+		data.Latitude, data.Longitude, data.Located = latitude, longitude, latOK && lonOK
+	}
+	return data, nil
+}
+
+// coordinate is a helper function used to decode a GPS coordinate stored as degrees, minutes and seconds rationals,
+// negative when its reference is negative, e.g. S or W
+func coordinate(value, ref []byte, negative string, order binary.ByteOrder) (float64, bool) {
+	if len(value) < 24 {
+		return 0, false
+	}
+	var decimal float64
+	for i, unit := range []float64{1, 60, 3600} {
+		numerator, denominator := order.Uint32(value[i*8:]), order.Uint32(value[i*8+4:])
+		if denominator == 0 {
+			return 0, false
+		}
+		decimal += float64(numerator) / float64(denominator) / unit
+	}
+	if asciiValue(ref) == negative {
+		decimal = -decimal
+	}
+	return decimal, true
+}
+
+// readIFD is a helper function used to read the raw values of every entry of an IFD
+func readIFD(tiff []byte, order binary.ByteOrder, offset uint32) (ifd, error) {
+	// offsets and sizes are computed in uint64 so that corrupt values cannot overflow int on 32-bit builds
+	if uint64(offset)+2 > uint64(len(tiff)) {
+		return nil, errors.New("ifd offset out of bounds")
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	entries := make(ifd, count)
+	for i := 0; i < count; i++ {
+		start := uint64(offset) + 2 + uint64(i)*12
+		if start+12 > uint64(len(tiff)) {
+			return nil, errors.New("ifd entry out of bounds")
+		}
+		entry := tiff[start : start+12]
+		size := uint64(typeSize(order.Uint16(entry[2:]))) * uint64(order.Uint32(entry[4:]))
+		if size > uint64(len(tiff)) {
+			continue
+		}
+		value := entry[8:12]
+		if size > 4 {
+			valueOffset := uint64(order.Uint32(entry[8:]))
+			if valueOffset+size > uint64(len(tiff)) {
+				continue
+			}
+			value = tiff[valueOffset : valueOffset+size]
+		} else {
+			value = value[:size]
+		}
+		entries[order.Uint16(entry)] = value
+	}
+	return entries, nil
+}
+
+// typeSize is a helper function used to return the size in bytes of a TIFF field type
+func typeSize(fieldType uint16) int {
+	switch fieldType {
+	case 1, 2, 6, 7:
+		return 1
+	case 3, 8:
+		return 2
+	case 4, 9, 11:
+		return 4
+	case 5, 10, 12:
+		return 8
+	}
+	return 0
+}
+
+// asciiValue is a helper function used to turn a NUL terminated TIFF ASCII value into a string
+func asciiValue(value []byte) string {
+	return strings.TrimRight(string(value), "\x00 ")
+}
@@ -0,0 +1,255 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// asciiTag is used to describe an ASCII entry when building test images
+type asciiTag struct {
+	tag   uint16
+	value string
+}
+
+// buildTIFF is a helper function used to build a little endian TIFF block with an EXIF IFD holding the provided tags
+func buildTIFF(tags ...asciiTag) []byte {
+	order := binary.LittleEndian
+	buf := &bytes.Buffer{}
+	buf.WriteString("II")
+	_ = binary.Write(buf, order, uint16(42))
+	_ = binary.Write(buf, order, uint32(8))
+
+	// IFD0 holds a single pointer to the EXIF IFD which starts right after it.
+	exifOffset := uint32(8 + 2 + 12 + 4)
+	_ = binary.Write(buf, order, uint16(1))
+	_ = binary.Write(buf, order, uint16(tagExifIFD))
+	_ = binary.Write(buf, order, uint16(4))
+	_ = binary.Write(buf, order, uint32(1))
+	_ = binary.Write(buf, order, exifOffset)
+	_ = binary.Write(buf, order, uint32(0))
+
+	dataOffset := exifOffset + 2 + uint32(len(tags))*12 + 4
+	values := &bytes.Buffer{}
+	_ = binary.Write(buf, order, uint16(len(tags)))
+	for _, tag := range tags {
+		value := append([]byte(tag.value), 0)
+		_ = binary.Write(buf, order, tag.tag)
+		_ = binary.Write(buf, order, uint16(2))
+		_ = binary.Write(buf, order, uint32(len(value)))
+		if len(value) <= 4 {
+			padded := make([]byte, 4)
+			copy(padded, value)
+			buf.Write(padded)
+			continue
+		}
+		_ = binary.Write(buf, order, dataOffset+uint32(values.Len()))
+		values.Write(value)
+	}
+	_ = binary.Write(buf, order, uint32(0))
+	buf.Write(values.Bytes())
+	return buf.Bytes()
+}
+
+// buildJPEG is a helper function used to wrap a TIFF block into a minimal JPEG
+func buildJPEG(tiff []byte) []byte {
+	buf := &bytes.Buffer{}
+	buf.Write([]byte{0xff, 0xd8})
+	// an unrelated APP0 segment comes first in most files.
+	buf.Write([]byte{0xff, 0xe0, 0x00, 0x04, 0x00, 0x00})
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	buf.Write([]byte{0xff, 0xe1})
+	_ = binary.Write(buf, binary.BigEndian, uint16(len(payload)+2))
+	buf.Write(payload)
+	buf.Write([]byte{0xff, 0xda})
+	return buf.Bytes()
+}
+
+func TestExif_Read(t *testing.T) {
+	for name, test := range map[string]struct {
+		input []byte
+
+		want      time.Time
+		wantZoned bool
+		wantErr   bool
+	}{
+		"jpeg with original date": {
+			input: buildJPEG(buildTIFF(asciiTag{tagDateTimeOriginal, "2019:10:31 18:52:59"})),
+			want:  time.Date(2019, 10, 31, 18, 52, 59, 0, time.UTC),
+		},
+		"jpeg with original date and offset": {
+			input: buildJPEG(buildTIFF(
+				asciiTag{tagDateTimeOriginal, "2019:10:31 19:52:59"},
+				asciiTag{tagOffsetTimeOriginal, "+01:00"},
+			)),
+			want:      time.Date(2019, 10, 31, 18, 52, 59, 0, time.UTC),
+			wantZoned: true,
+		},
+		"tiff file": {
+			input: buildTIFF(asciiTag{tagDateTimeOriginal, "2020:03:30 14:12:19"}),
+			want:  time.Date(2020, 3, 30, 14, 12, 19, 0, time.UTC),
+		},
+		"jpeg without exif": {
+			input:   []byte{0xff, 0xd8, 0xff, 0xe0, 0x00, 0x04, 0x00, 0x00, 0xff, 0xda},
+			wantErr: true,
+		},
+		"invalid date": {
+			input:   buildJPEG(buildTIFF(asciiTag{tagDateTimeOriginal, "yesterday"})),
+			wantErr: true,
+		},
+		"unsupported format": {
+			input:   []byte("GIF89a"),
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := Read(bytes.NewReader(test.input))
+			if (err != nil) != test.wantErr {
+				t.Errorf("exif.Read() error = %v, wantErr %v", err, test.wantErr)
+			}
+			require.True(t, test.want.Equal(got.DateTime), "got %s, want %s", got.DateTime, test.want)
+			require.Equal(t, test.wantZoned, got.Zoned)
+		})
+	}
+}
+
+// buildGPSTIFF is a helper function used to build a little endian TIFF block with a date in IFD0 and a GPS IFD
+// holding coordinates as degrees, minutes and hundredths of seconds
+func buildGPSTIFF(date, latitudeRef string, latitude [3]uint32, longitudeRef string, longitude [3]uint32) []byte {
+	order := binary.LittleEndian
+	buf := &bytes.Buffer{}
+	buf.WriteString("II")
+	_ = binary.Write(buf, order, uint16(42))
+	_ = binary.Write(buf, order, uint32(8))
+
+	dateValue := append([]byte(date), 0)
+	gpsOffset := uint32(8 + 2 + 2*12 + 4)
+	dateOffset := gpsOffset + 2 + 4*12 + 4
+	latitudeOffset := dateOffset + uint32(len(dateValue))
+	longitudeOffset := latitudeOffset + 24
+
+	_ = binary.Write(buf, order, uint16(2))
+	for _, entry := range [][4]uint32{{tagDateTime, 2, uint32(len(dateValue)), dateOffset}, {tagGPSIFD, 4, 1, gpsOffset}} {
+		_ = binary.Write(buf, order, uint16(entry[0]))
+		_ = binary.Write(buf, order, uint16(entry[1]))
+		_ = binary.Write(buf, order, entry[2])
+		_ = binary.Write(buf, order, entry[3])
+	}
+	_ = binary.Write(buf, order, uint32(0))
+
+	_ = binary.Write(buf, order, uint16(4))
+	for _, ref := range []struct {
+		tag    uint16
+		ref    string
+		offset uint32
+	}{{tagGPSLatitudeRef, latitudeRef, latitudeOffset}, {tagGPSLongitudeRef, longitudeRef, longitudeOffset}} {
+		_ = binary.Write(buf, order, ref.tag)
+		_ = binary.Write(buf, order, uint16(2))
+		_ = binary.Write(buf, order, uint32(2))
+		buf.Write([]byte{ref.ref[0], 0, 0, 0})
+		_ = binary.Write(buf, order, ref.tag+1)
+		_ = binary.Write(buf, order, uint16(5))
+		_ = binary.Write(buf, order, uint32(3))
+		_ = binary.Write(buf, order, ref.offset)
+	}
+	_ = binary.Write(buf, order, uint32(0))
+
+	buf.Write(dateValue)
+	for _, dms := range [][3]uint32{latitude, longitude} {
+		for i, denominator := range []uint32{1, 1, 100} {
+			_ = binary.Write(buf, order, dms[i])
+			_ = binary.Write(buf, order, denominator)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestExif_Read_gps(t *testing.T) {
+	for name, test := range map[string]struct {
+		input []byte
+
+		wantLatitude, wantLongitude float64
+		wantLocated                 bool
+	}{
+		"northern and western hemispheres": {
+			input:         buildJPEG(buildGPSTIFF("2019:07:04 12:30:00", "N", [3]uint32{40, 43, 4380}, "W", [3]uint32{73, 59, 4596})),
+			wantLatitude:  40.728833,
+			wantLongitude: -73.996100,
+			wantLocated:   true,
+		},
+		"southern and eastern hemispheres": {
+			input:         buildGPSTIFF("2019:07:04 12:30:00", "S", [3]uint32{33, 51, 3240}, "E", [3]uint32{151, 12, 3600}),
+			wantLatitude:  -33.859,
+			wantLongitude: 151.21,
+			wantLocated:   true,
+		},
+		"no gps data": {
+			input: buildJPEG(buildTIFF(asciiTag{tag: tagDateTimeOriginal, value: "2019:07:04 12:30:00"})),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := Read(bytes.NewReader(test.input))
+			require.NoError(t, err)
+			require.Equal(t, test.wantLocated, got.Located)
+			require.InDelta(t, test.wantLatitude, got.Latitude, 0.0001)
+			require.InDelta(t, test.wantLongitude, got.Longitude, 0.0001)
+		})
+	}
+}
+
+func TestExif_readIFD(t *testing.T) {
+	order := binary.LittleEndian
+	// entry builds a single 12 bytes IFD entry
+	entry := func(tag, fieldType uint16, count, value uint32) []byte {
+		buf := &bytes.Buffer{}
+		_ = binary.Write(buf, order, tag)
+		_ = binary.Write(buf, order, fieldType)
+		_ = binary.Write(buf, order, count)
+		_ = binary.Write(buf, order, value)
+		return buf.Bytes()
+	}
+	for name, test := range map[string]struct {
+		entries [][]byte
+		offset  uint32
+
+		want    ifd
+		wantErr bool
+	}{
+		"inline value": {
+			entries: [][]byte{entry(1, 3, 2, 0x00020001)},
+			want:    ifd{1: {0x01, 0x00, 0x02, 0x00}},
+		},
+		"count overflowing the size is skipped": {
+			entries: [][]byte{entry(1, 5, 0xffffffff, 0), entry(2, 1, 1, 7)},
+			want:    ifd{2: {0x07}},
+		},
+		"offset overflowing the block is skipped": {
+			entries: [][]byte{entry(1, 1, 8, 0xfffffffc), entry(2, 1, 1, 7)},
+			want:    ifd{2: {0x07}},
+		},
+		"ifd offset overflowing the block": {
+			entries: [][]byte{entry(1, 1, 1, 7)},
+			offset:  0xfffffff0,
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tiff := &bytes.Buffer{}
+			_ = binary.Write(tiff, order, uint16(len(test.entries)))
+			for _, e := range test.entries {
+				tiff.Write(e)
+			}
+			_ = binary.Write(tiff, order, uint32(0))
+			got, err := readIFD(tiff.Bytes(), order, test.offset)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// EarthRadius is the mean radius of the Earth in kilometres
+const EarthRadius = 6371.0
+
+// Distance is used to return the great circle distance in kilometres between two coordinates
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1, phi2 := radians(lat1), radians(lat2)
+	deltaPhi, deltaLambda := radians(lat2-lat1), radians(lon2-lon1)
+	a := math.Sin(deltaPhi/2)*math.Sin(deltaPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(deltaLambda/2)*math.Sin(deltaLambda/2)
+	return 2 * EarthRadius * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// radians is a helper function used to convert degrees to radians
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// LocalTime is used to approximate the local time of an instant from the longitude it was observed at,
+// using the nautical time zone the longitude falls in. Political time zones and daylight saving time
+// are not taken into account, so the result can be off by an hour or two.
+func LocalTime(t time.Time, longitude float64) time.Time {
+	return t.In(zone(longitude))
+}
+
+// FromLocalTime is the inverse of LocalTime: it is used to return the instant at which the clock of the
+// nautical time zone the longitude falls in read the wall clock time of t, whatever the location of t.
+func FromLocalTime(t time.Time, longitude float64) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), zone(longitude))
+}
+
+// zone is a helper function used to return the nautical time zone a longitude falls in
+func zone(longitude float64) *time.Location {
+	offset := int(math.Round(longitude/15)) * 3600
+	return time.FixedZone(fmt.Sprintf("UTC%+d", offset/3600), offset)
+}
@@ -0,0 +1,75 @@
+package geo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeo_Distance(t *testing.T) {
+	for name, test := range map[string]struct {
+		lat1, lon1, lat2, lon2 float64
+
+		want float64
+	}{
+		"same point": {
+			lat1: 40.728808, lon1: -73.996106, lat2: 40.728808, lon2: -73.996106,
+			want: 0,
+		},
+		"new york to las vegas": {
+			lat1: 40.728808, lon1: -73.996106, lat2: 36.102825, lon2: -115.173813,
+			want: 3587,
+		},
+		"positano to amalfi": {
+			lat1: 40.627883, lon1: 14.366858, lat2: 40.634303, lon2: 14.602580,
+			want: 19.9,
+		},
+		"across the antimeridian": {
+			lat1: 0, lon1: 179.5, lat2: 0, lon2: -179.5,
+			want: 111.2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got := Distance(test.lat1, test.lon1, test.lat2, test.lon2)
+			require.InDelta(t, test.want, got, test.want*0.01+0.01)
+		})
+	}
+}
+
+func TestGeo_LocalTime(t *testing.T) {
+	for name, test := range map[string]struct {
+		longitude float64
+
+		want string
+	}{
+		"positano":  {longitude: 14.366858, want: "2019-10-31T19:52:59+01:00"},
+		"new york":  {longitude: -73.996106, want: "2019-10-31T13:52:59-05:00"},
+		"las vegas": {longitude: -115.173813, want: "2019-10-31T10:52:59-08:00"},
+		"greenwich": {longitude: 0, want: "2019-10-31T18:52:59Z"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got := LocalTime(time.Date(2019, 10, 31, 18, 52, 59, 0, time.UTC), test.longitude)
+			require.Equal(t, test.want, got.Format(time.RFC3339))
+		})
+	}
+}
+
+func TestGeo_FromLocalTime(t *testing.T) {
+	for name, test := range map[string]struct {
+		longitude float64
+
+		want string
+	}{
+		"positano":  {longitude: 14.366858, want: "2019-10-31T17:52:59Z"},
+		"new york":  {longitude: -73.996106, want: "2019-10-31T23:52:59Z"},
+		"sydney":    {longitude: 151.21, want: "2019-10-31T08:52:59Z"},
+		"greenwich": {longitude: 0, want: "2019-10-31T18:52:59Z"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got := FromLocalTime(time.Date(2019, 10, 31, 18, 52, 59, 0, time.UTC), test.longitude)
+			require.Equal(t, test.want, got.UTC().Format(time.RFC3339))
+			require.Equal(t, "18:52:59", LocalTime(got, test.longitude).Format("15:04:05"))
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package gpx
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Point is a custom type used to describe a single timed position of a track
+type Point struct {
+	Latitude, Longitude float64
+	Time                time.Time
+}
+
+// Track is a custom type used to describe a GPX track as a time ordered list of points
+type Track struct {
+	Points []Point
+}
+
+// gpxData is used to unmarshal the parts of a GPX document this program needs.
+// see: https://www.topografix.com/GPX/1/1/
+type gpxData struct {
+	Tracks []struct {
+		Segments []struct {
+			Points []struct {
+				Latitude  float64 `xml:"lat,attr"`
+				Longitude float64 `xml:"lon,attr"`
+				Time      string  `xml:"time"`
+			} `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// Read is used to decode a GPX document into a Track. Points without a time are ignored
+// as they cannot be used to position photos.
+func Read(r io.Reader) (Track, error) {
+	doc := gpxData{}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return Track{}, fmt.Errorf("failed to decode gpx: %w", err)
+	}
+	track := Track{}
+	for _, trk := range doc.Tracks {
+		for _, segment := range trk.Segments {
+			for _, point := range segment.Points {
+				if point.Time == "" {
+					continue
+				}
+				date, err := time.Parse(time.RFC3339, point.Time)
+				if err != nil {
+					return Track{}, fmt.Errorf("invalid track point time: %w", err)
+				}
+				track.Points = append(track.Points, Point{
+					Latitude:  point.Latitude,
+					Longitude: point.Longitude,
+					Time:      date,
+				})
+			}
+		}
+	}
+	if len(track.Points) == 0 {
+		return Track{}, errors.New("gpx contains no timed track points")
+	}
+	sort.SliceStable(track.Points, func(i, j int) bool {
+		return track.Points[i].Time.Before(track.Points[j].Time)
+	})
+	return track, nil
+}
+
+// Interpolator is used to position photos on a Track based on the time they were taken
+type Interpolator struct {
+	track       Track
+	maxGap      time.Duration
+	clockOffset time.Duration
+}
+
+type InterpolatorOptions func(*Interpolator)
+
+// WithMaxGap sets the largest interval between two track points that a photo can be interpolated across,
+// as well as how far outside of the track bounds a photo can be before it is rejected.
+func WithMaxGap(max time.Duration) InterpolatorOptions {
+	return func(i *Interpolator) {
+		i.maxGap = max
+	}
+}
+
+// WithClockOffset sets how far ahead of the GPS clock the camera clock was running.
+// A negative offset means the camera was behind.
+func WithClockOffset(offset time.Duration) InterpolatorOptions {
+	return func(i *Interpolator) {
+		i.clockOffset = offset
+	}
+}
+
+// NewInterpolator returns a new Interpolator
+func NewInterpolator(track Track, options ...InterpolatorOptions) *Interpolator {
+	interpolator := &Interpolator{track: track, maxGap: 5 * time.Minute}
+	for _, option := range options {
+		option(interpolator)
+	}
+	return interpolator
+}
+
+// Locate is used to return the interpolated position of a photo taken at the provided camera time
+func (i *Interpolator) Locate(date time.Time) (Point, error) {
+	points := i.track.Points
+	if len(points) == 0 {
+		return Point{}, errors.New("empty track")
+	}
+	date = date.Add(-i.clockOffset)
+	next := sort.Search(len(points), func(n int) bool {
+		return !points[n].Time.Before(date)
+	})
+	switch {
+	case next == 0:
+		return i.nearest(points[0], date)
+	case next == len(points):
+		return i.nearest(points[len(points)-1], date)
+	}
+	before, after := points[next-1], points[next]
+	if after.Time.Sub(before.Time) > i.maxGap {
+		return Point{}, fmt.Errorf("no track point within %s of %s", i.maxGap, date.Format(time.RFC3339))
+	}
+	ratio := float64(date.Sub(before.Time)) / float64(after.Time.Sub(before.Time))
+	return Point{
+		Latitude:  before.Latitude + (after.Latitude-before.Latitude)*ratio,
+		Longitude: before.Longitude + (after.Longitude-before.Longitude)*ratio,
+		Time:      date,
+	}, nil
+}
+
+// Rows is used to turn photo timestamps into rows matching the CSV schema
+// accepted by the processor. Photos that cannot be positioned are reported as errors.
+func (i *Interpolator) Rows(dates []time.Time) ([][]string, []error) {
+	errs := make([]error, 0, len(dates))
+	rows := make([][]string, 0, len(dates))
+	for _, date := range dates {
+		point, err := i.Locate(date)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to position photo: %w", err))
+			continue
+		}
+		rows = append(rows, []string{
+			point.Time.UTC().Format("2006-01-02T15:04:05Z"),
+			fmt.Sprintf("%f", point.Latitude),
+			fmt.Sprintf("%f", point.Longitude),
+		})
+	}
+	return rows, errs
+}
+
+// nearest is a helper function used to position photos taken just outside of the track bounds
+func (i *Interpolator) nearest(point Point, date time.Time) (Point, error) {
+	gap := date.Sub(point.Time)
+	if gap < 0 {
+		gap = -gap
+	}
+	if gap > i.maxGap {
+		return Point{}, fmt.Errorf("no track point within %s of %s", i.maxGap, date.Format(time.RFC3339))
+	}
+	return Point{Latitude: point.Latitude, Longitude: point.Longitude, Time: date}, nil
+}
@@ -0,0 +1,108 @@
+package gpx
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const sampleGPX = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk>
+    <trkseg>
+      <trkpt lat="40.7000" lon="-74.0000"><time>2020-03-30T14:10:00Z</time></trkpt>
+      <trkpt lat="40.7100" lon="-74.0100"><time>2020-03-30T14:00:00Z</time></trkpt>
+      <trkpt lat="40.8000" lon="-74.1000"><time>2020-03-30T16:00:00Z</time></trkpt>
+      <trkpt lat="41.0000" lon="-75.0000"></trkpt>
+    </trkseg>
+  </trk>
+</gpx>`
+
+func TestGPX_Read(t *testing.T) {
+	for name, test := range map[string]struct {
+		input string
+
+		want    []Point
+		wantErr bool
+	}{
+		"sorted timed points": {
+			input: sampleGPX,
+			want: []Point{
+				{Latitude: 40.71, Longitude: -74.01, Time: time.Date(2020, 3, 30, 14, 0, 0, 0, time.UTC)},
+				{Latitude: 40.70, Longitude: -74.00, Time: time.Date(2020, 3, 30, 14, 10, 0, 0, time.UTC)},
+				{Latitude: 40.80, Longitude: -74.10, Time: time.Date(2020, 3, 30, 16, 0, 0, 0, time.UTC)},
+			},
+		},
+		"not xml": {
+			input:   "what",
+			wantErr: true,
+		},
+		"no timed points": {
+			input:   `<gpx><trk><trkseg><trkpt lat="1" lon="1"></trkpt></trkseg></trk></gpx>`,
+			wantErr: true,
+		},
+		"invalid time": {
+			input:   `<gpx><trk><trkseg><trkpt lat="1" lon="1"><time>noon</time></trkpt></trkseg></trk></gpx>`,
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := Read(strings.NewReader(test.input))
+			if (err != nil) != test.wantErr {
+				t.Errorf("gpx.Read() error = %v, wantErr %v", err, test.wantErr)
+			}
+			require.Equal(t, test.want, got.Points)
+		})
+	}
+}
+
+func TestInterpolator_Rows(t *testing.T) {
+	track, err := Read(strings.NewReader(sampleGPX))
+	require.NoError(t, err)
+
+	for name, test := range map[string]struct {
+		dates   []time.Time
+		options []InterpolatorOptions
+
+		want     [][]string
+		wantErrs int
+	}{
+		"interpolates between points": {
+			dates:   []time.Time{time.Date(2020, 3, 30, 14, 5, 0, 0, time.UTC)},
+			options: []InterpolatorOptions{WithMaxGap(10 * time.Minute)},
+			want:    [][]string{{"2020-03-30T14:05:00Z", "40.705000", "-74.005000"}},
+		},
+		"snaps to track ends within max gap": {
+			dates: []time.Time{time.Date(2020, 3, 30, 13, 58, 0, 0, time.UTC)},
+			want:  [][]string{{"2020-03-30T13:58:00Z", "40.710000", "-74.010000"}},
+		},
+		"rejects photos in a gap larger than max gap": {
+			dates:    []time.Time{time.Date(2020, 3, 30, 15, 0, 0, 0, time.UTC)},
+			want:     [][]string{},
+			wantErrs: 1,
+		},
+		"larger max gap": {
+			dates:   []time.Time{time.Date(2020, 3, 30, 15, 5, 0, 0, time.UTC)},
+			options: []InterpolatorOptions{WithMaxGap(2 * time.Hour)},
+			want:    [][]string{{"2020-03-30T15:05:00Z", "40.750000", "-74.050000"}},
+		},
+		"camera clock offset": {
+			dates:   []time.Time{time.Date(2020, 3, 30, 15, 5, 0, 0, time.UTC)},
+			options: []InterpolatorOptions{WithClockOffset(time.Hour), WithMaxGap(10 * time.Minute)},
+			want:    [][]string{{"2020-03-30T14:05:00Z", "40.705000", "-74.005000"}},
+		},
+		"outside of track": {
+			dates:    []time.Time{time.Date(2020, 3, 31, 14, 5, 0, 0, time.UTC)},
+			want:     [][]string{},
+			wantErrs: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, errs := NewInterpolator(track, test.options...).Rows(test.dates)
+			require.Len(t, errs, test.wantErrs)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package i18n
+
+// German is the Catalog for German
+var German = &Catalog{
+	Language: "de",
+	Periods: map[string]Noun{
+		"morning":      {Text: "Morgen", Gender: Masculine},
+		"afternoon":    {Text: "Nachmittag", Gender: Masculine},
+		"evening":      {Text: "Abend", Gender: Masculine},
+		"night":        {Text: "Nacht", Gender: Feminine},
+		"day":          {Text: "Tag", Gender: Masculine},
+		"weekend":      {Text: "Wochenende", Gender: Neuter},
+		"long weekend": {Text: "langes Wochenende", Gender: Neuter},
+		"few days":     {Text: "Tage", Gender: Masculine, Plural: true},
+		"week":         {Text: "Woche", Gender: Feminine},
+		"fortnight":    {Text: "Wochen", Gender: Feminine, Plural: true, Article: "Zwei"},
+		"month":        {Text: "Monat", Gender: Masculine},
+		"few months":   {Text: "Monate", Gender: Masculine, Plural: true},
+		"season":       {Text: "Jahreszeit", Gender: Feminine},
+		"year":         {Text: "Jahr", Gender: Neuter},
+		"night out":    {Text: "Partynacht", Gender: Feminine},
+	},
+	Seasons: map[string]Season{
+		"winter": {Noun: Noun{Text: "Winter", Gender: Masculine}, Adjective: germanAdjective("winterlich")},
+		"spring": {Noun: Noun{Text: "Frühling", Gender: Masculine}, Adjective: germanAdjective("frühlingshaft")},
+		"summer": {Noun: Noun{Text: "Sommer", Gender: Masculine}, Adjective: germanAdjective("sommerlich")},
+		"autumn": {Noun: Noun{Text: "Herbst", Gender: Masculine}, Adjective: germanAdjective("herbstlich")},
+	},
+	Weather: map[string]Adjective{
+		"sunny":      germanAdjective("sonnig"),
+		"rainy":      germanAdjective("regnerisch"),
+		"stormy":     germanAdjective("stürmisch"),
+		"snowy":      germanAdjective("verschneit"),
+		"chilly":     germanAdjective("frostig"),
+		"foggy":      germanAdjective("neblig"),
+		"changeable": germanAdjective("wechselhaft"),
+		"starry":     germanAdjective("sternklar"),
+	},
+	Holidays: map[string]Holiday{
+		"new-year":         {Noun: Noun{Text: "Neujahr", Gender: Neuter}, Modifier: "Neujahrs"},
+		"valentines-day":   {Noun: Noun{Text: "Valentinstag", Gender: Masculine}, Modifier: "Valentins"},
+		"easter":           {Noun: Noun{Text: "Ostern", Gender: Neuter}, Modifier: "Oster"},
+		"halloween":        {Noun: Noun{Text: "Halloween", Gender: Neuter}, Modifier: "Halloween"},
+		"christmas-eve":    {Noun: Noun{Text: "Heiligabend", Gender: Masculine}, Modifier: "Heiligabend"},
+		"christmas":        {Noun: Noun{Text: "Weihnachten", Gender: Neuter}, Modifier: "Weihnachts"},
+		"new-years-eve":    {Noun: Noun{Text: "Silvester", Gender: Neuter}, Modifier: "Silvester"},
+		"carnival":         {Noun: Noun{Text: "Karneval", Gender: Masculine}, Modifier: "Karnevals"},
+		"german-unity-day": {Noun: Noun{Text: "Tag der Deutschen Einheit", Gender: Masculine}, Modifier: "Einheits"},
+	},
+	Then:           "%s, dann %s",
+	Mostly:         "überwiegend %s",
+	HolidayWeekend: "%swochenende",
+	HolidayNight:   "%snacht",
+	In:             "in",
+	Landmarks: map[string]string{
+		"at":     "am",
+		"at the": "am",
+		"on":     "am",
+		"on the": "am",
+		"in the": "in",
+	},
+	Light: map[string]string{
+		"golden hour": "zur goldenen Stunde",
+		"blue hour":   "zur blauen Stunde",
+	},
+	Sky: map[string]string{
+		"full moon": "bei Vollmond",
+	},
+	Terrain: map[string]string{
+		"mountains": "in den Bergen",
+		"coast":     "an der Küste",
+	},
+	Travel: map[string]Noun{
+		"walking tour":         {Text: "Stadtrundgang", Gender: Masculine},
+		"road trip":            {Text: "Roadtrip", Gender: Masculine},
+		"hike":                 {Text: "Wanderung", Gender: Feminine},
+		"boat trip":            {Text: "Bootsfahrt", Gender: Feminine},
+		"multi-flight journey": {Text: "Flugreise", Gender: Feminine},
+	},
+	Across:     "durch",
+	Through:    "durch",
+	And:        "und",
+	Indefinite: germanIndefinite,
+}
+
+// germanAdjective is a helper function used to return the forms of a German adjective following an
+// indefinite article, e.g. ein sonniger Tag, eine sonnige Woche, ein sonniges Wochenende, einige sonnige Tage
+func germanAdjective(stem string) Adjective {
+	return Adjective{
+		Singular: [3]string{stem + "er", stem + "e", stem + "es"},
+		Plural:   [3]string{stem + "e", stem + "e", stem + "e"},
+	}
+}
+
+// germanIndefinite is a helper function used to prefix phrase with ein or eine, or with einige in the plural
+func germanIndefinite(noun Noun, phrase string) string {
+	switch {
+	case noun.Plural:
+		return "Einige " + phrase
+	case noun.Gender == Feminine:
+		return "Eine " + phrase
+	}
+	return "Ein " + phrase
+}
@@ -0,0 +1,35 @@
+package i18n
+
+// English is the Catalog for English. Keys are English words, so they are used as they are.
+var English = &Catalog{
+	Language:       "en",
+	Then:           "%s then %s",
+	Mostly:         "mostly %s",
+	HolidayWeekend: "%s weekend",
+	HolidayNight:   "%s night",
+	In:             "in",
+	Through:        "through",
+	And:            "and",
+	Across:         "through",
+	Light: map[string]string{
+		"golden hour": "golden-hour",
+		"blue hour":   "blue-hour",
+	},
+	LightBefore: true,
+	Sky: map[string]string{
+		"full moon": "under a full moon",
+	},
+	Terrain: map[string]string{
+		"mountains": "in the mountains",
+		"coast":     "on the coast",
+	},
+	Indefinite: englishIndefinite,
+}
+
+// englishIndefinite is a helper function used to prefix phrase with "A", or "An" before a vowel
+func englishIndefinite(_ Noun, phrase string) string {
+	if startsWithVowel(phrase) {
+		return "An " + phrase
+	}
+	return "A " + phrase
+}
@@ -0,0 +1,121 @@
+package i18n
+
+// Spanish is the Catalog for Spanish
+var Spanish = &Catalog{
+	Language: "es",
+	Periods: map[string]Noun{
+		"morning":      {Text: "mañana", Gender: Feminine},
+		"afternoon":    {Text: "tarde", Gender: Feminine},
+		"evening":      {Text: "atardecer", Gender: Masculine},
+		"night":        {Text: "noche", Gender: Feminine},
+		"day":          {Text: "día", Gender: Masculine},
+		"weekend":      {Text: "fin de semana", Gender: Masculine},
+		"long weekend": {Text: "puente", Gender: Masculine},
+		"few days":     {Text: "días", Gender: Masculine, Plural: true},
+		"week":         {Text: "semana", Gender: Feminine},
+		"fortnight":    {Text: "quincena", Gender: Feminine},
+		"month":        {Text: "mes", Gender: Masculine},
+		"few months":   {Text: "meses", Gender: Masculine, Plural: true},
+		"season":       {Text: "temporada", Gender: Feminine},
+		"year":         {Text: "año", Gender: Masculine},
+		"night out":    {Text: "noche de fiesta", Gender: Feminine},
+	},
+	Seasons: map[string]Season{
+		"winter": {Noun: Noun{Text: "invierno", Gender: Masculine}, Adjective: spanishAdjective("invernal", "")},
+		"spring": {Noun: Noun{Text: "primavera", Gender: Feminine}, Adjective: spanishAdjective("primaveral", "")},
+		"summer": {Noun: Noun{Text: "verano", Gender: Masculine}, Adjective: spanishAdjective("veranieg", "o")},
+		"autumn": {Noun: Noun{Text: "otoño", Gender: Masculine}, Adjective: spanishAdjective("otoñal", "")},
+	},
+	Weather: map[string]Adjective{
+		"sunny":      spanishAdjective("solead", "o"),
+		"rainy":      spanishAdjective("lluvios", "o"),
+		"stormy":     spanishAdjective("tormentos", "o"),
+		"snowy":      spanishAdjective("nevad", "o"),
+		"chilly":     spanishAdjective("frí", "o"),
+		"foggy":      spanishAdjective("brumos", "o"),
+		"changeable": spanishAdjective("variable", ""),
+		"starry":     spanishAdjective("estrellad", "o"),
+	},
+	Holidays: map[string]Holiday{
+		"new-year":        {Noun: Noun{Text: "Año Nuevo", Gender: Masculine}, Modifier: "de Año Nuevo"},
+		"valentines-day":  {Noun: Noun{Text: "San Valentín", Gender: Masculine}, Modifier: "de San Valentín"},
+		"easter":          {Noun: Noun{Text: "Pascua", Gender: Feminine}, Modifier: "de Pascua"},
+		"halloween":       {Noun: Noun{Text: "Halloween", Gender: Masculine}, Modifier: "de Halloween"},
+		"christmas-eve":   {Noun: Noun{Text: "Nochebuena", Gender: Feminine}, Modifier: "de Nochebuena"},
+		"christmas":       {Noun: Noun{Text: "Navidad", Gender: Feminine}, Modifier: "de Navidad"},
+		"new-years-eve":   {Noun: Noun{Text: "Nochevieja", Gender: Feminine}, Modifier: "de Nochevieja"},
+		"epiphany":        {Noun: Noun{Text: "Día de Reyes", Gender: Masculine}, Modifier: "de Reyes"},
+		"carnival":        {Noun: Noun{Text: "Carnaval", Gender: Masculine}, Modifier: "de Carnaval"},
+		"holy-week":       {Noun: Noun{Text: "Semana Santa", Gender: Feminine}, Modifier: "de Semana Santa"},
+		"day-of-the-dead": {Noun: Noun{Text: "Día de Muertos", Gender: Masculine}, Modifier: "de Muertos"},
+	},
+	Then:            "%s y luego %s",
+	Mostly:          "mayormente %s",
+	HolidayWeekend:  "fin de semana %s",
+	HolidayNight:    "noche %s",
+	AdjectivesAfter: true,
+	In:              "en",
+	Landmarks: map[string]string{
+		"at":     "en",
+		"at the": "en",
+		"on":     "en",
+		"on the": "en",
+		"in the": "en",
+		"in":     "en",
+	},
+	Light: map[string]string{
+		"golden hour": "a la hora dorada",
+		"blue hour":   "a la hora azul",
+	},
+	Sky: map[string]string{
+		"full moon": "de luna llena",
+	},
+	Terrain: map[string]string{
+		"mountains": "en la montaña",
+		"coast":     "en la costa",
+	},
+	Travel: map[string]Noun{
+		"walking tour":         {Text: "recorrido a pie", Gender: Masculine},
+		"road trip":            {Text: "viaje por carretera", Gender: Masculine},
+		"hike":                 {Text: "excursión", Gender: Feminine},
+		"boat trip":            {Text: "paseo en barco", Gender: Masculine},
+		"multi-flight journey": {Text: "viaje en avión", Gender: Masculine},
+	},
+	Across:     "por",
+	Through:    "por",
+	And:        "y",
+	Indefinite: spanishIndefinite,
+}
+
+// spanishAdjective is a helper function used to return the forms of a Spanish adjective from its stem and
+// its masculine singular ending, either o (lluvioso, lluviosa, lluviosos, lluviosas) or none for adjectives
+// only inflected in number (otoñal, otoñales; variable, variables)
+func spanishAdjective(stem, ending string) Adjective {
+	if ending == "o" {
+		return Adjective{
+			Singular: [3]string{stem + "o", stem + "a", stem + "o"},
+			Plural:   [3]string{stem + "os", stem + "as", stem + "os"},
+		}
+	}
+	plural := stem + "es"
+	if startsWithVowel(stem[len(stem)-1:]) {
+		plural = stem + "s"
+	}
+	return Adjective{
+		Singular: [3]string{stem, stem, stem},
+		Plural:   [3]string{plural, plural, plural},
+	}
+}
+
+// spanishIndefinite is a helper function used to prefix phrase with un or una, or with unos or unas in the plural
+func spanishIndefinite(noun Noun, phrase string) string {
+	switch {
+	case noun.Plural && noun.Gender == Feminine:
+		return "Unas " + phrase
+	case noun.Plural:
+		return "Unos " + phrase
+	case noun.Gender == Feminine:
+		return "Una " + phrase
+	}
+	return "Un " + phrase
+}
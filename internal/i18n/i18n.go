@@ -0,0 +1,338 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Gender is the grammatical gender of a noun
+type Gender int
+
+const (
+	Masculine Gender = iota
+	Feminine
+	Neuter
+)
+
+// Noun is a custom type used to describe a noun and the grammar words qualifying it agree with
+type Noun struct {
+	Text   string
+	Gender Gender
+	Plural bool
+	// Article replaces the indefinite article of the language, e.g. "Due" in "Due settimane piovose"
+	Article string
+}
+
+// Adjective is a custom type used to hold the forms of an adjective agreeing with each gender,
+// indexed by Gender, in the singular and in the plural
+type Adjective struct {
+	Singular, Plural [3]string
+}
+
+// Invariable returns an Adjective with the same form for every gender and number
+func Invariable(form string) Adjective {
+	return Adjective{
+		Singular: [3]string{form, form, form},
+		Plural:   [3]string{form, form, form},
+	}
+}
+
+// Agree is used to return the form of the adjective agreeing with noun
+func (a Adjective) Agree(noun Noun) string {
+	if noun.Plural {
+		return a.Plural[noun.Gender]
+	}
+	return a.Singular[noun.Gender]
+}
+
+// Season is a custom type used to describe the words for a season
+type Season struct {
+	// Noun is used when the season is the period of the album, e.g. "A rainy autumn"
+	Noun Noun
+	// Adjective is used when the season qualifies the period of the album, e.g. "An autumn week"
+	Adjective Adjective
+}
+
+// Holiday is a custom type used to describe the words for a holiday
+type Holiday struct {
+	Noun Noun
+	// Modifier is the form of the holiday qualifying a weekend or a night, e.g. "di Pasqua" in "weekend di Pasqua"
+	Modifier string
+}
+
+// Catalog is a custom type used to hold the words and grammar rules titles are composed with in a language.
+// Words are keyed by the English identifiers used by the processor, e.g. "few days", "autumn", "sunny" or
+// "christmas", and missing words fall back to their key, or to the English holiday name.
+type Catalog struct {
+	// Language is the ISO 639-1 code of the language, e.g. it
+	Language string
+	Periods  map[string]Noun
+	Seasons  map[string]Season
+	Weather  map[string]Adjective
+	// Holidays is keyed by calendar holiday ID
+	Holidays map[string]Holiday
+	// Then and Mostly are used to format weather changes and prevailing weather, e.g. "%s then %s" and "mostly %s"
+	Then, Mostly string
+	// HolidayWeekend and HolidayNight are used to format the modifier of a holiday into a weekend or a night,
+	// e.g. "%s weekend". The resulting noun has the gender of the weekend and night periods.
+	HolidayWeekend, HolidayNight string
+	// AdjectivesAfter is set for languages placing adjectives after the noun they qualify
+	AdjectivesAfter bool
+	// In is the preposition preceding the place, unless overridden for its level in Prepositions
+	In           string
+	Prepositions map[string]string
+	// Light is keyed by natural light, e.g. "golden hour", and holds the phrase describing it, which follows the noun
+	// unless LightBefore is set, e.g. "all'ora d'oro" after the noun or "golden-hour" before it
+	Light       map[string]string
+	LightBefore bool
+	// Sky is keyed by night sky, e.g. "full moon", and holds the phrase describing it, which follows the noun,
+	// e.g. "under a full moon". Starry nights are described by the "starry" weather adjective instead.
+	Sky map[string]string
+	// Terrain is keyed by kind of terrain, mountains or coast, and holds the phrase placing an album in it,
+	// e.g. "on the coast"
+	Terrain map[string]string
+	// Landmarks translates the English prepositions introducing landmarks, which are used as they are when missing
+	Landmarks map[string]string
+	// Through is the preposition preceding the stops of an itinerary, and And the conjunction joining the last two
+	Through, And string
+	// Travel is keyed by travel mode, e.g. "road trip". Across is the preposition preceding the place of a trip,
+	// falling back to the preposition of its level when empty.
+	Travel map[string]Noun
+	Across string
+	// Indefinite is used to prefix a phrase with the indefinite article agreeing with its noun
+	Indefinite func(noun Noun, phrase string) string
+}
+
+// Title is a custom type used to describe the facets of an album a title is composed of, by key
+type Title struct {
+	// Weather is the weather adjective, and Then the one it changed into over the album, if any
+	Weather, Then string
+	// Mostly is set when the weather only prevailed over most of the album
+	Mostly bool
+	Period string
+	// Holiday is the ID of the holiday the album was taken on and HolidayName its English name.
+	// HolidayPeriod is either weekend or night when the album covers the weekend or the night of the holiday.
+	Holiday, HolidayName, HolidayPeriod string
+	Season                              string
+	Place                               string
+	// PlaceLevel is the administrative level of the place, e.g. locality
+	PlaceLevel string
+	// Preposition is the English preposition introducing a landmark place, e.g. "on the" in "on the Las Vegas Strip"
+	Preposition string
+	// Places lists the stops of an itinerary, replacing the place when there is more than one
+	Places []string
+	// Travel is the travel mode of the album, e.g. "road trip", replacing the period
+	Travel string
+	// Light is the natural light of the album, e.g. "golden hour". Night albums are a "night out", replacing the period.
+	Light string
+	// Sky is the night sky of the album, e.g. "full moon" or "starry", making the period a night
+	Sky string
+}
+
+// catalogs lists the supported languages, keyed by ISO 639-1 code
+var catalogs = map[string]*Catalog{
+	English.Language: English,
+	Italian.Language: Italian,
+	German.Language:  German,
+	Spanish.Language: Spanish,
+}
+
+// Languages is used to return the codes of the supported languages
+func Languages() []string {
+	languages := make([]string, 0, len(catalogs))
+	for language := range catalogs {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Lookup is used to return the Catalog for a language code. Region subtags are ignored, e.g. it-CH is Italian.
+func Lookup(language string) (*Catalog, error) {
+	code := strings.ToLower(language)
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	catalog, found := catalogs[code]
+	if !found {
+		return nil, fmt.Errorf("unsupported language %q, use one of %s", language, strings.Join(Languages(), ", "))
+	}
+	return catalog, nil
+}
+
+// Title is used to compose a title from its facets
+func (c *Catalog) Title(t Title) string {
+	noun, season := c.noun(t)
+	adjectives := make([]string, 0, 2)
+	if weather := c.weather(t, noun); weather != "" {
+		adjectives = append(adjectives, weather)
+	}
+	if season != "" {
+		adjectives = append(adjectives, season)
+	}
+	if light := c.light(t); light != "" && c.LightBefore {
+		adjectives = append([]string{light}, adjectives...)
+	} else if light != "" {
+		noun.Text += " " + light
+	}
+	if sky, found := c.Sky[t.Sky]; found {
+		noun.Text += " " + sky
+	}
+
+	var words []string
+	if c.AdjectivesAfter {
+		words = append(words, noun.Text)
+		for i := len(adjectives) - 1; i >= 0; i-- {
+			words = append(words, adjectives[i])
+		}
+	} else {
+		words = append(adjectives, noun.Text)
+	}
+	phrase := strings.Join(words, " ")
+	if noun.Article != "" {
+		phrase = noun.Article + " " + phrase
+	} else {
+		phrase = c.Indefinite(noun, phrase)
+	}
+	if len(t.Places) > 1 {
+		return phrase + " " + c.Through + " " + c.list(t.Places)
+	}
+	if t.PlaceLevel == "terrain" {
+		return phrase + " " + c.terrain(t.Place)
+	}
+	preposition := c.preposition(t.PlaceLevel)
+	if t.Travel != "" && c.Across != "" {
+		preposition = c.Across
+	}
+	if t.Preposition != "" {
+		preposition = t.Preposition
+		if translated, found := c.Landmarks[t.Preposition]; found {
+			preposition = translated
+		}
+	}
+	return phrase + " " + preposition + " " + t.Place
+}
+
+// list is a helper function used to join words into a list, e.g. "Naples, Amalfi and Positano"
+func (c *Catalog) list(words []string) string {
+	last := len(words) - 1
+	return strings.Join(words[:last], ", ") + " " + c.And + " " + words[last]
+}
+
+// noun is a helper function used to return the noun a title is built around, and the form of the season
+// qualifying it, if any
+func (c *Catalog) noun(t Title) (Noun, string) {
+	switch {
+	case t.Holiday != "":
+		holiday, found := c.Holidays[t.Holiday]
+		if !found {
+			holiday = Holiday{Noun: Noun{Text: t.HolidayName}, Modifier: t.HolidayName}
+		}
+		switch t.HolidayPeriod {
+		case "weekend":
+			return Noun{Text: fmt.Sprintf(c.HolidayWeekend, holiday.Modifier), Gender: c.period("weekend").Gender}, ""
+		case "night":
+			return Noun{Text: fmt.Sprintf(c.HolidayNight, holiday.Modifier), Gender: c.period("night").Gender}, ""
+		}
+		return holiday.Noun, ""
+	case t.Light == "night":
+		return c.period("night out"), ""
+	case t.Travel != "" && t.Season != "":
+		noun := c.travel(t.Travel)
+		return noun, c.season(t.Season).Adjective.Agree(noun)
+	case t.Travel != "":
+		return c.travel(t.Travel), ""
+	case t.Sky != "":
+		return c.period("night"), ""
+	case t.Season != "" && t.Period == "season":
+		return c.season(t.Season).Noun, ""
+	case t.Season != "":
+		noun := c.period(t.Period)
+		return noun, c.season(t.Season).Adjective.Agree(noun)
+	case t.Period == "season":
+		// without a season to name, e.g. with calendar detection disabled, a season is a few months
+		return c.period("few months"), ""
+	}
+	return c.period(t.Period), ""
+}
+
+// weather is a helper function used to return the weather of a title agreeing with noun
+func (c *Catalog) weather(t Title, noun Noun) string {
+	if t.Weather == "" {
+		return ""
+	}
+	weather := c.adjective(t.Weather).Agree(noun)
+	switch {
+	case t.Then != "":
+		return fmt.Sprintf(c.Then, weather, c.adjective(t.Then).Agree(noun))
+	case t.Mostly:
+		return fmt.Sprintf(c.Mostly, weather)
+	}
+	return weather
+}
+
+// period is a helper function used to return the noun for a period, falling back to its key
+func (c *Catalog) period(key string) Noun {
+	if noun, found := c.Periods[key]; found {
+		return noun
+	}
+	return Noun{Text: key}
+}
+
+// light is a helper function used to return the phrase describing the light of a title, if any, falling back to its key.
+// Nights are described by their noun instead.
+func (c *Catalog) light(t Title) string {
+	if t.Light == "" || t.Light == "night" {
+		return ""
+	}
+	if phrase, found := c.Light[t.Light]; found {
+		return phrase
+	}
+	return t.Light
+}
+
+// terrain is a helper function used to return the phrase placing an album in a terrain, falling back to its key
+func (c *Catalog) terrain(key string) string {
+	if phrase, found := c.Terrain[key]; found {
+		return phrase
+	}
+	return c.In + " " + key
+}
+
+// travel is a helper function used to return the noun for a travel mode, falling back to its key
+func (c *Catalog) travel(key string) Noun {
+	if noun, found := c.Travel[key]; found {
+		return noun
+	}
+	return Noun{Text: key}
+}
+
+// season is a helper function used to return the words for a season, falling back to its key
+func (c *Catalog) season(key string) Season {
+	if season, found := c.Seasons[key]; found {
+		return season
+	}
+	return Season{Noun: Noun{Text: key}, Adjective: Invariable(key)}
+}
+
+// adjective is a helper function used to return a weather adjective, falling back to its key
+func (c *Catalog) adjective(key string) Adjective {
+	if adjective, found := c.Weather[key]; found {
+		return adjective
+	}
+	return Invariable(key)
+}
+
+// preposition is a helper function used to return the preposition preceding a place at an administrative level
+func (c *Catalog) preposition(level string) string {
+	if preposition, found := c.Prepositions[level]; found {
+		return preposition
+	}
+	return c.In
+}
+
+// startsWithVowel is a helper function used to tell whether phrase starts with a vowel
+func startsWithVowel(phrase string) bool {
+	return phrase != "" && strings.ContainsRune("aeiouAEIOU", rune(phrase[0]))
+}
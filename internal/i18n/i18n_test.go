@@ -0,0 +1,249 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	for name, test := range map[string]struct {
+		language string
+
+		expect  *Catalog
+		wantErr bool
+	}{
+		"english":        {language: "en", expect: English},
+		"italian":        {language: "it", expect: Italian},
+		"german":         {language: "de", expect: German},
+		"spanish":        {language: "es", expect: Spanish},
+		"region subtag":  {language: "it-CH", expect: Italian},
+		"upper case":     {language: "ES_mx", expect: Spanish},
+		"unsupported":    {language: "fr", wantErr: true},
+		"empty language": {language: "", wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := Lookup(test.language)
+			require.Equal(t, test.wantErr, err != nil, err)
+			require.Equal(t, test.expect, got)
+		})
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	require.Equal(t, []string{"de", "en", "es", "it"}, Languages())
+}
+
+func TestCatalog_Title(t *testing.T) {
+	for name, test := range map[string]struct {
+		catalog *Catalog
+		input   Title
+
+		expect string
+	}{
+		"english": {
+			catalog: English,
+			input:   Title{Weather: "rainy", Period: "few days", Place: "New York"},
+			expect:  "A rainy few days in New York",
+		},
+		"english unknown words": {
+			catalog: English,
+			input:   Title{Weather: "cloudy", Period: "week", Holiday: "thanksgiving", HolidayName: "Thanksgiving", HolidayPeriod: "weekend", Place: "Boston"},
+			expect:  "A cloudy Thanksgiving weekend in Boston",
+		},
+		"english itinerary": {
+			catalog: English,
+			input:   Title{Weather: "sunny", Period: "week", Place: "Campania", Places: []string{"Naples", "Amalfi", "Positano"}},
+			expect:  "A sunny week through Naples, Amalfi and Positano",
+		},
+		"single stop itinerary": {
+			catalog: English,
+			input:   Title{Weather: "sunny", Period: "week", Place: "Campania", Places: []string{"Naples"}},
+			expect:  "A sunny week in Campania",
+		},
+		"english landmark": {
+			catalog: English,
+			input:   Title{Weather: "sunny", Period: "evening", Place: "Las Vegas Strip", PlaceLevel: "landmark", Preposition: "on the"},
+			expect:  "A sunny evening on the Las Vegas Strip",
+		},
+		"italian landmark": {
+			catalog: Italian,
+			input:   Title{Weather: "foggy", Period: "day", Place: "Central Park", PlaceLevel: "landmark", Preposition: "at"},
+			expect:  "Una giornata nebbiosa a Central Park",
+		},
+		"german landmark": {
+			catalog: German,
+			input:   Title{Weather: "sunny", Period: "day", Place: "Grand Canyon", PlaceLevel: "landmark", Preposition: "at the"},
+			expect:  "Ein sonniger Tag am Grand Canyon",
+		},
+		"english road trip": {
+			catalog: English,
+			input:   Title{Weather: "rainy", Period: "day", Place: "Campania", PlaceLevel: "region", Travel: "road trip"},
+			expect:  "A rainy road trip through Campania",
+		},
+		"italian seasonal hike": {
+			catalog: Italian,
+			input:   Title{Weather: "sunny", Period: "week", Season: "autumn", Place: "Positano", PlaceLevel: "locality", Travel: "hike"},
+			expect:  "Un'escursione autunnale soleggiata a Positano",
+		},
+		"spanish boat trip": {
+			catalog: Spanish,
+			input:   Title{Weather: "sunny", Period: "day", Place: "Campania", PlaceLevel: "region", Travel: "boat trip"},
+			expect:  "Un paseo en barco soleado por Campania",
+		},
+		"english coast": {
+			catalog: English,
+			input:   Title{Weather: "sunny", Period: "day", Place: "coast", PlaceLevel: "terrain"},
+			expect:  "A sunny day on the coast",
+		},
+		"german mountains": {
+			catalog: German,
+			input:   Title{Weather: "snowy", Period: "week", Place: "mountains", PlaceLevel: "terrain"},
+			expect:  "Eine verschneite Woche in den Bergen",
+		},
+		"english golden hour": {
+			catalog: English,
+			input:   Title{Period: "evening", Place: "Positano", PlaceLevel: "locality", Light: "golden hour"},
+			expect:  "A golden-hour evening in Positano",
+		},
+		"english night out": {
+			catalog: English,
+			input:   Title{Period: "evening", Place: "Las Vegas", PlaceLevel: "locality", Light: "night"},
+			expect:  "A night out in Las Vegas",
+		},
+		"italian golden hour": {
+			catalog: Italian,
+			input:   Title{Period: "evening", Place: "Positano", PlaceLevel: "locality", Light: "golden hour"},
+			expect:  "Una sera all'ora d'oro a Positano",
+		},
+		"spanish rainy night out": {
+			catalog: Spanish,
+			input:   Title{Weather: "rainy", Period: "night", Place: "Las Vegas", PlaceLevel: "locality", Light: "night"},
+			expect:  "Una noche de fiesta lluviosa en Las Vegas",
+		},
+		"english full moon": {
+			catalog: English,
+			input:   Title{Period: "evening", Place: "Positano", PlaceLevel: "locality", Sky: "full moon"},
+			expect:  "A night under a full moon in Positano",
+		},
+		"german starry night": {
+			catalog: German,
+			input:   Title{Weather: "starry", Period: "evening", Place: "Positano", PlaceLevel: "locality", Sky: "starry"},
+			expect:  "Eine sternklare Nacht in Positano",
+		},
+		"italian itinerary": {
+			catalog: Italian,
+			input:   Title{Weather: "rainy", Period: "few days", Places: []string{"Napoli", "Positano"}},
+			expect:  "Alcuni giorni piovosi tra Napoli e Positano",
+		},
+		"italian masculine": {
+			catalog: Italian,
+			input:   Title{Weather: "sunny", Period: "weekend", Place: "Campania", PlaceLevel: "region"},
+			expect:  "Un weekend soleggiato in Campania",
+		},
+		"italian apostrophe": {
+			catalog: Italian,
+			input:   Title{Weather: "foggy", Period: "season", Season: "summer", Place: "Milano", PlaceLevel: "locality"},
+			expect:  "Un'estate nebbiosa a Milano",
+		},
+		"italian plural": {
+			catalog: Italian,
+			input:   Title{Weather: "rainy", Period: "few days", Place: "Positano", PlaceLevel: "locality"},
+			expect:  "Alcuni giorni piovosi a Positano",
+		},
+		"italian explicit article": {
+			catalog: Italian,
+			input:   Title{Weather: "snowy", Period: "fortnight", Place: "Trentino"},
+			expect:  "Due settimane nevose in Trentino",
+		},
+		"italian season and weather": {
+			catalog: Italian,
+			input:   Title{Weather: "changeable", Period: "week", Season: "autumn", Place: "Positano", PlaceLevel: "locality"},
+			expect:  "Una settimana autunnale variabile a Positano",
+		},
+		"italian holiday night": {
+			catalog: Italian,
+			input:   Title{Weather: "sunny", Period: "evening", Holiday: "halloween", HolidayName: "Halloween", HolidayPeriod: "night", Place: "Positano", PlaceLevel: "locality"},
+			expect:  "Una notte di Halloween soleggiata a Positano",
+		},
+		"italian weather change": {
+			catalog: Italian,
+			input:   Title{Weather: "rainy", Then: "sunny", Period: "weekend", Place: "Roma", PlaceLevel: "locality"},
+			expect:  "Un weekend piovoso poi soleggiato a Roma",
+		},
+		"italian uno": {
+			catalog: Italian,
+			input:   Title{Period: "stadium", Place: "Roma"},
+			expect:  "Uno stadium in Roma",
+		},
+		"german neuter": {
+			catalog: German,
+			input:   Title{Weather: "rainy", Then: "sunny", Period: "weekend", Place: "Berlin"},
+			expect:  "Ein regnerisches, dann sonniges Wochenende in Berlin",
+		},
+		"german feminine": {
+			catalog: German,
+			input:   Title{Weather: "sunny", Mostly: true, Period: "week", Season: "summer", Place: "Bayern"},
+			expect:  "Eine überwiegend sonnige sommerliche Woche in Bayern",
+		},
+		"german masculine": {
+			catalog: German,
+			input:   Title{Weather: "snowy", Period: "season", Season: "winter", Place: "Tirol"},
+			expect:  "Ein verschneiter Winter in Tirol",
+		},
+		"german plural": {
+			catalog: German,
+			input:   Title{Weather: "foggy", Period: "few days", Place: "Hamburg"},
+			expect:  "Einige neblige Tage in Hamburg",
+		},
+		"german holiday": {
+			catalog: German,
+			input:   Title{Weather: "snowy", Period: "day", Holiday: "christmas", HolidayName: "Christmas", Place: "München"},
+			expect:  "Ein verschneites Weihnachten in München",
+		},
+		"spanish feminine": {
+			catalog: Spanish,
+			input:   Title{Weather: "snowy", Period: "day", Holiday: "christmas", HolidayName: "Christmas", Place: "Nueva York"},
+			expect:  "Una Navidad nevada en Nueva York",
+		},
+		"spanish holiday weekend": {
+			catalog: Spanish,
+			input:   Title{Weather: "sunny", Period: "weekend", Holiday: "easter", HolidayName: "Easter", HolidayPeriod: "weekend", Place: "Sevilla"},
+			expect:  "Un fin de semana de Pascua soleado en Sevilla",
+		},
+		"spanish plural adjective": {
+			catalog: Spanish,
+			input:   Title{Weather: "changeable", Period: "few days", Place: "Bilbao"},
+			expect:  "Unos días variables en Bilbao",
+		},
+		"spanish season": {
+			catalog: Spanish,
+			input:   Title{Weather: "chilly", Mostly: true, Period: "month", Season: "autumn", Place: "Madrid"},
+			expect:  "Un mes otoñal mayormente frío en Madrid",
+		},
+		"english season without a name": {
+			catalog: English,
+			input:   Title{Weather: "sunny", Period: "season", Place: "Positano", PlaceLevel: "locality"},
+			expect:  "A sunny few months in Positano",
+		},
+		"italian season without a name": {
+			catalog: Italian,
+			input:   Title{Weather: "sunny", Period: "season", Place: "Positano", PlaceLevel: "locality"},
+			expect:  "Alcuni mesi soleggiati a Positano",
+		},
+		"german season without a name": {
+			catalog: German,
+			input:   Title{Weather: "sunny", Period: "season", Place: "Bayern"},
+			expect:  "Einige sonnige Monate in Bayern",
+		},
+		"spanish season without a name": {
+			catalog: Spanish,
+			input:   Title{Weather: "sunny", Period: "season", Place: "Madrid"},
+			expect:  "Unos meses soleados en Madrid",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expect, test.catalog.Title(test.input))
+		})
+	}
+}
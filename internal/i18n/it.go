@@ -0,0 +1,138 @@
+package i18n
+
+import "strings"
+
+// Italian is the Catalog for Italian
+var Italian = &Catalog{
+	Language: "it",
+	Periods: map[string]Noun{
+		"morning":      {Text: "mattina", Gender: Feminine},
+		"afternoon":    {Text: "pomeriggio", Gender: Masculine},
+		"evening":      {Text: "sera", Gender: Feminine},
+		"night":        {Text: "notte", Gender: Feminine},
+		"day":          {Text: "giornata", Gender: Feminine},
+		"weekend":      {Text: "weekend", Gender: Masculine},
+		"long weekend": {Text: "ponte", Gender: Masculine},
+		"few days":     {Text: "giorni", Gender: Masculine, Plural: true},
+		"week":         {Text: "settimana", Gender: Feminine},
+		"fortnight":    {Text: "settimane", Gender: Feminine, Plural: true, Article: "Due"},
+		"month":        {Text: "mese", Gender: Masculine},
+		"few months":   {Text: "mesi", Gender: Masculine, Plural: true},
+		"season":       {Text: "stagione", Gender: Feminine},
+		"year":         {Text: "anno", Gender: Masculine},
+		"night out":    {Text: "serata fuori", Gender: Feminine},
+	},
+	Seasons: map[string]Season{
+		"winter": {Noun: Noun{Text: "inverno", Gender: Masculine}, Adjective: italianAdjective("invernal", "e")},
+		"spring": {Noun: Noun{Text: "primavera", Gender: Feminine}, Adjective: italianAdjective("primaveril", "e")},
+		"summer": {Noun: Noun{Text: "estate", Gender: Feminine}, Adjective: italianAdjective("estiv", "o")},
+		"autumn": {Noun: Noun{Text: "autunno", Gender: Masculine}, Adjective: italianAdjective("autunnal", "e")},
+	},
+	Weather: map[string]Adjective{
+		"sunny":      italianAdjective("soleggiat", "o"),
+		"rainy":      italianAdjective("piovos", "o"),
+		"stormy":     italianAdjective("tempestos", "o"),
+		"snowy":      italianAdjective("nevos", "o"),
+		"chilly":     italianAdjective("fredd", "o"),
+		"foggy":      italianAdjective("nebbios", "o"),
+		"changeable": italianAdjective("variabil", "e"),
+		"starry":     italianAdjective("stellat", "o"),
+	},
+	Holidays: map[string]Holiday{
+		"new-year":       {Noun: Noun{Text: "Capodanno", Gender: Masculine}, Modifier: "di Capodanno"},
+		"valentines-day": {Noun: Noun{Text: "San Valentino", Gender: Masculine}, Modifier: "di San Valentino"},
+		"easter":         {Noun: Noun{Text: "Pasqua", Gender: Feminine}, Modifier: "di Pasqua"},
+		"halloween":      {Noun: Noun{Text: "Halloween", Gender: Masculine}, Modifier: "di Halloween"},
+		"christmas-eve":  {Noun: Noun{Text: "Vigilia di Natale", Gender: Feminine}, Modifier: "della Vigilia di Natale"},
+		"christmas":      {Noun: Noun{Text: "Natale", Gender: Masculine}, Modifier: "di Natale"},
+		"new-years-eve":  {Noun: Noun{Text: "San Silvestro", Gender: Masculine}, Modifier: "di San Silvestro"},
+		"epiphany":       {Noun: Noun{Text: "Epifania", Gender: Feminine}, Modifier: "dell'Epifania"},
+		"carnival":       {Noun: Noun{Text: "Carnevale", Gender: Masculine}, Modifier: "di Carnevale"},
+		"ferragosto":     {Noun: Noun{Text: "Ferragosto", Gender: Masculine}, Modifier: "di Ferragosto"},
+		"holy-week":      {Noun: Noun{Text: "Settimana Santa", Gender: Feminine}, Modifier: "della Settimana Santa"},
+	},
+	Then:            "%s poi %s",
+	Mostly:          "per lo più %s",
+	HolidayWeekend:  "weekend %s",
+	HolidayNight:    "notte %s",
+	AdjectivesAfter: true,
+	In:              "in",
+	Landmarks: map[string]string{
+		"at":     "a",
+		"at the": "a",
+		"on":     "su",
+		"on the": "su",
+		"in":     "a",
+		"in the": "in",
+	},
+	Through: "tra",
+	And:     "e",
+	Light: map[string]string{
+		"golden hour": "all'ora d'oro",
+		"blue hour":   "all'ora blu",
+	},
+	Sky: map[string]string{
+		"full moon": "di luna piena",
+	},
+	Terrain: map[string]string{
+		"mountains": "in montagna",
+		"coast":     "sulla costa",
+	},
+	Travel: map[string]Noun{
+		"walking tour":         {Text: "tour a piedi", Gender: Masculine},
+		"road trip":            {Text: "viaggio in auto", Gender: Masculine},
+		"hike":                 {Text: "escursione", Gender: Feminine},
+		"boat trip":            {Text: "gita in barca", Gender: Feminine},
+		"multi-flight journey": {Text: "viaggio in aereo", Gender: Masculine},
+	},
+	Prepositions: map[string]string{
+		"neighbourhood": "a",
+		"locality":      "a",
+	},
+	Indefinite: italianIndefinite,
+}
+
+// italianAdjective is a helper function used to return the forms of an Italian adjective from its stem
+// and its masculine singular ending, either o (piovoso, piovosa, piovosi, piovose) or e (variabile, variabili)
+func italianAdjective(stem, ending string) Adjective {
+	if ending == "e" {
+		return Adjective{
+			Singular: [3]string{stem + "e", stem + "e", stem + "e"},
+			Plural:   [3]string{stem + "i", stem + "i", stem + "i"},
+		}
+	}
+	return Adjective{
+		Singular: [3]string{stem + "o", stem + "a", stem + "o"},
+		Plural:   [3]string{stem + "i", stem + "e", stem + "i"},
+	}
+}
+
+// italianIndefinite is a helper function used to prefix phrase with un, uno, una or un', or with
+// alcuni or alcune in the plural
+func italianIndefinite(noun Noun, phrase string) string {
+	switch {
+	case noun.Plural && noun.Gender == Feminine:
+		return "Alcune " + phrase
+	case noun.Plural:
+		return "Alcuni " + phrase
+	case noun.Gender == Feminine && startsWithVowel(phrase):
+		return "Un'" + phrase
+	case noun.Gender == Feminine:
+		return "Una " + phrase
+	case italianImpure(phrase):
+		return "Uno " + phrase
+	}
+	return "Un " + phrase
+}
+
+// italianImpure is a helper function used to tell whether phrase starts with the sounds taking uno,
+// e.g. s followed by a consonant, z or gn
+func italianImpure(phrase string) bool {
+	phrase = strings.ToLower(phrase)
+	for _, prefix := range []string{"z", "x", "y", "gn", "ps", "pn"} {
+		if strings.HasPrefix(phrase, prefix) {
+			return true
+		}
+	}
+	return len(phrase) > 1 && phrase[0] == 's' && !startsWithVowel(phrase[1:])
+}
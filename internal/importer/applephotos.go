@@ -0,0 +1,162 @@
+package importer
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+)
+
+// xmpDateLayouts lists the date formats allowed by the XMP specification, most precise first
+var xmpDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// ApplePhotos is used to scan an Apple Photos export folder, pairing every photo with its XMP sidecar
+func ApplePhotos(dir string) ([]processor.Metadata, []error) {
+	files, err := mediaFiles(dir)
+	if err != nil {
+		return nil, []error{err}
+	}
+	errs := make([]error, 0, len(files))
+	album := make([]processor.Metadata, 0, len(files))
+	for _, file := range files {
+		sidecar, err := findXMPSidecar(file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid photo %s: %w", file, err))
+			continue
+		}
+		metadata, err := readXMPSidecar(sidecar)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid photo %s: %w", file, err))
+			continue
+		}
+		album = append(album, metadata)
+	}
+	return album, errs
+}
+
+// findXMPSidecar is a helper function used to return the path of the sidecar describing file.
+// Apple Photos replaces the media extension, other tools append to it.
+func findXMPSidecar(file string) (string, error) {
+	base := strings.TrimSuffix(file, filepath.Ext(file))
+	for _, candidate := range []string{base + ".xmp", base + ".XMP", file + ".xmp", file + ".XMP"} {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", errors.New("no xmp sidecar found")
+}
+
+// readXMPSidecar is a helper function used to map an XMP sidecar to the processor metadata
+func readXMPSidecar(file string) (processor.Metadata, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return processor.Metadata{}, err
+	}
+	defer f.Close()
+	fields, err := xmpFields(f)
+	if err != nil {
+		return processor.Metadata{}, fmt.Errorf("failed to decode sidecar: %w", err)
+	}
+
+	var date time.Time
+	for _, name := range []string{"DateTimeOriginal", "DateCreated", "CreateDate"} {
+		if value, found := fields[name]; found {
+			if date, err = parseXMPDate(value); err != nil {
+				return processor.Metadata{}, err
+			}
+			break
+		}
+	}
+	if date.IsZero() {
+		return processor.Metadata{}, errors.New("missing date")
+	}
+	latitude, latErr := parseXMPCoordinate(fields["GPSLatitude"])
+	longitude, lonErr := parseXMPCoordinate(fields["GPSLongitude"])
+	if latErr != nil || lonErr != nil || (latitude == 0 && longitude == 0) {
+		return processor.Metadata{}, errUnknownLocation
+	}
+	return processor.Metadata{Latitude: latitude, Longitude: longitude, Date: date}, nil
+}
+
+// xmpFields is a helper function used to collect the properties of an XMP packet by local name.
+// Properties can be written either as attributes of rdf:Description or as child elements.
+func xmpFields(r io.Reader) (map[string]string, error) {
+	fields := map[string]string{}
+	decoder := xml.NewDecoder(r)
+	var current string
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return fields, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			current = t.Name.Local
+			for _, attr := range t.Attr {
+				fields[attr.Name.Local] = attr.Value
+			}
+		case xml.CharData:
+			if value := strings.TrimSpace(string(t)); value != "" && current != "" {
+				fields[current] = value
+			}
+		case xml.EndElement:
+			current = ""
+		}
+	}
+}
+
+// parseXMPDate is a helper function used to parse the XMP date formats
+func parseXMPDate(value string) (time.Time, error) {
+	for _, layout := range xmpDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date: %q", value)
+}
+
+// parseXMPCoordinate is a helper function used to parse XMP GPS coordinates, written
+// as "DDD,MM,SSk" or "DDD,MM.mmk" where k is the N, S, E or W reference.
+func parseXMPCoordinate(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, errors.New("missing coordinate")
+	}
+	if decimal, err := strconv.ParseFloat(value, 64); err == nil {
+		return decimal, nil
+	}
+	ref := strings.ToUpper(value[len(value)-1:])
+	parts := strings.Split(value[:len(value)-1], ",")
+	if len(parts) < 2 || len(parts) > 3 || !strings.Contains("NSEW", ref) {
+		return 0, fmt.Errorf("invalid coordinate: %q", value)
+	}
+	var coordinate float64
+	for i, part := range parts {
+		number, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid coordinate: %q", value)
+		}
+		coordinate += number / []float64{1, 60, 3600}[i]
+	}
+	if ref == "S" || ref == "W" {
+		coordinate = -coordinate
+	}
+	return coordinate, nil
+}
@@ -0,0 +1,97 @@
+package importer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+	"github.com/stretchr/testify/require"
+)
+
+const xmpElements = `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+  <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+    <rdf:Description rdf:about="" xmlns:exif="http://ns.adobe.com/exif/1.0/" xmlns:photoshop="http://ns.adobe.com/photoshop/1.0/">
+      <exif:GPSLatitude>40,37.67298N</exif:GPSLatitude>
+      <exif:GPSLongitude>14,22.01148E</exif:GPSLongitude>
+      <photoshop:DateCreated>2019-10-31T19:52:59+01:00</photoshop:DateCreated>
+    </rdf:Description>
+  </rdf:RDF>
+</x:xmpmeta>`
+
+const xmpAttributes = `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+  <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+    <rdf:Description rdf:about="" xmlns:exif="http://ns.adobe.com/exif/1.0/"
+      exif:GPSLatitude="36,6,10.17N" exif:GPSLongitude="115,10,25.73W" exif:DateTimeOriginal="2019-12-01T05:16:45Z"/>
+  </rdf:RDF>
+</x:xmpmeta>`
+
+func TestImporter_ApplePhotos(t *testing.T) {
+	for name, test := range map[string]struct {
+		files map[string]string
+
+		want     []processor.Metadata
+		wantErrs int
+	}{
+		"pairs sidecars with photos": {
+			files: map[string]string{
+				"IMG_0001.HEIC":    "",
+				"IMG_0001.XMP":     xmpElements,
+				"IMG_0002.jpg":     "",
+				"IMG_0002.jpg.xmp": xmpAttributes,
+			},
+			want: []processor.Metadata{
+				{Latitude: 40.627883, Longitude: 14.366858, Date: time.Date(2019, 10, 31, 18, 52, 59, 0, time.UTC)},
+				{Latitude: 36.102825, Longitude: -115.173814, Date: time.Date(2019, 12, 1, 5, 16, 45, 0, time.UTC)},
+			},
+		},
+		"skips photos without sidecar, date or location": {
+			files: map[string]string{
+				"IMG_0001.HEIC": "",
+				"IMG_0002.HEIC": "",
+				"IMG_0002.XMP":  `<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:Description exif:GPSLatitude="36,6N" exif:GPSLongitude="115,10W"/></x:xmpmeta>`,
+				"IMG_0003.HEIC": "",
+				"IMG_0003.XMP":  `<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:Description exif:DateTimeOriginal="2019-12-01T05:16:45"/></x:xmpmeta>`,
+				"IMG_0004.HEIC": "",
+				"IMG_0004.XMP":  `<x:xmpmeta`,
+			},
+			want:     []processor.Metadata{},
+			wantErrs: 4,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, errs := ApplePhotos(writeFiles(t, test.files))
+			require.Len(t, errs, test.wantErrs)
+			require.Len(t, got, len(test.want))
+			for i := range test.want {
+				require.InDelta(t, test.want[i].Latitude, got[i].Latitude, 0.000001)
+				require.InDelta(t, test.want[i].Longitude, got[i].Longitude, 0.000001)
+				require.True(t, test.want[i].Date.Equal(got[i].Date))
+			}
+		})
+	}
+}
+
+func TestImporter_parseXMPCoordinate(t *testing.T) {
+	for name, test := range map[string]struct {
+		input string
+
+		want    float64
+		wantErr bool
+	}{
+		"degrees and decimal minutes": {input: "40,37.67298N", want: 40.627883},
+		"degrees minutes seconds":     {input: "115,10,25.73W", want: -115.173814},
+		"southern hemisphere":         {input: "33,52.0S", want: -33.866667},
+		"decimal degrees":             {input: "14.366858", want: 14.366858},
+		"missing reference":           {input: "40,37.67298", wantErr: true},
+		"not a number":                {input: "north", wantErr: true},
+		"empty":                       {input: "", wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseXMPCoordinate(test.input)
+			if (err != nil) != test.wantErr {
+				t.Errorf("importer.parseXMPCoordinate() error = %v, wantErr %v", err, test.wantErr)
+			}
+			require.InDelta(t, test.want, got, 0.000001)
+		})
+	}
+}
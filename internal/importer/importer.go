@@ -0,0 +1,106 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+)
+
+// Importer is a function scanning an exported photo library folder and returning album input
+type Importer func(dir string) ([]processor.Metadata, []error)
+
+// importers holds every supported export format
+var importers = map[string]Importer{
+	"takeout":      Takeout,
+	"apple-photos": ApplePhotos,
+}
+
+// mediaExtensions lists the file extensions treated as photos or videos when pairing sidecars
+var mediaExtensions = map[string]bool{
+	".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".webp": true,
+	".heic": true, ".heif": true, ".tif": true, ".tiff": true,
+	".dng": true, ".cr2": true, ".cr3": true, ".nef": true, ".arw": true, ".raf": true, ".orf": true,
+	".mov": true, ".mp4": true, ".m4v": true,
+}
+
+// errDetected is used to stop scanning a folder as soon as its format is known
+var errDetected = errors.New("format detected")
+
+// errUnknownLocation is returned for photos whose sidecar has no location, which Takeout records as 0,0
+var errUnknownLocation = errors.New("unknown location")
+
+// Formats returns the names of every supported export format
+func Formats() []string {
+	formats := make([]string, 0, len(importers))
+	for format := range importers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Import is used to scan an export folder with the importer registered for format,
+// or the one matching the sidecars found in the folder when no format is provided.
+func Import(dir, format string) ([]processor.Metadata, []error) {
+	if format == "" {
+		detected, err := Detect(dir)
+		if err != nil {
+			return nil, []error{err}
+		}
+		format = detected
+	}
+	importer, found := importers[format]
+	if !found {
+		return nil, []error{fmt.Errorf("unknown export format %q, use one of %s", format, strings.Join(Formats(), ", "))}
+	}
+	return importer(dir)
+}
+
+// Detect is used to guess the export format of a folder from the sidecars it contains
+func Detect(dir string) (string, error) {
+	format := ""
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			format = "takeout"
+		case ".xmp":
+			format = "apple-photos"
+		default:
+			return nil
+		}
+		return errDetected
+	})
+	if err != nil && err != errDetected {
+		return "", fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+	if format == "" {
+		return "", fmt.Errorf("no sidecar files found in %s", dir)
+	}
+	return format, nil
+}
+
+// mediaFiles is a helper function used to list every photo or video below dir
+func mediaFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && mediaExtensions[strings.ToLower(filepath.Ext(path))] {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+	return files, nil
+}
@@ -0,0 +1,69 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles is a helper function used to create an export folder from a map of relative paths to contents
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestImporter_Detect(t *testing.T) {
+	for name, test := range map[string]struct {
+		files map[string]string
+
+		want    string
+		wantErr bool
+	}{
+		"takeout": {
+			files: map[string]string{"Photos from 2019/IMG_0001.jpg": "", "Photos from 2019/IMG_0001.jpg.json": "{}"},
+			want:  "takeout",
+		},
+		"apple photos": {
+			files: map[string]string{"IMG_0001.HEIC": "", "IMG_0001.XMP": ""},
+			want:  "apple-photos",
+		},
+		"no sidecars": {
+			files:   map[string]string{"IMG_0001.jpg": ""},
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := Detect(writeFiles(t, test.files))
+			if (err != nil) != test.wantErr {
+				t.Errorf("importer.Detect() error = %v, wantErr %v", err, test.wantErr)
+			}
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestImporter_Import(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"IMG_0001.jpg":      "",
+		"IMG_0001.jpg.json": `{"title":"IMG_0001.jpg","photoTakenTime":{"timestamp":"1572548000"},"geoData":{"latitude":40.627883,"longitude":14.366858}}`,
+	})
+
+	album, errs := Import(dir, "")
+	require.Empty(t, errs)
+	require.Len(t, album, 1)
+
+	album, errs = Import(dir, "apple-photos")
+	require.Len(t, errs, 1)
+	require.Empty(t, album)
+
+	album, errs = Import(dir, "flickr")
+	require.Len(t, errs, 1)
+	require.Empty(t, album)
+}
@@ -0,0 +1,152 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+)
+
+// takeoutSidecar is used to unmarshal the per photo JSON written by Google Photos Takeout
+type takeoutSidecar struct {
+	Title          string `json:"title"`
+	PhotoTakenTime struct {
+		Timestamp string `json:"timestamp"`
+	} `json:"photoTakenTime"`
+	GeoData     takeoutGeoData `json:"geoData"`
+	GeoDataExif takeoutGeoData `json:"geoDataExif"`
+}
+
+type takeoutGeoData struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Takeout sidecars either append .json to the media name, or a possibly truncated
+// .supplemental-metadata.json in newer exports. Duplicates named IMG(1).jpg get IMG.jpg(1).json.
+var (
+	duplicateRegExp = regexp.MustCompile(`^(.*)(\(\d+\))(\.[^.]+)$`)
+	editedRegExp    = regexp.MustCompile(`-(edited|bearbeitet|modifié|modificato|editado)(\.[^.]+)$`)
+)
+
+// Takeout is used to scan a Google Photos Takeout folder, pairing every photo with its JSON sidecar
+func Takeout(dir string) ([]processor.Metadata, []error) {
+	files, err := mediaFiles(dir)
+	if err != nil {
+		return nil, []error{err}
+	}
+	errs := make([]error, 0, len(files))
+	album := make([]processor.Metadata, 0, len(files))
+	titles := make(map[string]map[string]string)
+	for _, file := range files {
+		sidecar, err := findTakeoutSidecar(file, titles)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid photo %s: %w", file, err))
+			continue
+		}
+		metadata, err := readTakeoutSidecar(sidecar)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid photo %s: %w", file, err))
+			continue
+		}
+		album = append(album, metadata)
+	}
+	return album, errs
+}
+
+// findTakeoutSidecar is a helper function used to return the path of the sidecar describing file,
+// caching in titles the sidecars of every directory indexed by the title they describe
+func findTakeoutSidecar(file string, titles map[string]map[string]string) (string, error) {
+	dir, name := filepath.Split(file)
+	names := []string{name}
+	if m := editedRegExp.FindStringSubmatch(name); m != nil {
+		names = append(names, strings.TrimSuffix(name, m[0])+m[2])
+	}
+	candidates := make([]string, 0, len(names)*3)
+	for _, name := range names {
+		candidates = append(candidates, name+".json", name+".supplemental-metadata.json")
+		if m := duplicateRegExp.FindStringSubmatch(name); m != nil {
+			candidates = append(candidates, m[1]+m[3]+m[2]+".json", m[1]+m[3]+".supplemental-metadata"+m[2]+".json")
+		}
+	}
+	for _, candidate := range candidates {
+		if _, err := os.Stat(filepath.Join(dir, candidate)); err == nil {
+			return filepath.Join(dir, candidate), nil
+		}
+	}
+
+	// long names get truncated, so fall back to a sidecar whose title is the media name.
+	index, ok := titles[dir]
+	if !ok {
+		index = takeoutTitles(dir)
+		titles[dir] = index
+	}
+	for _, name := range []string{name, names[len(names)-1]} {
+		if sidecar, ok := index[name]; ok {
+			return sidecar, nil
+		}
+	}
+	return "", fmt.Errorf("no takeout sidecar found")
+}
+
+// takeoutTitles is a helper function used to index the sidecars of dir by the title they describe,
+// reading the directory once rather than once per photo
+func takeoutTitles(dir string) map[string]string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	index := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		sidecar := takeoutSidecar{}
+		err = json.NewDecoder(f).Decode(&sidecar)
+		f.Close()
+		if _, ok := index[sidecar.Title]; err == nil && sidecar.Title != "" && !ok {
+			index[sidecar.Title] = path
+		}
+	}
+	return index
+}
+
+// readTakeoutSidecar is a helper function used to map a Takeout sidecar to the processor metadata
+func readTakeoutSidecar(file string) (processor.Metadata, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return processor.Metadata{}, err
+	}
+	defer f.Close()
+	sidecar := takeoutSidecar{}
+	if err := json.NewDecoder(f).Decode(&sidecar); err != nil {
+		return processor.Metadata{}, fmt.Errorf("failed to decode sidecar: %w", err)
+	}
+	timestamp, err := strconv.ParseInt(sidecar.PhotoTakenTime.Timestamp, 10, 64)
+	if err != nil {
+		return processor.Metadata{}, fmt.Errorf("invalid date: %w", err)
+	}
+	location := sidecar.GeoData
+	if location.Latitude == 0 && location.Longitude == 0 {
+		location = sidecar.GeoDataExif
+	}
+	if location.Latitude == 0 && location.Longitude == 0 {
+		return processor.Metadata{}, errUnknownLocation
+	}
+	return processor.Metadata{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+		Date:      time.Unix(timestamp, 0).UTC(),
+	}, nil
+}
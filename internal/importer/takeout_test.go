@@ -0,0 +1,70 @@
+package importer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/processor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImporter_Takeout(t *testing.T) {
+	for name, test := range map[string]struct {
+		files map[string]string
+
+		want     []processor.Metadata
+		wantErrs int
+	}{
+		"pairs sidecars with photos": {
+			files: map[string]string{
+				"IMG_0001.jpg":      "",
+				"IMG_0001.jpg.json": `{"title":"IMG_0001.jpg","photoTakenTime":{"timestamp":"1572548000"},"geoData":{"latitude":40.627883,"longitude":14.366858}}`,
+				"IMG_0002.jpg":      "",
+				"IMG_0002.jpg.supplemental-metadata.json":     `{"title":"IMG_0002.jpg","photoTakenTime":{"timestamp":"1572548060"},"geoData":{"latitude":40.627808,"longitude":14.364933}}`,
+				"IMG_0003(1).jpg":                             "",
+				"IMG_0003.jpg(1).json":                        `{"title":"IMG_0003.jpg","photoTakenTime":{"timestamp":"1572548120"},"geoData":{"latitude":40.628197,"longitude":14.367075}}`,
+				"IMG_0004-edited.jpg":                         "",
+				"IMG_0004.jpg.json":                           `{"title":"IMG_0004.jpg","photoTakenTime":{"timestamp":"1572548180"},"geoData":{"latitude":0,"longitude":0},"geoDataExif":{"latitude":40.634303,"longitude":14.60258}}`,
+				"PXL_20191031_185300000_a_very_long_name.jpg": "",
+				"PXL_20191031_185300000_a_very_long_na.json":  `{"title":"PXL_20191031_185300000_a_very_long_name.jpg","photoTakenTime":{"timestamp":"1572548240"},"geoData":{"latitude":40.6,"longitude":14.3}}`,
+				"metadata.json":                               `{"title":"Positano"}`,
+			},
+			want: []processor.Metadata{
+				{Latitude: 40.627883, Longitude: 14.366858, Date: time.Unix(1572548000, 0).UTC()},
+				{Latitude: 40.627808, Longitude: 14.364933, Date: time.Unix(1572548060, 0).UTC()},
+				{Latitude: 40.628197, Longitude: 14.367075, Date: time.Unix(1572548120, 0).UTC()},
+				{Latitude: 40.634303, Longitude: 14.60258, Date: time.Unix(1572548180, 0).UTC()},
+				{Latitude: 40.6, Longitude: 14.3, Date: time.Unix(1572548240, 0).UTC()},
+			},
+		},
+		"truncated names in folders with glob characters": {
+			files: map[string]string{
+				"Trip [2019] *best*/PXL_20191031_185300000_a_very_long_name.jpg": "",
+				"Trip [2019] *best*/PXL_20191031_185300000_a_very_long_na.json":  `{"title":"PXL_20191031_185300000_a_very_long_name.jpg","photoTakenTime":{"timestamp":"1572548240"},"geoData":{"latitude":40.6,"longitude":14.3}}`,
+				"Trip [2019] *best*/metadata.json":                               `{"title":"Trip"}`,
+			},
+			want: []processor.Metadata{
+				{Latitude: 40.6, Longitude: 14.3, Date: time.Unix(1572548240, 0).UTC()},
+			},
+		},
+		"skips unknown locations and missing sidecars": {
+			files: map[string]string{
+				"IMG_0001.jpg":      "",
+				"IMG_0001.jpg.json": `{"title":"IMG_0001.jpg","photoTakenTime":{"timestamp":"1572548000"},"geoData":{"latitude":0,"longitude":0}}`,
+				"IMG_0002.mp4":      "",
+				"IMG_0003.jpg":      "",
+				"IMG_0003.jpg.json": `{"title":"IMG_0003.jpg","photoTakenTime":{"timestamp":"yesterday"},"geoData":{"latitude":40.6,"longitude":14.3}}`,
+				"IMG_0004.jpg":      "",
+				"IMG_0004.jpg.json": `what`,
+			},
+			want:     []processor.Metadata{},
+			wantErrs: 4,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, errs := Takeout(writeFiles(t, test.files))
+			require.Len(t, errs, test.wantErrs)
+			require.ElementsMatch(t, test.want, got)
+		})
+	}
+}
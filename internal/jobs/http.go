@@ -0,0 +1,127 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// DefaultMaxBody is the size in bytes of the largest album accepted by the HTTP API, unless set with WithMaxBody
+const DefaultMaxBody = 32 << 20
+
+// handler is used to hold the settings of the HTTP API
+type handler struct {
+	maxBody int64
+}
+
+type HandlerOptions func(*handler)
+
+// WithMaxBody sets the size in bytes of the largest album accepted by the HTTP API, larger ones are answered with 413
+func WithMaxBody(bytes int64) HandlerOptions {
+	return func(h *handler) {
+		if bytes > 0 {
+			h.maxBody = bytes
+		}
+	}
+}
+
+// Handler returns the HTTP API of m, answering in JSON:
+//
+//	POST   /jobs?name=NAME&format=FORMAT  queues a job titling the album in the request body, 202 with the job or 413 when too large
+//	GET    /jobs                          lists every job
+//	GET    /jobs/{id}                     returns a job, with its progress and its result once done
+//	DELETE /jobs/{id}                     cancels a job, 202 with the job or 409 when it is already finished
+//
+// formatOf is used to resolve the format of submitted inputs from their name and requested format, failing for unsupported ones.
+func Handler(m *Manager, formatOf func(name, format string) (string, error), options ...HandlerOptions) http.Handler {
+	h := &handler{maxBody: DefaultMaxBody}
+	for _, option := range options {
+		option(h)
+	}
+	r := mux.NewRouter()
+	r.HandleFunc("/jobs", func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		format, err := formatOf(name, r.URL.Query().Get("format"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		body := &requestBody{r: http.MaxBytesReader(w, r.Body, h.maxBody)}
+		job, err := m.Submit(name, format, body)
+		switch {
+		case err != nil && body.err != nil && body.read >= h.maxBody:
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("album larger than %d bytes", h.maxBody))
+			return
+		case err != nil && body.err != nil:
+			writeError(w, http.StatusBadRequest, err)
+			return
+		case err != nil:
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Location", "/jobs/"+job.ID)
+		writeJSON(w, http.StatusAccepted, job)
+	}).Methods(http.MethodPost)
+	r.HandleFunc("/jobs", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, struct {
+			Jobs []Job `json:"jobs"`
+		}{m.List()})
+	}).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		job, err := m.Get(mux.Vars(r)["id"])
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, job)
+	}).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		job, err := m.Cancel(mux.Vars(r)["id"])
+		switch {
+		case errors.Is(err, ErrNotFound):
+			writeError(w, http.StatusNotFound, err)
+		case errors.Is(err, ErrFinished):
+			writeError(w, http.StatusConflict, err)
+		case err != nil:
+			writeError(w, http.StatusInternalServerError, err)
+		default:
+			writeJSON(w, http.StatusAccepted, job)
+		}
+	}).Methods(http.MethodDelete)
+	return r
+}
+
+// requestBody is a custom type used to tell the errors reading a request body apart from the errors saving it,
+// counting the bytes read so that bodies over the limit can be told apart from broken ones
+type requestBody struct {
+	r    io.Reader
+	read int64
+	err  error
+}
+
+func (b *requestBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.read += int64(n)
+	if err != nil && err != io.EOF {
+		b.err = err
+	}
+	return n, err
+}
+
+// writeJSON is a helper function used to answer with value encoded as JSON
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeError is a helper function used to answer with an error as a JSON object
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
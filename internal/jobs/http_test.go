@@ -0,0 +1,96 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+)
+
+func TestHandler(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenStore(dir)
+	require.NoError(t, err)
+	m, err := NewManager(store, func(ctx context.Context, job Job, input io.Reader, tracker *Tracker) (*batch.Result, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	require.NoError(t, err)
+	formatOf := func(name, format string) (string, error) {
+		if format == "" {
+			format = "csv"
+		}
+		if format != "csv" {
+			return "", fmt.Errorf("unknown input format %q", format)
+		}
+		return format, nil
+	}
+	server := httptest.NewServer(Handler(m, formatOf, WithMaxBody(64)))
+	defer server.Close()
+
+	// do is a helper function used to send a request to the API, decoding its JSON answer into value
+	do := func(method, path, body string, value interface{}) *http.Response {
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(value))
+		return resp
+	}
+
+	var submitted Job
+	resp := do(http.MethodPost, "/jobs?name=rome.csv", "2019-07-04T12:00:00Z,41.9,12.5\n", &submitted)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	require.Equal(t, "/jobs/"+submitted.ID, resp.Header.Get("Location"))
+	require.Equal(t, "rome.csv", submitted.Name)
+	require.Equal(t, "csv", submitted.Format)
+	require.Equal(t, Queued, submitted.State)
+
+	var failure struct {
+		Error string `json:"error"`
+	}
+	resp = do(http.MethodPost, "/jobs?format=xls", "", &failure)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.Equal(t, `unknown input format "xls"`, failure.Error)
+
+	resp = do(http.MethodPost, "/jobs?name=naples.csv", strings.Repeat("2019-07-04T12:00:00Z,40.8,14.2\n", 3), &failure)
+	require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	require.Equal(t, "album larger than 64 bytes", failure.Error)
+	inputs, err := filepath.Glob(filepath.Join(dir, "*.input*"))
+	require.NoError(t, err)
+	require.Len(t, inputs, 1)
+
+	var got Job
+	resp = do(http.MethodGet, "/jobs/"+submitted.ID, "", &got)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, submitted.ID, got.ID)
+
+	var list struct {
+		Jobs []Job `json:"jobs"`
+	}
+	resp = do(http.MethodGet, "/jobs", "", &list)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, list.Jobs, 1)
+
+	resp = do(http.MethodDelete, "/jobs/"+submitted.ID, "", &got)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	require.Equal(t, Cancelled, got.State)
+
+	resp = do(http.MethodDelete, "/jobs/"+submitted.ID, "", &failure)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = do(http.MethodGet, "/jobs/missing", "", &failure)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.Equal(t, ErrNotFound.Error(), failure.Error)
+}
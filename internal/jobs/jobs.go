@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+)
+
+// State is a custom type used to tell where a job is in its lifecycle
+type State string
+
+const (
+	Queued    State = "queued"
+	Running   State = "running"
+	Done      State = "done"
+	Failed    State = "failed"
+	Cancelled State = "cancelled"
+)
+
+// Finished is used to tell whether a job in this state is over, successfully or not
+func (s State) Finished() bool {
+	return s == Done || s == Failed || s == Cancelled
+}
+
+// Progress is a custom type used to describe how far a running job is
+type Progress struct {
+	// Rows is the number of photos read from the input, Done the number of them processed so far
+	Rows int `json:"rows"`
+	Done int `json:"done"`
+	// Errors is the number of processed photos that were discarded
+	Errors int `json:"errors"`
+	// APICalls is the number of requests sent to the geolocation and weather APIs
+	APICalls int `json:"api_calls"`
+}
+
+// Job is a custom type used to describe an album submitted to be titled in the background
+type Job struct {
+	ID string `json:"id"`
+	// Name is the name of the submitted input, e.g. the file it was read from
+	Name     string        `json:"name"`
+	Format   string        `json:"format"`
+	State    State         `json:"state"`
+	Progress Progress      `json:"progress"`
+	Result   *batch.Result `json:"result,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Created  time.Time     `json:"created"`
+	Started  *time.Time    `json:"started,omitempty"`
+	Finished *time.Time    `json:"finished,omitempty"`
+}
+
+// Runner is used to title the album of a job from its input, reporting progress to tracker.
+// It is expected to return as soon as ctx is done. A result returned along with an error is kept.
+type Runner func(ctx context.Context, job Job, input io.Reader, tracker *Tracker) (*batch.Result, error)
+
+// Tracker is a custom type used by a Runner to update the progress of its job. It is safe for concurrent use.
+type Tracker struct {
+	mu       *sync.Mutex
+	progress *Progress
+}
+
+// Rows is used to set the number of photos read from the input
+func (t *Tracker) Rows(rows int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.progress.Rows = rows
+}
+
+// Row is used to count a processed photo, along with the error it was discarded for, if any
+func (t *Tracker) Row(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.progress.Done++
+	if err != nil {
+		t.progress.Errors++
+	}
+}
+
+// APICall is used to count a request sent to an API
+func (t *Tracker) APICall() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.progress.APICalls++
+}
@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_Finished(t *testing.T) {
+	for state, expect := range map[State]bool{
+		Queued:    false,
+		Running:   false,
+		Done:      true,
+		Failed:    true,
+		Cancelled: true,
+	} {
+		require.Equal(t, expect, state.Finished(), state)
+	}
+}
+
+func TestTracker(t *testing.T) {
+	var mu sync.Mutex
+	progress := Progress{}
+	tracker := &Tracker{mu: &mu, progress: &progress}
+	tracker.Rows(3)
+
+	var wg sync.WaitGroup
+	for _, err := range []error{nil, errors.New("invalid photo"), nil} {
+		wg.Add(1)
+		go func(err error) {
+			defer wg.Done()
+			tracker.APICall()
+			tracker.APICall()
+			tracker.Row(err)
+		}(err)
+	}
+	wg.Wait()
+	require.Equal(t, Progress{Rows: 3, Done: 3, Errors: 1, APICalls: 6}, progress)
+}
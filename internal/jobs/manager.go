@@ -0,0 +1,357 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+)
+
+var (
+	// ErrNotFound is returned for jobs that do not exist
+	ErrNotFound = errors.New("job not found")
+	// ErrFinished is returned when cancelling jobs that are already over
+	ErrFinished = errors.New("job already finished")
+)
+
+// Manager is a custom type used to queue jobs and run them in the background, a few at a time.
+// Every change to a job is saved to its Store, and jobs left unfinished by a restart are queued again.
+// The input of a job is removed from the store once it is finished, and the job itself after the retention, if any.
+type Manager struct {
+	mu        sync.Mutex
+	store     *Store
+	run       Runner
+	workers   int
+	retention time.Duration
+	jobs      map[string]*Job
+	order     []string
+	pending   []string
+	cancels   map[string]context.CancelFunc
+	cancelled map[string]bool
+	wake      chan struct{}
+	newID     func() string
+	now       func() time.Time
+}
+
+type ManagerOptions func(*Manager)
+
+// WithWorkers sets how many jobs are run at once, 1 by default
+func WithWorkers(workers int) ManagerOptions {
+	return func(m *Manager) {
+		if workers > 0 {
+			m.workers = workers
+		}
+	}
+}
+
+// WithRetention sets how long finished jobs are kept for before they are removed, forever by default
+func WithRetention(retention time.Duration) ManagerOptions {
+	return func(m *Manager) {
+		if retention > 0 {
+			m.retention = retention
+		}
+	}
+}
+
+// NewManager returns a new Manager running jobs with run, loading the jobs of store.
+// Jobs that were queued or running when the store was last used are queued again from scratch.
+func NewManager(store *Store, run Runner, options ...ManagerOptions) (*Manager, error) {
+	manager := &Manager{
+		store:     store,
+		run:       run,
+		workers:   1,
+		jobs:      map[string]*Job{},
+		cancels:   map[string]context.CancelFunc{},
+		cancelled: map[string]bool{},
+		wake:      make(chan struct{}, 1),
+		newID:     newID,
+		now:       time.Now,
+	}
+	for _, option := range options {
+		option(manager)
+	}
+	jobs, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	for i := range jobs {
+		job := jobs[i]
+		if !job.State.Finished() {
+			job.State, job.Progress, job.Started = Queued, Progress{}, nil
+			if err := store.Save(job); err != nil {
+				return nil, err
+			}
+			manager.pending = append(manager.pending, job.ID)
+		} else if err := store.RemoveInput(job.ID); err != nil {
+			return nil, err
+		}
+		manager.jobs[job.ID] = &job
+		manager.order = append(manager.order, job.ID)
+	}
+	return manager, nil
+}
+
+// Submit is used to queue a job titling the album read from input, decoded as format.
+// The input is saved to the store before the job is returned.
+func (m *Manager) Submit(name, format string, input io.Reader) (Job, error) {
+	job := Job{ID: m.newID(), Name: name, Format: format, State: Queued, Created: m.now().UTC()}
+	if job.Name == "" {
+		job.Name = job.ID
+	}
+	if err := m.store.SaveInput(job.ID, input); err != nil {
+		return Job{}, err
+	}
+	if err := m.store.Save(job); err != nil {
+		return Job{}, err
+	}
+	stored := job
+	m.mu.Lock()
+	m.jobs[job.ID] = &stored
+	m.order = append(m.order, job.ID)
+	m.pending = append(m.pending, job.ID)
+	m.mu.Unlock()
+	m.signal()
+	return job, nil
+}
+
+// Get is used to return the job with id
+func (m *Manager) Get(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, found := m.jobs[id]
+	if !found {
+		return Job{}, ErrNotFound
+	}
+	return *job, nil
+}
+
+// List is used to return every job, oldest first
+func (m *Manager) List() []Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	jobs := make([]Job, 0, len(m.order))
+	for _, id := range m.order {
+		jobs = append(jobs, *m.jobs[id])
+	}
+	return jobs
+}
+
+// Cancel is used to cancel the job with id. Queued jobs are cancelled right away, running ones as soon as their Runner returns.
+func (m *Manager) Cancel(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, found := m.jobs[id]
+	if !found {
+		return Job{}, ErrNotFound
+	}
+	if job.State.Finished() {
+		return *job, ErrFinished
+	}
+	m.cancelled[id] = true
+	if cancel, running := m.cancels[id]; running {
+		cancel()
+		return *job, nil
+	}
+	for i, pending := range m.pending {
+		if pending == id {
+			m.pending = append(m.pending[:i], m.pending[i+1:]...)
+			break
+		}
+	}
+	m.finish(job, nil, context.Canceled)
+	if err := m.store.Save(*job); err != nil {
+		return *job, err
+	}
+	return *job, m.store.RemoveInput(id)
+}
+
+// Run is used to run the queued jobs until ctx is done, calling failed when a job cannot be saved or removed.
+// Jobs interrupted by ctx are queued again, to be run from scratch on the next start.
+func (m *Manager) Run(ctx context.Context, failed func(error)) {
+	var wg sync.WaitGroup
+	if m.retention > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.expire(ctx, failed)
+		}()
+	}
+	for i := 0; i < m.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				id, found := m.next()
+				if !found {
+					select {
+					case <-ctx.Done():
+						return
+					case <-m.wake:
+						continue
+					}
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				if err := m.process(ctx, id); err != nil {
+					failed(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// expire is a helper function used to remove the jobs finished longer than the retention ago until ctx is done,
+// checking every minute or more often for shorter retentions
+func (m *Manager) expire(ctx context.Context, failed func(error)) {
+	interval := m.retention
+	if interval > time.Minute {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := m.prune(); err != nil {
+			failed(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// prune is a helper function used to remove the jobs finished longer than the retention ago from the store.
+// Jobs that cannot be removed are kept, to be removed on the next call.
+func (m *Manager) prune() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expired := m.now().Add(-m.retention)
+	var err error
+	order := make([]string, 0, len(m.order))
+	for _, id := range m.order {
+		job := m.jobs[id]
+		if !job.State.Finished() || job.Finished == nil || job.Finished.After(expired) {
+			order = append(order, id)
+			continue
+		}
+		if removeErr := m.store.Remove(id); removeErr != nil {
+			err = removeErr
+			order = append(order, id)
+			continue
+		}
+		delete(m.jobs, id)
+	}
+	m.order = order
+	return err
+}
+
+// next is a helper function used to take the oldest queued job, waking up another worker when more are left
+func (m *Manager) next() (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.pending) == 0 {
+		return "", false
+	}
+	id := m.pending[0]
+	m.pending = m.pending[1:]
+	if len(m.pending) > 0 {
+		m.signal()
+	}
+	return id, true
+}
+
+// signal is a helper function used to wake up a worker waiting for jobs, if any
+func (m *Manager) signal() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// process is a helper function used to run a job and record its outcome. Changes of state are saved before
+// they are visible, so that the store never lags behind what clients were told.
+func (m *Manager) process(ctx context.Context, id string) error {
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	m.mu.Lock()
+	job := m.jobs[id]
+	if job.State.Finished() {
+		// cancelled between being taken from the queue and started
+		m.mu.Unlock()
+		return nil
+	}
+	started := m.now().UTC()
+	job.State, job.Started = Running, &started
+	m.cancels[id] = cancel
+	// the job is run even when it cannot be saved as running, as its outcome may still be
+	saveErr := m.store.Save(*job)
+	snapshot := *job
+	m.mu.Unlock()
+
+	result, err := m.runJob(jobCtx, snapshot, &Tracker{mu: &m.mu, progress: &job.Progress})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.cancels, id)
+	switch {
+	case m.cancelled[id]:
+		m.finish(job, result, context.Canceled)
+	case ctx.Err() != nil:
+		job.State, job.Progress, job.Started = Queued, Progress{}, nil
+	default:
+		m.finish(job, result, err)
+	}
+	if err := m.store.Save(*job); err != nil {
+		return err
+	}
+	if job.State.Finished() {
+		if err := m.store.RemoveInput(id); err != nil {
+			return err
+		}
+	}
+	return saveErr
+}
+
+// runJob is a helper function used to call the Runner with the saved input of a job
+func (m *Manager) runJob(ctx context.Context, job Job, tracker *Tracker) (*batch.Result, error) {
+	input, err := m.store.OpenInput(job.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	defer input.Close()
+	return m.run(ctx, job, input, tracker)
+}
+
+// finish is a helper function used to record the outcome of a job. It must be called with the lock held.
+func (m *Manager) finish(job *Job, result *batch.Result, err error) {
+	finished := m.now().UTC()
+	job.Finished, job.Result = &finished, result
+	delete(m.cancelled, job.ID)
+	switch {
+	case errors.Is(err, context.Canceled):
+		job.State, job.Error = Cancelled, ""
+	case err != nil:
+		job.State, job.Error = Failed, err.Error()
+	default:
+		job.State = Done
+	}
+}
+
+// newID is a helper function used to return a random job ID
+func newID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return fmt.Sprintf("%016x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(id)
+}
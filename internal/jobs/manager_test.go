@@ -0,0 +1,232 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+)
+
+// titleRows is a Runner titling every input after its name, counting each of its lines as a photo
+func titleRows(ctx context.Context, job Job, input io.Reader, tracker *Tracker) (*batch.Result, error) {
+	content, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	rows := strings.Split(strings.TrimSpace(string(content)), "\n")
+	tracker.Rows(len(rows))
+	for range rows {
+		tracker.APICall()
+		tracker.Row(nil)
+	}
+	return &batch.Result{Folder: job.Name, Title: "A day in " + job.Name, Photos: len(rows), Read: len(rows)}, nil
+}
+
+// start is a helper function used to run m in the background until the test is over
+func start(t *testing.T, m *Manager) context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.Run(ctx, func(err error) { t.Errorf("unexpected error: %v", err) })
+	}()
+	stop := func() {
+		cancel()
+		<-done
+	}
+	t.Cleanup(stop)
+	return stop
+}
+
+// waitFor is a helper function used to wait until the job with id is in state
+func waitFor(t *testing.T, m *Manager, id string, state State) Job {
+	var job Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = m.Get(id)
+		require.NoError(t, err)
+		return job.State == state
+	}, 5*time.Second, time.Millisecond)
+	return job
+}
+
+func TestManager_Run(t *testing.T) {
+	for name, test := range map[string]struct {
+		run Runner
+
+		expectState    State
+		expectProgress Progress
+		expectResult   *batch.Result
+		expectError    string
+	}{
+		"done": {
+			run:            titleRows,
+			expectState:    Done,
+			expectProgress: Progress{Rows: 2, Done: 2, APICalls: 2},
+			expectResult:   &batch.Result{Folder: "rome.csv", Title: "A day in rome.csv", Photos: 2, Read: 2},
+		},
+		"failed with a result": {
+			run: func(ctx context.Context, job Job, input io.Reader, tracker *Tracker) (*batch.Result, error) {
+				tracker.Rows(2)
+				tracker.Row(errors.New("invalid photo"))
+				tracker.Row(errors.New("invalid photo"))
+				return &batch.Result{Folder: job.Name, Read: 2, Errors: []string{"invalid photo", "invalid photo"}}, errors.New("no photo could be titled")
+			},
+			expectState:    Failed,
+			expectProgress: Progress{Rows: 2, Done: 2, Errors: 2},
+			expectResult:   &batch.Result{Folder: "rome.csv", Read: 2, Errors: []string{"invalid photo", "invalid photo"}},
+			expectError:    "no photo could be titled",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store, err := OpenStore(t.TempDir())
+			require.NoError(t, err)
+			m, err := NewManager(store, test.run, WithWorkers(2))
+			require.NoError(t, err)
+			start(t, m)
+
+			submitted, err := m.Submit("rome.csv", "csv", strings.NewReader("a\nb\n"))
+			require.NoError(t, err)
+			require.Equal(t, Queued, submitted.State)
+
+			got := waitFor(t, m, submitted.ID, test.expectState)
+			require.Equal(t, test.expectProgress, got.Progress)
+			require.Equal(t, test.expectResult, got.Result)
+			require.Equal(t, test.expectError, got.Error)
+			require.NotNil(t, got.Started)
+			require.NotNil(t, got.Finished)
+
+			saved, err := store.Load()
+			require.NoError(t, err)
+			require.Equal(t, []Job{got}, saved)
+			require.Equal(t, []Job{got}, m.List())
+		})
+	}
+}
+
+func TestManager_Cancel(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenStore(dir)
+	require.NoError(t, err)
+	started := make(chan string, 1)
+	m, err := NewManager(store, func(ctx context.Context, job Job, input io.Reader, tracker *Tracker) (*batch.Result, error) {
+		started <- job.ID
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	require.NoError(t, err)
+
+	queued, err := m.Submit("amalfi.csv", "csv", strings.NewReader("a\n"))
+	require.NoError(t, err)
+	got, err := m.Cancel(queued.ID)
+	require.NoError(t, err)
+	require.Equal(t, Cancelled, got.State)
+	_, err = m.Cancel(queued.ID)
+	require.ErrorIs(t, err, ErrFinished)
+	_, err = m.Cancel("missing")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	running, err := m.Submit("rome.csv", "csv", strings.NewReader("a\n"))
+	require.NoError(t, err)
+	start(t, m)
+	require.Equal(t, running.ID, <-started)
+	_, err = m.Cancel(running.ID)
+	require.NoError(t, err)
+	got = waitFor(t, m, running.ID, Cancelled)
+	require.Empty(t, got.Error)
+
+	saved, err := store.Load()
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	for _, job := range saved {
+		require.Equal(t, Cancelled, job.State)
+	}
+	inputs, err := filepath.Glob(filepath.Join(dir, "*.input"))
+	require.NoError(t, err)
+	require.Empty(t, inputs)
+}
+
+func TestManager_restart(t *testing.T) {
+	store, err := OpenStore(t.TempDir())
+	require.NoError(t, err)
+	var once sync.Once
+	started := make(chan struct{})
+	blocked, err := NewManager(store, func(ctx context.Context, job Job, input io.Reader, tracker *Tracker) (*batch.Result, error) {
+		tracker.Rows(2)
+		once.Do(func() { close(started) })
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	require.NoError(t, err)
+	interrupted, err := blocked.Submit("rome.csv", "csv", strings.NewReader("a\nb\n"))
+	require.NoError(t, err)
+	queued, err := blocked.Submit("amalfi.csv", "csv", strings.NewReader("a\n"))
+	require.NoError(t, err)
+	stop := start(t, blocked)
+	<-started
+	stop()
+
+	saved, err := store.Load()
+	require.NoError(t, err)
+	for _, job := range saved {
+		require.Equal(t, Queued, job.State)
+		require.Equal(t, Progress{}, job.Progress)
+	}
+
+	restarted, err := NewManager(store, titleRows)
+	require.NoError(t, err)
+	start(t, restarted)
+	require.Equal(t, "A day in rome.csv", waitFor(t, restarted, interrupted.ID, Done).Result.Title)
+	require.Equal(t, "A day in amalfi.csv", waitFor(t, restarted, queued.ID, Done).Result.Title)
+}
+
+func TestManager_expire(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenStore(dir)
+	require.NoError(t, err)
+	m, err := NewManager(store, titleRows, WithRetention(time.Hour))
+	require.NoError(t, err)
+
+	done, err := m.Submit("rome.csv", "csv", strings.NewReader("a\n"))
+	require.NoError(t, err)
+	stop := start(t, m)
+	waitFor(t, m, done.ID, Done)
+	stop()
+	_, err = os.Stat(filepath.Join(dir, done.ID+".input"))
+	require.True(t, os.IsNotExist(err), "the input of a finished job is removed")
+
+	queued, err := m.Submit("amalfi.csv", "csv", strings.NewReader("a\n"))
+	require.NoError(t, err)
+	require.NoError(t, m.prune())
+	require.Len(t, m.List(), 2, "jobs are kept for the retention")
+
+	m.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	require.NoError(t, m.prune())
+	_, err = m.Get(done.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Equal(t, []string{queued.ID}, jobIDs(m.List()))
+	saved, err := store.Load()
+	require.NoError(t, err)
+	require.Equal(t, []string{queued.ID}, jobIDs(saved))
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{filepath.Join(dir, queued.ID+".json"), filepath.Join(dir, queued.ID+".input")}, files)
+}
+
+// jobIDs is a helper function used to return the IDs of jobs
+func jobIDs(jobs []Job) []string {
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
@@ -0,0 +1,118 @@
+package jobs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Store is a custom type used to persist jobs and their inputs in a folder, so that they survive a restart.
+// Every job is kept as an <id>.json file next to its <id>.input file.
+type Store struct {
+	dir string
+}
+
+// OpenStore is used to open the store kept in dir, creating it when missing
+func OpenStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to open job store: %w", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Load is used to return every job of the store, oldest first. Files that cannot be decoded are ignored.
+func (s *Store) Load() ([]Job, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]Job, 0, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load job: %w", err)
+		}
+		var job Job
+		if err := json.Unmarshal(content, &job); err != nil || job.ID != strings.TrimSuffix(filepath.Base(file), ".json") {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].Created.Before(jobs[j].Created) })
+	return jobs, nil
+}
+
+// Save is used to write job to the store, replacing its previous state atomically
+func (s *Store) Save(job Job) error {
+	content, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := s.write(job.ID+".json", func(w io.Writer) error {
+		_, err := w.Write(content)
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to save job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// SaveInput is used to write the input of the job with id to the store
+func (s *Store) SaveInput(id string, input io.Reader) error {
+	if err := s.write(id+".input", func(w io.Writer) error {
+		_, err := io.Copy(w, input)
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to save input of job %s: %w", id, err)
+	}
+	return nil
+}
+
+// OpenInput is used to read the input of the job with id
+func (s *Store) OpenInput(id string) (*os.File, error) {
+	return os.Open(filepath.Join(s.dir, id+".input"))
+}
+
+// RemoveInput is used to delete the input of the job with id, which is no longer needed once the job is finished
+func (s *Store) RemoveInput(id string) error {
+	if err := os.Remove(filepath.Join(s.dir, id+".input")); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove input of job %s: %w", id, err)
+	}
+	return nil
+}
+
+// Remove is used to delete the job with id from the store, along with its input
+func (s *Store) Remove(id string) error {
+	if err := s.RemoveInput(id); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(s.dir, id+".json")); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove job %s: %w", id, err)
+	}
+	return nil
+}
+
+// write is a helper function used to write a file of the store through a temporary file, so that it is never left half written
+func (s *Store) write(name string, content func(io.Writer) error) error {
+	f, err := os.CreateTemp(s.dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := content(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(s.dir, name))
+}
@@ -0,0 +1,57 @@
+package jobs
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+)
+
+func TestStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "jobs")
+	store, err := OpenStore(dir)
+	require.NoError(t, err)
+
+	created := time.Date(2019, 7, 4, 12, 0, 0, 0, time.UTC)
+	older := Job{ID: "b", Name: "amalfi.csv", Format: "csv", State: Queued, Created: created}
+	newer := Job{
+		ID: "a", Name: "rome.csv", Format: "csv", State: Done, Created: created.Add(time.Minute),
+		Progress: Progress{Rows: 2, Done: 2, APICalls: 4},
+		Result:   &batch.Result{Folder: "rome.csv", Title: "A sunny day in Rome", Photos: 2, Read: 2},
+	}
+	require.NoError(t, store.Save(newer))
+	require.NoError(t, store.Save(older))
+	older.State = Running
+	require.NoError(t, store.Save(older))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o644))
+
+	got, err := store.Load()
+	require.NoError(t, err)
+	require.Equal(t, []Job{older, newer}, got)
+
+	require.NoError(t, store.SaveInput("a", strings.NewReader("2019-07-04T12:00:00Z,41.9,12.5\n")))
+	input, err := store.OpenInput("a")
+	require.NoError(t, err)
+	defer input.Close()
+	content, err := io.ReadAll(input)
+	require.NoError(t, err)
+	require.Equal(t, "2019-07-04T12:00:00Z,41.9,12.5\n", string(content))
+
+	temporary, err := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	require.NoError(t, err)
+	require.Empty(t, temporary)
+
+	require.NoError(t, store.Remove("a"))
+	require.NoError(t, store.Remove("missing"))
+	got, err = store.Load()
+	require.NoError(t, err)
+	require.Equal(t, []Job{older}, got)
+	_, err = store.OpenInput("a")
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,80 @@
+package landmark
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/adrianos93/nomenclator/internal/geo"
+)
+
+// Landmark is a custom type used to describe a point of interest, e.g. a park, a natural feature or a famous
+// landmark, as a circle around its centre
+type Landmark struct {
+	Name string `json:"name"`
+	// Kind is the kind of point of interest, e.g. park, natural, street or landmark, or mountains for mountain ranges
+	Kind string `json:"kind"`
+	// Preposition introduces the landmark in English titles, e.g. "on the" for "on the Las Vegas Strip"
+	Preposition string  `json:"preposition"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	// Radius is the distance from the centre covered by the landmark, in kilometres
+	Radius float64 `json:"radius"`
+}
+
+// Dataset is a custom type used to resolve the landmark coordinates fall within
+type Dataset struct {
+	landmarks []Landmark
+}
+
+//go:embed landmarks.json
+var builtin []byte
+
+// New returns a new Dataset of landmarks
+func New(landmarks []Landmark) *Dataset {
+	return &Dataset{landmarks: landmarks}
+}
+
+// Load is used to read a Dataset from a JSON array of landmarks
+func Load(r io.Reader) (*Dataset, error) {
+	var landmarks []Landmark
+	if err := json.NewDecoder(r).Decode(&landmarks); err != nil {
+		return nil, fmt.Errorf("failed to decode landmarks: %w", err)
+	}
+	for i, landmark := range landmarks {
+		if landmark.Name == "" {
+			return nil, fmt.Errorf("landmark %d has no name", i)
+		}
+		if landmark.Radius <= 0 {
+			return nil, fmt.Errorf("landmark %q has no radius", landmark.Name)
+		}
+	}
+	return New(landmarks), nil
+}
+
+// Builtin is used to return the Dataset bundled with nomenclator, listing famous landmarks, parks and natural features
+func Builtin() *Dataset {
+	dataset, err := Load(bytes.NewReader(builtin))
+	if err != nil {
+		panic(fmt.Errorf("invalid builtin landmarks: %w", err))
+	}
+	return dataset
+}
+
+// Resolve is used to return the landmark coordinates fall within. When landmarks overlap, the smallest
+// one is returned, e.g. the Colosseum rather than a park around it.
+func (d *Dataset) Resolve(latitude, longitude float64) (Landmark, bool) {
+	var found bool
+	var best Landmark
+	for _, landmark := range d.landmarks {
+		if geo.Distance(latitude, longitude, landmark.Latitude, landmark.Longitude) > landmark.Radius {
+			continue
+		}
+		if !found || landmark.Radius < best.Radius {
+			best, found = landmark, true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,121 @@
+package landmark
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltin(t *testing.T) {
+	for name, test := range map[string]struct {
+		latitude, longitude float64
+
+		expect      string
+		expectFound bool
+	}{
+		"las vegas strip": {
+			latitude:    36.1126,
+			longitude:   -115.1767,
+			expect:      "Las Vegas Strip",
+			expectFound: true,
+		},
+		"central park": {
+			latitude:    40.7794,
+			longitude:   -73.9632,
+			expect:      "Central Park",
+			expectFound: true,
+		},
+		"colosseum": {
+			latitude:    41.8905,
+			longitude:   12.4925,
+			expect:      "Colosseum",
+			expectFound: true,
+		},
+		"positano on the amalfi coast": {
+			latitude:    40.628197,
+			longitude:   14.367075,
+			expect:      "Amalfi Coast",
+			expectFound: true,
+		},
+		"pompeii within the amalfi coast": {
+			latitude:    40.7490,
+			longitude:   14.4850,
+			expect:      "Pompeii",
+			expectFound: true,
+		},
+		"zermatt in the alps": {
+			latitude:    46.0207,
+			longitude:   7.7491,
+			expect:      "Alps",
+			expectFound: true,
+		},
+		"cortina in the dolomites": {
+			latitude:    46.5405,
+			longitude:   12.1357,
+			expect:      "Dolomites",
+			expectFound: true,
+		},
+		"greenwich village": {
+			latitude:  40.728808,
+			longitude: -73.996106,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, found := Builtin().Resolve(test.latitude, test.longitude)
+			require.Equal(t, test.expectFound, found)
+			require.Equal(t, test.expect, got.Name)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	for name, test := range map[string]struct {
+		input string
+
+		expect  *Dataset
+		wantErr bool
+	}{
+		"valid landmarks": {
+			input: `[{"name": "Boston Common", "kind": "park", "preposition": "on", "latitude": 42.355, "longitude": -71.0656, "radius": 0.4}]`,
+			expect: New([]Landmark{
+				{Name: "Boston Common", Kind: "park", Preposition: "on", Latitude: 42.355, Longitude: -71.0656, Radius: 0.4},
+			}),
+		},
+		"invalid json": {
+			input:   `{"name": "Boston Common"}`,
+			wantErr: true,
+		},
+		"missing name": {
+			input:   `[{"latitude": 42.355, "longitude": -71.0656, "radius": 0.4}]`,
+			wantErr: true,
+		},
+		"missing radius": {
+			input:   `[{"name": "Boston Common", "latitude": 42.355, "longitude": -71.0656}]`,
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := Load(strings.NewReader(test.input))
+			require.Equal(t, test.wantErr, err != nil, err)
+			require.Equal(t, test.expect, got)
+		})
+	}
+}
+
+func TestDataset_Resolve(t *testing.T) {
+	dataset := New([]Landmark{
+		{Name: "Park", Latitude: 0.5, Longitude: 0.5, Radius: 10},
+		{Name: "Fountain", Latitude: 0.5, Longitude: 0.5, Radius: 0.1},
+	})
+	got, found := dataset.Resolve(0.5, 0.5)
+	require.True(t, found)
+	require.Equal(t, "Fountain", got.Name)
+
+	got, found = dataset.Resolve(0.55, 0.5)
+	require.True(t, found)
+	require.Equal(t, "Park", got.Name)
+
+	_, found = dataset.Resolve(1, 1)
+	require.False(t, found)
+}
@@ -10,16 +10,31 @@ import (
 
 // Locator is an interface for interacting with the geolocation API
 type Locator struct {
-	apikey string
-	limit  int
+	apikey   string
+	limit    int
+	language string
 }
 
 // Location is a custom type used to describe the data returned by the geolocation API to other packages
+// City holds the region, as it has historically been used in titles, the other fields hold each administrative level
+// returned by the geolocation API, from the most to the least specific.
+// Type is the kind of place found at the coordinates, e.g. venue, street, locality, or ocean and marinearea for water.
 type Location struct {
-	City    string
-	Country string
-	Date    time.Time
-	Weather string
+	City                string
+	Type                string
+	Neighbourhood       string
+	Locality            string
+	County              string
+	Region              string
+	Country             string
+	Continent           string
+	Latitude, Longitude float64
+	// Elevation is the elevation the photo was taken at in metres, looked up by the processor, NaN when unknown
+	Elevation float64
+	Date      time.Time
+	Weather   string
+	// CloudCover is the percentage of the sky covered by clouds on the day of the photo, NaN when unknown
+	CloudCover float64
 }
 
 // locationData is used to store the response from the geolocation API
@@ -58,6 +73,13 @@ func WithDataLimit(max int) LocatorOptions {
 	}
 }
 
+// WithLanguage requests place names in a language, as an ISO 639-1 code, e.g. it
+func WithLanguage(language string) LocatorOptions {
+	return func(l *Locator) {
+		l.language = language
+	}
+}
+
 // The URL of the geolocation API
 var url = "http://api.positionstack.com/v1/reverse"
 
@@ -87,9 +109,21 @@ func (l *Locator) Locate(latitude, longitude float64) (Location, error) {
 		return Location{}, fmt.Errorf("failed to decode response body: %w", err)
 	}
 
+	if len(locationData.Data) == 0 {
+		return Location{}, errors.New("no location found")
+	}
+	data := locationData.Data[0]
 	return Location{
-		City:    locationData.Data[0].Region,
-		Country: locationData.Data[0].Country,
+		City:          data.Region,
+		Type:          data.Type,
+		Neighbourhood: data.Neighbourhood,
+		Locality:      data.Locality,
+		County:        data.County,
+		Region:        data.Region,
+		Country:       data.Country,
+		Continent:     data.Continent,
+		Latitude:      data.Latitude,
+		Longitude:     data.Longitude,
 	}, nil
 }
 
@@ -101,6 +135,9 @@ func (l *Locator) reverseGeoRequestBuilder(query string) string {
 	if l.limit != 0 {
 		q.Add("limit", fmt.Sprintf("%d", l.limit))
 	}
+	if l.language != "" {
+		q.Add("language", l.language)
+	}
 	req.URL.RawQuery = q.Encode()
 	return req.URL.String()
 }
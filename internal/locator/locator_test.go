@@ -17,10 +17,20 @@ func (f *fakeGeoAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch {
 	case r.URL.Path == "/v1/error":
 		_ = json.NewEncoder(w).Encode(`{"mystuff:hello"}`)
+	case r.URL.Path == "/v1/empty":
+		_ = json.NewEncoder(w).Encode(locationData{})
 	case r.URL.Path == "/v1/reverse":
 		location := locationData{
 			Data: []data{
-				{Region: "London", Country: "United Kingdom"},
+				{
+					Type:          "neighbourhood",
+					Neighbourhood: "Soho",
+					Locality:      "London",
+					County:        "Greater London",
+					Region:        "London",
+					Country:       "United Kingdom",
+					Continent:     "Europe",
+				},
 			},
 		}
 		_ = json.NewEncoder(w).Encode(location)
@@ -29,11 +39,12 @@ func (f *fakeGeoAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 func TestLocator_New(t *testing.T) {
 	for name, test := range map[string]struct {
-		apikey  string
-		expect  *Locator
-		limit   int
-		fields  []string
-		options []LocatorOptions
+		apikey   string
+		expect   *Locator
+		limit    int
+		language string
+		fields   []string
+		options  []LocatorOptions
 	}{
 		"returns a new Locator": {
 			apikey: "iamapikey",
@@ -47,12 +58,41 @@ func TestLocator_New(t *testing.T) {
 				WithDataLimit(1),
 			},
 		},
+		"returns a new locator with language": {
+			apikey:   "iamapikey",
+			language: "it",
+			options: []LocatorOptions{
+				WithLanguage("it"),
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			got := New(test.apikey, test.options...)
 			require.IsType(t, test.expect, got)
 			require.Equal(t, test.apikey, got.apikey)
 			require.Equal(t, test.limit, got.limit)
+			require.Equal(t, test.language, got.language)
+		})
+	}
+}
+
+func TestLocator_reverseGeoRequestBuilder(t *testing.T) {
+	for name, test := range map[string]struct {
+		locator Locator
+
+		expect string
+	}{
+		"query only": {
+			locator: Locator{apikey: "iamapikey"},
+			expect:  url + "?access_key=iamapikey&query=40.728808%2C-73.996106",
+		},
+		"limit and language": {
+			locator: Locator{apikey: "iamapikey", limit: 1, language: "it"},
+			expect:  url + "?access_key=iamapikey&language=it&limit=1&query=40.728808%2C-73.996106",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expect, test.locator.reverseGeoRequestBuilder("40.728808,-73.996106"))
 		})
 	}
 }
@@ -65,16 +105,29 @@ func TestLocator_Locate(t *testing.T) {
 
 		wantReqErr     bool
 		wantDecoderErr bool
+		wantEmpty      bool
 		wantErr        bool
 	}{
 		"successfully retrieve geospatial data": {
 			latitude:  40.728808,
 			longitude: -73.996106,
 			want: Location{
-				City:    "London",
-				Country: "United Kingdom",
+				City:          "London",
+				Type:          "neighbourhood",
+				Neighbourhood: "Soho",
+				Locality:      "London",
+				County:        "Greater London",
+				Region:        "London",
+				Country:       "United Kingdom",
+				Continent:     "Europe",
 			},
 		},
+		"no location found": {
+			latitude:  40.728808,
+			longitude: -73.996106,
+			wantEmpty: true,
+			wantErr:   true,
+		},
 		"fail to generate request": {
 			latitude:   40.728808,
 			longitude:  -73.996106,
@@ -93,6 +146,9 @@ func TestLocator_Locate(t *testing.T) {
 			if test.wantDecoderErr {
 				url = ts.URL + "/v1/error"
 			}
+			if test.wantEmpty {
+				url = ts.URL + "/v1/empty"
+			}
 
 			l := Locator{
 				apikey: "iamapikey",
@@ -103,8 +159,7 @@ func TestLocator_Locate(t *testing.T) {
 			if (err != nil) != test.wantErr {
 				t.Errorf("Locator.Locate() error = %v, wantErr %v", err, test.wantErr)
 			}
-			require.Equal(t, got.City, test.want.City)
-			require.Equal(t, got.Country, test.want.Country)
+			require.Equal(t, test.want, got)
 
 		})
 	}
@@ -0,0 +1,30 @@
+package notify
+
+import (
+	"context"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+)
+
+// TitledEvent is the type of the events sent when an album is titled
+const TitledEvent = "album.titled"
+
+// Event is a custom type used to tell downstream systems about an album, carrying its structured result
+type Event struct {
+	Type string `json:"type"`
+	// Mode is the command the album was titled by, e.g. cli, batch, watch, serve or grpc
+	Mode  string       `json:"mode"`
+	Time  time.Time    `json:"time"`
+	Album batch.Result `json:"album"`
+}
+
+// Notifier is an interface used to send events to downstream systems
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// Titled returns the event telling that album was titled by mode
+func Titled(mode string, album batch.Result) Event {
+	return Event{Type: TitledEvent, Mode: mode, Time: time.Now().UTC(), Album: album}
+}
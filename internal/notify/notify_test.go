@@ -0,0 +1,21 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+)
+
+func TestTitled(t *testing.T) {
+	album := batch.Result{Folder: "data/1.csv", Title: "A rainy day in New York", Photos: 26, Read: 26}
+	before := time.Now()
+	got := Titled("cli", album)
+	require.Equal(t, TitledEvent, got.Type)
+	require.Equal(t, "cli", got.Mode)
+	require.Equal(t, album, got.Album)
+	require.False(t, got.Time.Before(before.Truncate(time.Second)))
+	require.Equal(t, time.UTC, got.Time.Location())
+}
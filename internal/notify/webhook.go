@@ -0,0 +1,138 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SignatureHeader is the header holding the HMAC-SHA256 signature of a webhook payload, as sha256=<hex digest>
+const SignatureHeader = "X-Nomenclator-Signature"
+
+// Webhook is a custom type used to post events as JSON to a URL, retrying failed deliveries
+type Webhook struct {
+	url     string
+	secret  []byte
+	retries int
+	backoff time.Duration
+	client  *http.Client
+	sleep   func(context.Context, time.Duration) error
+}
+
+type WebhookOptions func(*Webhook)
+
+// WithSecret signs every payload with secret, so that receivers can check it was sent by nomenclator
+func WithSecret(secret string) WebhookOptions {
+	return func(w *Webhook) {
+		w.secret = []byte(secret)
+	}
+}
+
+// WithRetries sets how many times a failed delivery is retried, 3 by default
+func WithRetries(retries int) WebhookOptions {
+	return func(w *Webhook) {
+		w.retries = retries
+	}
+}
+
+// WithBackoff sets how long to wait before the first retry, 1s by default. The wait doubles on every retry.
+func WithBackoff(backoff time.Duration) WebhookOptions {
+	return func(w *Webhook) {
+		w.backoff = backoff
+	}
+}
+
+// WithTimeout sets how long a single delivery can take, 10s by default
+func WithTimeout(timeout time.Duration) WebhookOptions {
+	return func(w *Webhook) {
+		w.client.Timeout = timeout
+	}
+}
+
+// NewWebhook returns a new Webhook posting events to url
+func NewWebhook(url string, options ...WebhookOptions) *Webhook {
+	webhook := &Webhook{
+		url:     url,
+		retries: 3,
+		backoff: time.Second,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		sleep:   sleep,
+	}
+	for _, option := range options {
+		option(webhook)
+	}
+	return webhook
+}
+
+// Notify posts event to the webhook, retrying network errors, 429 and 5xx responses until it is delivered,
+// the retries run out or ctx is done
+func (w *Webhook) Notify(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	backoff := w.backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := w.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= w.retries {
+			return fmt.Errorf("webhook %s: %w", w.url, err)
+		}
+		if err := w.sleep(ctx, backoff); err != nil {
+			return fmt.Errorf("webhook %s: %w", w.url, err)
+		}
+		backoff *= 2
+	}
+}
+
+// post is a helper function used to deliver a payload once, returning whether a failed delivery is worth retrying
+func (w *Webhook) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "nomenclator")
+	if len(w.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(w.secret, body))
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("non 2xx response: %s", resp.Status)
+}
+
+// Sign returns the signature of a payload with secret, as sent in the SignatureHeader
+func Sign(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// sleep is a helper function used to wait between retries, giving up as soon as ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+	"github.com/adrianos93/nomenclator/internal/processor"
+)
+
+func TestWebhook_Notify(t *testing.T) {
+	cloudCover := 10.0
+	event := Event{
+		Type: TitledEvent,
+		Mode: "batch",
+		Time: time.Date(2019, 7, 4, 12, 0, 0, 0, time.UTC),
+		Album: batch.Result{
+			Folder:      "Archive/Amalfi",
+			Title:       "A sunny weekend in Amalfi",
+			Suggestions: []processor.Suggestion{{Title: "A sunny boat trip through Campania", Score: 0.8}},
+			Photos:      42,
+			Read:        42,
+			Travel:      &processor.Travel{Mode: processor.BoatTrip, Track: processor.Track{Distance: 21.5, Water: 0.6}},
+			Sky:         &processor.Sky{Moon: "full moon", Illumination: 0.99, MoonUp: 1, CloudCover: &cloudCover, Facet: "full moon"},
+			Altitude:    &processor.Altitude{Min: 0, Max: 320},
+			Explanation: &processor.Explanation{Decisions: []processor.Decision{{Facet: "weather", Value: "sunny", Reason: "most votes"}}},
+		},
+	}
+	for name, test := range map[string]struct {
+		statuses []int
+		options  []WebhookOptions
+
+		expectCalls  int
+		expectSleeps []time.Duration
+		wantErr      bool
+	}{
+		"delivered": {
+			statuses:    []int{http.StatusNoContent},
+			expectCalls: 1,
+		},
+		"signed": {
+			statuses:    []int{http.StatusOK},
+			options:     []WebhookOptions{WithSecret("shh")},
+			expectCalls: 1,
+		},
+		"retries server errors with a growing backoff": {
+			statuses:     []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusOK},
+			expectCalls:  3,
+			expectSleeps: []time.Duration{time.Second, 2 * time.Second},
+		},
+		"gives up after the retries": {
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			options:      []WebhookOptions{WithRetries(2), WithBackoff(time.Millisecond)},
+			expectCalls:  3,
+			expectSleeps: []time.Duration{time.Millisecond, 2 * time.Millisecond},
+			wantErr:      true,
+		},
+		"does not retry client errors": {
+			statuses:    []int{http.StatusBadRequest},
+			expectCalls: 1,
+			wantErr:     true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var calls int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				var got Event
+				require.NoError(t, json.Unmarshal(body, &got))
+				require.Equal(t, event, got)
+				if name == "signed" {
+					require.Equal(t, Sign([]byte("shh"), body), r.Header.Get(SignatureHeader))
+				} else {
+					require.Empty(t, r.Header.Get(SignatureHeader))
+				}
+				w.WriteHeader(test.statuses[calls])
+				calls++
+			}))
+			defer server.Close()
+
+			webhook := NewWebhook(server.URL, test.options...)
+			var sleeps []time.Duration
+			webhook.sleep = func(_ context.Context, d time.Duration) error {
+				sleeps = append(sleeps, d)
+				return nil
+			}
+			err := webhook.Notify(context.Background(), event)
+			require.Equal(t, test.wantErr, err != nil, err)
+			require.Equal(t, test.expectCalls, calls)
+			require.Equal(t, test.expectSleeps, sleeps)
+		})
+	}
+}
+
+func TestWebhook_Notify_timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	webhook := NewWebhook(server.URL, WithTimeout(10*time.Millisecond), WithRetries(1), WithBackoff(time.Millisecond))
+	start := time.Now()
+	require.Error(t, webhook.Notify(context.Background(), Event{Type: TitledEvent}))
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestSign(t *testing.T) {
+	// from the HMAC-SHA256 test vectors of RFC 4231, test case 2
+	require.Equal(t,
+		"sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		Sign([]byte("Jefe"), []byte("what do ya want for nothing?")),
+	)
+}
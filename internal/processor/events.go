@@ -0,0 +1,104 @@
+package processor
+
+import (
+	"sort"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/calendar"
+	"github.com/adrianos93/nomenclator/internal/geo"
+	"github.com/adrianos93/nomenclator/internal/locator"
+)
+
+// albumCountry is a helper function used to return the most commonly occurring country from an album
+func albumCountry(album []locator.Location) string {
+	m := make(map[string]int, len(album))
+	var count int
+	var country string
+	for _, photo := range album {
+		m[photo.Country]++
+		if m[photo.Country] > count {
+			count = m[photo.Country]
+			country = photo.Country
+		}
+	}
+	return country
+}
+
+// albumEvent is a helper function used to return the holiday or named event an album was taken during, if any.
+// Albums are named after the holiday most of their photos were taken on, e.g. "Halloween night" when most were
+// taken in the evening of a holiday celebrated at night. Short multi-day albums are also named after holidays
+// making up a long weekend, e.g. "Easter weekend", whenever the holiday falls within the album.
+func albumEvent(album []locator.Location, period string) event {
+	if len(album) == 0 {
+		return event{}
+	}
+	country := albumCountry(album)
+	m := make(map[string]int, len(album))
+	evenings := make(map[string]int, len(album))
+	var count int
+	var holiday calendar.Holiday
+	for _, photo := range album {
+		local := geo.LocalTime(photo.Date, photo.Longitude)
+		h, found := calendar.On(country, local)
+		if !found {
+			continue
+		}
+		m[h.ID]++
+		if local.Hour() >= 17 || local.Hour() < 4 {
+			evenings[h.ID]++
+		}
+		if m[h.ID] > count {
+			count = m[h.ID]
+			holiday = h
+		}
+	}
+	switch {
+	case count*2 >= len(album) && holiday.Weekend && multiDay(period):
+		return event{holiday: holiday.ID, name: holiday.Name, period: "weekend"}
+	case count*2 >= len(album) && holiday.Night && evenings[holiday.ID]*2 >= count:
+		return event{holiday: holiday.ID, name: holiday.Name, period: "night"}
+	case count*2 >= len(album):
+		return event{holiday: holiday.ID, name: holiday.Name}
+	case multiDay(period):
+		start, end := albumBounds(album)
+		for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+			if h, found := calendar.On(country, day); found && h.Weekend {
+				return event{holiday: h.ID, name: h.Name, period: "weekend"}
+			}
+		}
+	}
+	return event{}
+}
+
+// albumSeason is a helper function used to return the season an album spanning a week up to a season was taken in,
+// based on the median photo date and the hemisphere it was taken in.
+func albumSeason(album []locator.Location, period string) string {
+	switch period {
+	case "week", "fortnight", "month", "season":
+	default:
+		return ""
+	}
+	if len(album) == 0 {
+		return ""
+	}
+	photos := make([]locator.Location, len(album))
+	copy(photos, album)
+	sort.Slice(photos, func(i, j int) bool { return photos[i].Date.Before(photos[j].Date) })
+	median := photos[len(photos)/2]
+	return calendar.Season(geo.LocalTime(median.Date, median.Longitude), median.Latitude)
+}
+
+// albumBounds is a helper function used to return the first and last local calendar days of an album
+func albumBounds(album []locator.Location) (start, end time.Time) {
+	for i, photo := range album {
+		local := geo.LocalTime(photo.Date, photo.Longitude)
+		day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+		if i == 0 || day.Before(start) {
+			start = day
+		}
+		if i == 0 || day.After(end) {
+			end = day
+		}
+	}
+	return start, end
+}
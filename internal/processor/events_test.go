@@ -0,0 +1,117 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_Process_calendar(t *testing.T) {
+	newYork := locator.Location{City: "New York", Country: "United States"}
+	positano := locator.Location{City: "Positano", Country: "Italy"}
+	sydney := locator.Location{City: "Sydney", Country: "Australia"}
+	for name, test := range map[string]struct {
+		input      [][]string
+		location   locator.Location
+		conditions string
+		options    []ProcessorOptions
+
+		expect string
+	}{
+		"snowy christmas": {
+			input: [][]string{
+				{"2019-12-25T15:12:19Z", "40.728808", "-73.996106"},
+				{"2019-12-25T17:20:10Z", "40.728656", "-73.998790"},
+			},
+			location:   newYork,
+			conditions: "Snow",
+			expect:     "A snowy Christmas in New York",
+		},
+		"easter weekend": {
+			input: [][]string{
+				{"2020-04-11T14:12:19Z", "40.728808", "-73.996106"},
+				{"2020-04-12T14:20:10Z", "40.728656", "-73.998790"},
+				{"2020-04-13T14:32:02Z", "40.727160", "-73.996044"},
+			},
+			location:   newYork,
+			conditions: "Clear",
+			expect:     "A sunny Easter weekend in New York",
+		},
+		"halloween night": {
+			input: [][]string{
+				{"2019-10-31T18:26:12Z", "40.628197", "14.367075"},
+				{"2019-10-31T18:35:23Z", "40.627808", "14.364933"},
+				{"2019-10-31T18:52:59Z", "40.627883", "14.366858"},
+			},
+			location:   positano,
+			conditions: "Clear",
+			expect:     "A sunny Halloween night in Positano",
+		},
+		"halloween during the day": {
+			input: [][]string{
+				{"2019-10-31T10:26:12Z", "40.628197", "14.367075"},
+				{"2019-10-31T11:35:23Z", "40.627808", "14.364933"},
+			},
+			location:   positano,
+			conditions: "Clear",
+			expect:     "A sunny Halloween in Positano",
+		},
+		"autumn week": {
+			input: [][]string{
+				{"2019-10-20T10:26:12Z", "40.628197", "14.367075"},
+				{"2019-10-23T11:35:23Z", "40.627808", "14.364933"},
+				{"2019-10-25T18:52:59Z", "40.627883", "14.366858"},
+			},
+			location:   positano,
+			conditions: "Rain",
+			expect:     "A rainy autumn week in Positano",
+		},
+		"southern hemisphere summer week": {
+			input: [][]string{
+				{"2019-12-02T01:00:00Z", "-33.856784", "151.215297"},
+				{"2019-12-08T01:00:00Z", "-33.856784", "151.215297"},
+			},
+			location:   sydney,
+			conditions: "Clear",
+			expect:     "A sunny summer week in Sydney",
+		},
+		"thanksgiving is not observed in italy": {
+			input: [][]string{
+				{"2019-11-28T10:26:12Z", "40.628197", "14.367075"},
+			},
+			location:   positano,
+			conditions: "Clear",
+			expect:     "A sunny morning in Positano",
+		},
+		"calendar disabled": {
+			input: [][]string{
+				{"2019-12-25T15:12:19Z", "40.728808", "-73.996106"},
+			},
+			location:   newYork,
+			conditions: "Snow",
+			options:    []ProcessorOptions{},
+			expect:     "A snowy morning in New York",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			locatorDouble := &mockLocator{}
+			locatorDouble.Test(t)
+			locatorDouble.On("Locate", mock.Anything, mock.Anything).Return(test.location, nil)
+			weathermanDouble := &mockWeatherman{}
+			weathermanDouble.Test(t)
+			weathermanDouble.On("CheckWeather", mock.Anything, mock.Anything, mock.Anything).Return(weatherman.Forecast{Conditions: test.conditions}, nil)
+
+			options := test.options
+			if options == nil {
+				options = []ProcessorOptions{WithCalendar()}
+			}
+			p := New(locatorDouble, weathermanDouble, options...)
+			got, errs := p.Process(test.input)
+			require.Empty(t, errs)
+			require.Equal(t, test.expect, got)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/astro"
+	"github.com/adrianos93/nomenclator/internal/geo"
+	"github.com/adrianos93/nomenclator/internal/locator"
+)
+
+// Explanation is a custom type used to describe how the title of an album was derived
+type Explanation struct {
+	Photos []PhotoExplanation `json:"photos"`
+	// Weather holds the votes cast for each weather adjective, counted as set by Aggregation
+	Weather     map[string]float64 `json:"weather"`
+	Aggregation WeatherAggregation `json:"aggregation"`
+	// Places holds the number of photos taken in each place, by administrative level
+	Places map[PlaceLevel]map[string]int `json:"places"`
+	// Parts holds the number of photos taken in each part of the day, in local time
+	Parts map[string]int `json:"parts"`
+	// Span is the time between the first and the last photo, e.g. 6h30m0s, and Days the number of calendar days they cover
+	Span string `json:"span"`
+	Days int    `json:"days"`
+	// Decisions lists the rule deciding each facet of the title
+	Decisions []Decision `json:"decisions"`
+}
+
+// PhotoExplanation is a custom type used to describe what was found out about a photo
+type PhotoExplanation struct {
+	Date      time.Time `json:"date"`
+	LocalTime time.Time `json:"local_time"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	// Places holds the place the photo was taken in, by administrative level, and Landmark the landmark it was taken at
+	Places   map[PlaceLevel]string `json:"places"`
+	Landmark string                `json:"landmark,omitempty"`
+	// Elevation is the elevation the photo was taken at in metres, when known
+	Elevation *float64 `json:"elevation,omitempty"`
+	// Sun is the elevation of the sun in degrees, and Light the natural light it gives, when enabled with WithLight
+	Sun   *float64    `json:"sun,omitempty"`
+	Light astro.Light `json:"light,omitempty"`
+	// Conditions are the raw weather conditions returned by the provider, and Weather the adjective they
+	// were classified as by the Rule pattern, empty when no rule matched and the default was used
+	Conditions string `json:"conditions"`
+	Weather    string `json:"weather"`
+	Rule       string `json:"rule,omitempty"`
+}
+
+// Decision is a custom type used to describe the value of a title facet and the rule it was decided by
+type Decision struct {
+	Facet  string `json:"facet"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// WithExplain enables explaining how titles are derived in the album result
+func WithExplain() ProcessorOptions {
+	return func(p *Processor) {
+		p.explain = true
+	}
+}
+
+// explainAlbum is a helper function used to describe how the facets of a title were derived from an album
+func (p *Processor) explainAlbum(album []locator.Location, f facets) *Explanation {
+	e := &Explanation{
+		Photos:      make([]PhotoExplanation, 0, len(album)),
+		Weather:     weatherVotes(album, p.weatherRules, p.aggregation),
+		Aggregation: p.aggregation,
+		Places:      make(map[PlaceLevel]map[string]int, len(placeLevels)+1),
+		Parts:       make(map[string]int, 4),
+	}
+	minDate, maxDate := album[0].Date, album[0].Date
+	var minLocal, maxLocal time.Time
+	for _, photo := range album {
+		local := geo.LocalTime(photo.Date, photo.Longitude)
+		adjective, rule := p.weatherRules.classify(photo.Weather)
+		explanation := PhotoExplanation{
+			Date:       photo.Date,
+			LocalTime:  local,
+			Latitude:   photo.Latitude,
+			Longitude:  photo.Longitude,
+			Places:     make(map[PlaceLevel]string, len(placeLevels)+1),
+			Conditions: photo.Weather,
+			Weather:    adjective,
+			Rule:       rule,
+		}
+		if p.light {
+			sun := astro.Sun(photo.Date, photo.Latitude, photo.Longitude).Elevation
+			explanation.Sun, explanation.Light = &sun, astro.Classify(sun)
+		}
+		if !math.IsNaN(photo.Elevation) {
+			elevation := photo.Elevation
+			explanation.Elevation = &elevation
+		}
+		if p.landmarks != nil {
+			if l, found := p.landmark(photo); found {
+				explanation.Landmark = l.Name
+			}
+		}
+		for _, level := range append([]PlaceLevel{CityPlace}, placeLevels...) {
+			name := placeName(photo, level)
+			if name == "" {
+				continue
+			}
+			explanation.Places[level] = name
+			if e.Places[level] == nil {
+				e.Places[level] = make(map[string]int)
+			}
+			e.Places[level][name]++
+		}
+		e.Photos = append(e.Photos, explanation)
+		e.Parts[partOfDay(local)]++
+		if !photo.Date.After(minDate) {
+			minDate, minLocal = photo.Date, local
+		}
+		if !photo.Date.Before(maxDate) {
+			maxDate, maxLocal = photo.Date, local
+		}
+	}
+	span := maxDate.Sub(minDate)
+	e.Span = span.String()
+	e.Days = calendarDays(minLocal, maxLocal)
+
+	e.Decisions = append(e.Decisions,
+		Decision{Facet: "weather", Value: p.weatherValue(f.weather), Reason: p.weatherReason(album, e.Weather)},
+		Decision{Facet: "period", Value: f.period, Reason: periodReason(f.period, p.periods, span, e.Days)},
+		Decision{Facet: "place", Value: f.place, Reason: p.placeReason(album, f)},
+	)
+	if p.light {
+		e.Decisions = append(e.Decisions, Decision{Facet: "light", Value: f.light, Reason: lightReason(album, f.light)})
+	}
+	if p.nightSky {
+		e.Decisions = append(e.Decisions, Decision{Facet: "sky", Value: f.sky, Reason: skyReason(albumSky(album), f.sky)})
+	}
+	if p.travel != nil {
+		travel := albumTravel(album, *p.travel)
+		e.Decisions = append(e.Decisions, Decision{Facet: "travel", Value: string(travel.Mode), Reason: travelReason(travel, *p.travel)})
+	}
+	if f.event.holiday != "" {
+		e.Decisions = append(e.Decisions, Decision{Facet: "event", Value: f.event.name, Reason: eventReason(album, f.event)})
+	}
+	if f.season != "" {
+		e.Decisions = append(e.Decisions, Decision{Facet: "season", Value: f.season, Reason: seasonReason(album)})
+	}
+	return e
+}
+
+// weatherValue is a helper function used to describe the weather facet of a title in English
+func (p *Processor) weatherValue(w weather) string {
+	switch {
+	case w.then != "":
+		return w.adjective + " then " + w.then
+	case w.mostly:
+		return "mostly " + w.adjective
+	}
+	return w.adjective
+}
+
+// weatherReason is a helper function used to describe how the weather of an album was decided
+func (p *Processor) weatherReason(album []locator.Location, votes map[string]float64) string {
+	if p.phrasing != nil {
+		_, reason := mixedWeather(album, p.weatherRules, p.aggregation, *p.phrasing)
+		return reason + ", counted by " + string(p.aggregation)
+	}
+	leader := topAdjective(votes, p.weatherRules)
+	var total float64
+	tied := 0
+	for _, n := range votes {
+		total += n
+		if n >= votes[leader]-voteTolerance {
+			tied++
+		}
+	}
+	reason := fmt.Sprintf("%s has the most votes, %.3g of %.3g counted by %s", leader, votes[leader], total, p.aggregation)
+	if tied > 1 {
+		reason += ", winning a tie by rule priority"
+	}
+	return reason
+}
+
+// periodReason is a helper function used to describe the rule deciding the period of an album
+func periodReason(period string, periods Periods, span time.Duration, days int) string {
+	reason := fmt.Sprintf("the photos span %s over %d calendar days", span, days)
+	if days == 1 {
+		reason = fmt.Sprintf("the photos span %s over a single calendar day", span)
+	}
+	switch period {
+	case "morning", "afternoon", "evening", "night":
+		return fmt.Sprintf("%s, all in the %s and within %s", reason, period, periods.PartOfDay)
+	case "day":
+		return fmt.Sprintf("%s, within %s", reason, periods.Day)
+	case "long weekend":
+		return reason + ", four days covering a weekend"
+	case "weekend":
+		return fmt.Sprintf("%s, within %s, starting or ending between Friday and Sunday", reason, periods.FewDays)
+	case "few days":
+		return fmt.Sprintf("%s, within %s", reason, periods.FewDays)
+	case "week":
+		return fmt.Sprintf("%s, within %s", reason, periods.Week)
+	case "fortnight":
+		return fmt.Sprintf("%s, within %s", reason, periods.Fortnight)
+	case "month":
+		return fmt.Sprintf("%s, within %s", reason, periods.Month)
+	case "season":
+		return fmt.Sprintf("%s, within %s", reason, periods.Season)
+	}
+	return fmt.Sprintf("%s, longer than %s", reason, periods.Season)
+}
+
+// placeReason is a helper function used to describe the rule deciding the place of an album
+func (p *Processor) placeReason(album []locator.Location, f facets) string {
+	place, level, requested := f.place, f.placeLevel, p.placeLevel
+	if level == LandmarkPlace {
+		_, share := p.albumLandmark(album)
+		return fmt.Sprintf("%d of %d photos were taken within %s, at least %.0f%% of them",
+			int(share*float64(len(album))+0.5), len(album), place, placeCoverage*100)
+	}
+	if level == TerrainPlace {
+		_, share := p.albumTerrain(album)
+		count := int(share*float64(len(album)) + 0.5)
+		if place == "mountains" {
+			return fmt.Sprintf("%d of %d photos were taken above %.0f m, at least %.0f%% of them",
+				count, len(album), p.terrain.Mountains, placeCoverage*100)
+		}
+		return fmt.Sprintf("%d of %d photos were taken within %.1f km of the coastline, at least %.0f%% of them",
+			count, len(album), p.terrain.Coast, placeCoverage*100)
+	}
+	count := int(placeShare(album, place, level)*float64(len(album)) + 0.5)
+	switch {
+	case level == requested:
+		return fmt.Sprintf("%s is the most common %s, where %d of %d photos were taken, as requested", place, level, count, len(album))
+	case level == CityPlace && requested != AutoPlace && requested != "":
+		return fmt.Sprintf("no photo has a %s, %s is the most common city with %d of %d photos", requested, place, count, len(album))
+	case level == CityPlace:
+		return fmt.Sprintf("no place covers %.0f%% of the photos, %s is the most common city with %d of %d photos",
+			placeCoverage*100, place, count, len(album))
+	}
+	return fmt.Sprintf("%s covers %d of %d photos, the most specific %s covering at least %.0f%% of them",
+		place, count, len(album), level, placeCoverage*100)
+}
+
+// eventReason is a helper function used to describe the rule deciding the event of an album
+func eventReason(album []locator.Location, e event) string {
+	if e.period == "weekend" {
+		return fmt.Sprintf("%s falls within a short trip", e.name)
+	}
+	count := int(eventShare(album, e)*float64(len(album)) + 0.5)
+	reason := fmt.Sprintf("%d of %d photos were taken on %s in %s", count, len(album), e.name, albumCountry(album))
+	if e.period == "night" {
+		reason += ", mostly in the evening"
+	}
+	return reason
+}
+
+// seasonReason is a helper function used to describe the rule deciding the season of an album
+func seasonReason(album []locator.Location) string {
+	photos := make([]locator.Location, len(album))
+	copy(photos, album)
+	sort.Slice(photos, func(i, j int) bool { return photos[i].Date.Before(photos[j].Date) })
+	median := photos[len(photos)/2]
+	return fmt.Sprintf("the median photo was taken on %s at latitude %.2f",
+		geo.LocalTime(median.Date, median.Longitude).Format("2 January"), median.Latitude)
+}
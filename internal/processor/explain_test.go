@@ -0,0 +1,115 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/adrianos93/nomenclator/internal/geo"
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_ProcessAlbum_explain(t *testing.T) {
+	halloween := []Metadata{
+		{Latitude: 40.628197, Longitude: 14.367075, Date: dateParser("2019-10-31T18:26:12")},
+		{Latitude: 40.627808, Longitude: 14.364933, Date: dateParser("2019-10-31T18:35:23")},
+		{Latitude: 40.627883, Longitude: 14.366858, Date: dateParser("2019-10-31T18:52:59")},
+	}
+	for name, test := range map[string]struct {
+		input      []Metadata
+		locations  map[float64]locator.Location
+		conditions map[float64]string
+		options    []ProcessorOptions
+
+		expectPhoto     PhotoExplanation
+		expectWeather   map[string]float64
+		expectPlaces    map[PlaceLevel]map[string]int
+		expectDecisions []Decision
+	}{
+		"halloween night": {
+			input:      halloween,
+			locations:  map[float64]locator.Location{40.628197: positano, 40.627808: positano, 40.627883: amalfi},
+			conditions: map[float64]string{40.628197: "Rain, Overcast", 40.627808: "Clear", 40.627883: "Partially cloudy"},
+			options:    []ProcessorOptions{WithCalendar()},
+			expectPhoto: PhotoExplanation{
+				Date:       dateParser("2019-10-31T18:26:12"),
+				LocalTime:  geo.LocalTime(dateParser("2019-10-31T18:26:12"), 14.367075),
+				Latitude:   40.628197,
+				Longitude:  14.367075,
+				Places:     map[PlaceLevel]string{CityPlace: "Campania", Locality: "Positano", County: "Salerno", Region: "Campania", Country: "Italy", Continent: "Europe"},
+				Conditions: "Rain, Overcast",
+				Weather:    "rainy",
+				Rule:       "rain",
+			},
+			expectWeather: map[string]float64{"rainy": 1, "sunny": 2},
+			expectPlaces: map[PlaceLevel]map[string]int{
+				CityPlace: {"Campania": 3},
+				Locality:  {"Positano": 2, "Amalfi": 1},
+				County:    {"Salerno": 3},
+				Region:    {"Campania": 3},
+				Country:   {"Italy": 3},
+				Continent: {"Europe": 3},
+			},
+			expectDecisions: []Decision{
+				{Facet: "weather", Value: "sunny", Reason: "sunny has the most votes, 2 of 3 counted by photo"},
+				{Facet: "period", Value: "evening", Reason: "the photos span 26m47s over a single calendar day, all in the evening and within 6h0m0s"},
+				{Facet: "place", Value: "Salerno", Reason: "Salerno covers 3 of 3 photos, the most specific county covering at least 80% of them"},
+				{Facet: "event", Value: "Halloween", Reason: "3 of 3 photos were taken on Halloween in Italy, mostly in the evening"},
+			},
+		},
+		"forced level and mixed weather": {
+			input:      halloween,
+			locations:  map[float64]locator.Location{40.628197: positano, 40.627808: positano, 40.627883: amalfi},
+			conditions: map[float64]string{40.628197: "Rain", 40.627808: "Clear", 40.627883: "Clear"},
+			options:    []ProcessorOptions{WithPlaceLevel(Locality), WithMixedWeather()},
+			expectPhoto: PhotoExplanation{
+				Date:       dateParser("2019-10-31T18:26:12"),
+				LocalTime:  geo.LocalTime(dateParser("2019-10-31T18:26:12"), 14.367075),
+				Latitude:   40.628197,
+				Longitude:  14.367075,
+				Places:     map[PlaceLevel]string{CityPlace: "Campania", Locality: "Positano", County: "Salerno", Region: "Campania", Country: "Italy", Continent: "Europe"},
+				Conditions: "Rain",
+				Weather:    "rainy",
+				Rule:       "rain",
+			},
+			expectWeather: map[string]float64{"rainy": 1, "sunny": 2},
+			expectPlaces: map[PlaceLevel]map[string]int{
+				CityPlace: {"Campania": 3},
+				Locality:  {"Positano": 2, "Amalfi": 1},
+				County:    {"Salerno": 3},
+				Region:    {"Campania": 3},
+				Country:   {"Italy": 3},
+				Continent: {"Europe": 3},
+			},
+			expectDecisions: []Decision{
+				{Facet: "weather", Value: "mostly sunny", Reason: "sunny has 67% of the votes, less than 75%, counted by photo"},
+				{Facet: "period", Value: "evening", Reason: "the photos span 26m47s over a single calendar day, all in the evening and within 6h0m0s"},
+				{Facet: "place", Value: "Positano", Reason: "Positano is the most common locality, where 2 of 3 photos were taken, as requested"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			locatorDouble := &mockLocator{}
+			locatorDouble.Test(t)
+			for latitude, location := range test.locations {
+				locatorDouble.On("Locate", latitude, mock.Anything).Return(location, nil)
+			}
+			weathermanDouble := &mockWeatherman{}
+			weathermanDouble.Test(t)
+			for latitude, conditions := range test.conditions {
+				weathermanDouble.On("CheckWeather", latitude, mock.Anything, mock.Anything).Return(weatherman.Forecast{Conditions: conditions}, nil)
+			}
+
+			p := New(locatorDouble, weathermanDouble, append(test.options, WithExplain())...)
+			got, errs := p.ProcessAlbum(test.input)
+			require.Empty(t, errs)
+			require.NotNil(t, got.Explanation)
+			require.Len(t, got.Explanation.Photos, len(test.input))
+			require.Equal(t, test.expectPhoto, got.Explanation.Photos[0])
+			require.Equal(t, test.expectWeather, got.Explanation.Weather)
+			require.Equal(t, test.expectPlaces, got.Explanation.Places)
+			require.Equal(t, test.expectDecisions, got.Explanation.Decisions)
+		})
+	}
+}
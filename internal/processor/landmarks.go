@@ -0,0 +1,55 @@
+package processor
+
+import (
+	"github.com/adrianos93/nomenclator/internal/landmark"
+	"github.com/adrianos93/nomenclator/internal/locator"
+)
+
+// Landmarks is an interface for resolving the landmark coordinates fall within, e.g. the landmark package
+type Landmarks interface {
+	Resolve(latitude, longitude float64) (landmark.Landmark, bool)
+}
+
+// LandmarkPlace is the level of places that are landmarks rather than administrative areas
+const LandmarkPlace PlaceLevel = "landmark"
+
+// WithLandmarks enables naming albums after the landmark most of their photos were taken at,
+// e.g. "A sunny evening on the Las Vegas Strip", when picking the place automatically
+func WithLandmarks(landmarks Landmarks) ProcessorOptions {
+	return func(p *Processor) {
+		p.landmarks = landmarks
+	}
+}
+
+// albumLandmark is a helper function used to return the landmark most commonly found for the photos of an album,
+// along with the share of photos taken within it
+func (p *Processor) albumLandmark(album []locator.Location) (landmark.Landmark, float64) {
+	if len(album) == 0 || p.landmarks == nil {
+		return landmark.Landmark{}, 0
+	}
+	m := make(map[string]int, len(album))
+	var count int
+	var best landmark.Landmark
+	for _, photo := range album {
+		l, found := p.landmark(photo)
+		if !found {
+			continue
+		}
+		m[l.Name]++
+		if m[l.Name] > count {
+			count = m[l.Name]
+			best = l
+		}
+	}
+	return best, float64(count) / float64(len(album))
+}
+
+// landmark is a helper function used to return the landmark a photo was taken at. Mountain ranges are only
+// found for photos taken in the mountains, as their outline also covers the valleys and plains around them.
+func (p *Processor) landmark(photo locator.Location) (landmark.Landmark, bool) {
+	l, found := p.landmarks.Resolve(photo.Latitude, photo.Longitude)
+	if found && l.Kind == "mountains" && !(photo.Elevation >= p.terrain.Mountains) {
+		return landmark.Landmark{}, false
+	}
+	return l, found
+}
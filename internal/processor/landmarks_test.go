@@ -0,0 +1,98 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/adrianos93/nomenclator/internal/i18n"
+	"github.com/adrianos93/nomenclator/internal/landmark"
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_ProcessAlbum_landmarks(t *testing.T) {
+	landmarks := landmark.New([]landmark.Landmark{
+		{Name: "Las Vegas Strip", Kind: "street", Preposition: "on the", Latitude: 36.1147, Longitude: -115.1728, Radius: 3},
+		{Name: "Central Park", Kind: "park", Preposition: "at", Latitude: 40.7812, Longitude: -73.9665, Radius: 1.9},
+	})
+	lasVegas := locator.Location{City: "Nevada", Locality: "Las Vegas", Region: "Nevada", Country: "United States"}
+	manhattan := locator.Location{City: "New York", Locality: "New York", Region: "New York", Country: "United States"}
+	strip := []Metadata{
+		{Latitude: 36.1126, Longitude: -115.1767, Date: dateParser("2019-06-14T03:12:19")},
+		{Latitude: 36.1162, Longitude: -115.1745, Date: dateParser("2019-06-14T03:40:10")},
+		{Latitude: 36.1212, Longitude: -115.1697, Date: dateParser("2019-06-14T04:32:02")},
+	}
+	park := []Metadata{
+		{Latitude: 40.7794, Longitude: -73.9632, Date: dateParser("2019-11-02T15:12:19")},
+		{Latitude: 40.7829, Longitude: -73.9654, Date: dateParser("2019-11-02T16:20:10")},
+		{Latitude: 40.7851, Longitude: -73.9683, Date: dateParser("2019-11-02T19:32:02")},
+		{Latitude: 40.7723, Longitude: -73.9735, Date: dateParser("2019-11-02T20:12:19")},
+		{Latitude: 40.7411, Longitude: -73.9897, Date: dateParser("2019-11-02T21:12:19")},
+	}
+	for name, test := range map[string]struct {
+		input      []Metadata
+		location   locator.Location
+		conditions string
+		options    []ProcessorOptions
+
+		expect Album
+	}{
+		"landmark": {
+			input:    strip,
+			location: lasVegas,
+			options:  []ProcessorOptions{WithLandmarks(landmarks)},
+			expect:   Album{Title: "A sunny evening on the Las Vegas Strip", Photos: 3},
+		},
+		"landmarks disabled": {
+			input:    strip,
+			location: lasVegas,
+			expect:   Album{Title: "A sunny evening in Las Vegas", Photos: 3},
+		},
+		"most photos within a landmark": {
+			input:      park,
+			location:   manhattan,
+			conditions: "Fog",
+			options:    []ProcessorOptions{WithLandmarks(landmarks)},
+			expect:     Album{Title: "A foggy day at Central Park", Photos: 5},
+		},
+		"too few photos within a landmark": {
+			input:    park[2:],
+			location: manhattan,
+			options:  []ProcessorOptions{WithLandmarks(landmarks)},
+			expect:   Album{Title: "A sunny afternoon in New York", Photos: 3},
+		},
+		"forced place level": {
+			input:    strip,
+			location: lasVegas,
+			options:  []ProcessorOptions{WithLandmarks(landmarks), WithPlaceLevel(Region)},
+			expect:   Album{Title: "A sunny evening in Nevada", Photos: 3},
+		},
+		"translated": {
+			input:      park,
+			location:   manhattan,
+			conditions: "Fog",
+			options:    []ProcessorOptions{WithLandmarks(landmarks), WithCatalog(i18n.Italian)},
+			expect:     Album{Title: "Una giornata nebbiosa a Central Park", Photos: 5},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			locatorDouble := &mockLocator{}
+			locatorDouble.Test(t)
+			locatorDouble.On("Locate", mock.Anything, mock.Anything).Return(test.location, nil)
+
+			conditions := test.conditions
+			if conditions == "" {
+				conditions = "Clear"
+			}
+			weathermanDouble := &mockWeatherman{}
+			weathermanDouble.Test(t)
+			weathermanDouble.On("CheckWeather", mock.Anything, mock.Anything, mock.Anything).Return(weatherman.Forecast{Conditions: conditions}, nil)
+
+			p := New(locatorDouble, weathermanDouble, test.options...)
+			got, errs := p.ProcessAlbum(test.input)
+			require.Empty(t, errs)
+			require.Equal(t, test.expect, got)
+		})
+	}
+}
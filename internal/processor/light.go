@@ -0,0 +1,78 @@
+package processor
+
+import (
+	"fmt"
+
+	"github.com/adrianos93/nomenclator/internal/astro"
+	"github.com/adrianos93/nomenclator/internal/locator"
+)
+
+// WithLight enables describing the natural light albums taken within a part of the day were taken in,
+// e.g. "A golden-hour evening in Positano" or "A night out in Las Vegas"
+func WithLight() ProcessorOptions {
+	return func(p *Processor) {
+		p.light = true
+	}
+}
+
+// albumLight is a helper function used to return the natural light most photos of an album were taken in,
+// along with the share of photos taken in it
+func albumLight(album []locator.Location) (astro.Light, float64) {
+	if len(album) == 0 {
+		return "", 0
+	}
+	m := make(map[astro.Light]int, 4)
+	var count int
+	var light astro.Light
+	for _, photo := range album {
+		l := astro.LightAt(photo.Date, photo.Latitude, photo.Longitude)
+		m[l]++
+		if m[l] > count {
+			count = m[l]
+			light = l
+		}
+	}
+	return light, float64(count) / float64(len(album))
+}
+
+// lightFacets is a helper function used to describe the light of albums taken within a part of the day, when at least
+// 80% of their photos were taken at night, in the blue hour or in the golden hour. Night albums are nights out, and
+// sunny weather is left out of them. The blue and golden hours describe clear skies, replacing sunny weather only.
+func lightFacets(album []locator.Location, f facets) facets {
+	switch f.period {
+	case "morning", "afternoon", "evening", "night":
+	default:
+		return f
+	}
+	light, share := albumLight(album)
+	if share < placeCoverage {
+		return f
+	}
+	sunny := f.weather == weather{adjective: "sunny"}
+	switch {
+	case light == astro.Night && sunny:
+		f.light, f.weather = string(light), weather{}
+	case light == astro.Night:
+		f.light = string(light)
+	case (light == astro.GoldenHour || light == astro.BlueHour) && sunny:
+		f.light, f.weather = string(light), weather{}
+	}
+	return f
+}
+
+// lightReason is a helper function used to describe the rule deciding the light of an album
+func lightReason(album []locator.Location, light string) string {
+	l, share := albumLight(album)
+	when := map[astro.Light]string{
+		astro.Night:      "at night",
+		astro.BlueHour:   "in the blue hour",
+		astro.GoldenHour: "in the golden hour",
+		astro.Daylight:   "in daylight",
+	}[l]
+	reason := fmt.Sprintf("%d of %d photos were taken %s", int(share*float64(len(album))+0.5), len(album), when)
+	if light == "" {
+		return fmt.Sprintf("%s, which is only named for nights and sunny albums within a part of the day, with at least %.0f%% of the photos",
+			reason, placeCoverage*100)
+	}
+	return fmt.Sprintf("%s, at least %.0f%% of them", reason, placeCoverage*100)
+}
@@ -0,0 +1,116 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/adrianos93/nomenclator/internal/i18n"
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_ProcessAlbum_light(t *testing.T) {
+	lasVegas := locator.Location{City: "Nevada", Locality: "Las Vegas", Region: "Nevada", Country: "United States"}
+	sunset := []Metadata{
+		{Latitude: 40.627883, Longitude: 14.366858, Date: dateParser("2019-06-21T18:05:00")},
+		{Latitude: 40.627808, Longitude: 14.364933, Date: dateParser("2019-06-21T18:15:00")},
+		{Latitude: 40.628197, Longitude: 14.367075, Date: dateParser("2019-06-21T18:25:00")},
+	}
+	halloween := []Metadata{
+		{Latitude: 40.627883, Longitude: 14.366858, Date: dateParser("2019-10-31T18:52:59")},
+		{Latitude: 40.627808, Longitude: 14.364933, Date: dateParser("2019-10-31T18:35:23")},
+		{Latitude: 40.628197, Longitude: 14.367075, Date: dateParser("2019-10-31T18:26:12")},
+	}
+	strip := []Metadata{
+		{Latitude: 36.112600, Longitude: -115.176700, Date: dateParser("2019-10-05T05:10:00")},
+		{Latitude: 36.116200, Longitude: -115.174500, Date: dateParser("2019-10-05T06:20:00")},
+		{Latitude: 36.121200, Longitude: -115.169700, Date: dateParser("2019-10-05T07:30:00")},
+	}
+	for name, test := range map[string]struct {
+		input      []Metadata
+		location   locator.Location
+		conditions string
+		options    []ProcessorOptions
+
+		expect string
+	}{
+		"golden hour": {
+			input:    sunset,
+			location: positano,
+			options:  []ProcessorOptions{WithLight()},
+			expect:   "A golden-hour evening in Positano",
+		},
+		"light disabled": {
+			input:    sunset,
+			location: positano,
+			expect:   "A sunny evening in Positano",
+		},
+		"golden hour in the rain": {
+			input:      sunset,
+			location:   positano,
+			conditions: "Rain",
+			options:    []ProcessorOptions{WithLight()},
+			expect:     "A rainy evening in Positano",
+		},
+		"translated golden hour": {
+			input:    sunset,
+			location: positano,
+			options:  []ProcessorOptions{WithLight(), WithCatalog(i18n.Italian)},
+			expect:   "Una sera all'ora d'oro a Positano",
+		},
+		"night out": {
+			input:    strip,
+			location: lasVegas,
+			options:  []ProcessorOptions{WithLight()},
+			expect:   "A night out in Las Vegas",
+		},
+		"rainy night out": {
+			input:      strip,
+			location:   lasVegas,
+			conditions: "Rain",
+			options:    []ProcessorOptions{WithLight()},
+			expect:     "A rainy night out in Las Vegas",
+		},
+		"holiday night": {
+			input:    halloween,
+			location: positano,
+			options:  []ProcessorOptions{WithLight(), WithCalendar()},
+			expect:   "A Halloween night in Positano",
+		},
+		"daylight": {
+			input: []Metadata{
+				{Latitude: 40.627883, Longitude: 14.366858, Date: dateParser("2019-06-21T08:05:00")},
+				{Latitude: 40.627808, Longitude: 14.364933, Date: dateParser("2019-06-21T09:15:00")},
+			},
+			location: positano,
+			options:  []ProcessorOptions{WithLight()},
+			expect:   "A sunny morning in Positano",
+		},
+		"longer than a part of the day": {
+			input:    append(append([]Metadata{}, sunset...), halloween...),
+			location: positano,
+			options:  []ProcessorOptions{WithLight()},
+			expect:   "A sunny year in Positano",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			locatorDouble := &mockLocator{}
+			locatorDouble.Test(t)
+			locatorDouble.On("Locate", mock.Anything, mock.Anything).Return(test.location, nil)
+
+			conditions := test.conditions
+			if conditions == "" {
+				conditions = "Clear"
+			}
+			weathermanDouble := &mockWeatherman{}
+			weathermanDouble.Test(t)
+			weathermanDouble.On("CheckWeather", mock.Anything, mock.Anything, mock.Anything).Return(weatherman.Forecast{Conditions: conditions}, nil)
+
+			p := New(locatorDouble, weathermanDouble, test.options...)
+			got, errs := p.ProcessAlbum(test.input)
+			require.Empty(t, errs)
+			require.Equal(t, test.expect, got.Title)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package processor
+
+import (
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/geo"
+	"github.com/adrianos93/nomenclator/internal/locator"
+)
+
+// Periods is a custom type used to configure the longest span of time each album period covers
+type Periods struct {
+	// PartOfDay is the longest span for albums taken within a single morning, afternoon, evening or night.
+	PartOfDay time.Duration
+	Day       time.Duration
+	FewDays   time.Duration
+	Week      time.Duration
+	Fortnight time.Duration
+	Month     time.Duration
+	// Season is the longest span of a season, longer albums are a year.
+	Season time.Duration
+}
+
+// DefaultPeriods is the Periods configuration used unless overridden
+var DefaultPeriods = Periods{
+	PartOfDay: 6 * time.Hour,
+	Day:       24 * time.Hour,
+	FewDays:   72 * time.Hour,
+	Week:      10 * 24 * time.Hour,
+	Fortnight: 18 * 24 * time.Hour,
+	Month:     45 * 24 * time.Hour,
+	Season:    120 * 24 * time.Hour,
+}
+
+// WithPeriods replaces the default Periods thresholds
+func WithPeriods(periods Periods) ProcessorOptions {
+	return func(p *Processor) {
+		p.periods = periods
+	}
+}
+
+// partOfDay is a helper function used to return the part of the day of a local time
+func partOfDay(local time.Time) string {
+	switch hour := local.Hour(); {
+	case hour >= 5 && hour < 12:
+		return "morning"
+	case hour >= 12 && hour < 17:
+		return "afternoon"
+	case hour >= 17 && hour < 21:
+		return "evening"
+	}
+	return "night"
+}
+
+// multiDay is a helper function used to tell whether a period describes a short trip over a few days
+func multiDay(period string) bool {
+	return period == "weekend" || period == "long weekend" || period == "few days"
+}
+
+// albumPeriod is a helper function used to return the period of time the album was composed in.
+// Photos are compared in the local time they were taken in, so that evenings and weekends match the clock
+// at the destination.
+// Long weekends span the four calendar days from a Friday to a Monday.
+func albumPeriod(album []locator.Location, periods Periods) string {
+	if len(album) < 1 {
+		return ""
+	}
+	minDate, maxDate := album[0].Date, album[0].Date
+	var minLocal, maxLocal time.Time
+	parts := make(map[string]int, 4)
+	for _, photo := range album {
+		local := geo.LocalTime(photo.Date, photo.Longitude)
+		parts[partOfDay(local)]++
+		if !photo.Date.After(minDate) {
+			minDate, minLocal = photo.Date, local
+		}
+		if !photo.Date.Before(maxDate) {
+			maxDate, maxLocal = photo.Date, local
+		}
+	}
+	span := maxDate.Sub(minDate)
+	days := calendarDays(minLocal, maxLocal)
+	switch {
+	case span <= periods.PartOfDay && len(parts) == 1:
+		return partOfDay(minLocal)
+	case span <= periods.Day:
+		return "day"
+	case days == 4 && minLocal.Weekday() == time.Friday:
+		return "long weekend"
+	case span <= periods.FewDays:
+		if isWeekend(minLocal.Weekday()) || isWeekend(maxLocal.Weekday()) {
+			return "weekend"
+		}
+		return "few days"
+	case span <= periods.Week:
+		return "week"
+	case span <= periods.Fortnight:
+		return "fortnight"
+	case span <= periods.Month:
+		return "month"
+	case span <= periods.Season:
+		return "season"
+	}
+	return "year"
+}
+
+// calendarDays is a helper function used to return the number of calendar days between two local times, inclusive
+func calendarDays(start, end time.Time) int {
+	first := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	last := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return int(last.Sub(first).Hours()/24) + 1
+}
+
+// isWeekend is a helper function used to tell whether a weekday is part of a weekend trip
+func isWeekend(day time.Weekday) bool {
+	return day == time.Friday || day == time.Saturday || day == time.Sunday
+}
@@ -0,0 +1,109 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/stretchr/testify/require"
+)
+
+// newYorkPhotos is a helper function used to build an album taken in New York, where local time is UTC-5
+func newYorkPhotos(dates ...string) []locator.Location {
+	album := make([]locator.Location, 0, len(dates))
+	for _, date := range dates {
+		album = append(album, locator.Location{City: "New York", Latitude: 40.728808, Longitude: -73.996106, Date: dateParser(date)})
+	}
+	return album
+}
+
+func TestProcessor_albumPeriod(t *testing.T) {
+	for name, test := range map[string]struct {
+		album   []locator.Location
+		periods *Periods
+
+		expect string
+	}{
+		"empty album": {
+			expect: "",
+		},
+		"morning": {
+			album:  newYorkPhotos("2020-03-30T14:12:19", "2020-03-30T15:40:10"),
+			expect: "morning",
+		},
+		"two hour afternoon walk": {
+			album:  newYorkPhotos("2020-03-30T18:00:00", "2020-03-30T20:00:00"),
+			expect: "afternoon",
+		},
+		"evening": {
+			album:  newYorkPhotos("2020-03-30T22:30:00", "2020-03-31T01:45:00"),
+			expect: "evening",
+		},
+		"night out past midnight": {
+			album:  newYorkPhotos("2020-03-31T03:00:00", "2020-03-31T07:30:00"),
+			expect: "night",
+		},
+		"morning to evening is a day": {
+			album:  newYorkPhotos("2020-03-30T14:12:19", "2020-03-30T23:40:10"),
+			expect: "day",
+		},
+		"weekend": {
+			album:  newYorkPhotos("2020-03-28T14:32:02", "2020-03-29T14:20:10", "2020-03-30T14:12:19"),
+			expect: "weekend",
+		},
+		"friday to monday is a long weekend": {
+			album:  newYorkPhotos("2020-03-27T15:00:00", "2020-03-28T15:00:00", "2020-03-30T22:00:00"),
+			expect: "long weekend",
+		},
+		"thursday to sunday is not a long weekend": {
+			album:  newYorkPhotos("2020-03-26T15:00:00", "2020-03-27T15:00:00", "2020-03-29T22:00:00"),
+			expect: "week",
+		},
+		"saturday to tuesday is not a long weekend": {
+			album:  newYorkPhotos("2020-03-28T15:00:00", "2020-03-29T15:00:00", "2020-03-31T22:00:00"),
+			expect: "week",
+		},
+		"few days": {
+			album:  newYorkPhotos("2020-02-11T14:12:19", "2020-02-13T14:32:02"),
+			expect: "few days",
+		},
+		"week": {
+			album:  newYorkPhotos("2020-03-28T14:32:02", "2020-04-01T14:32:02"),
+			expect: "week",
+		},
+		"fortnight": {
+			album:  newYorkPhotos("2020-03-01T14:00:00", "2020-03-14T14:00:00"),
+			expect: "fortnight",
+		},
+		"month long sabbatical": {
+			album:  newYorkPhotos("2020-03-01T14:00:00", "2020-03-31T14:00:00"),
+			expect: "month",
+		},
+		"season": {
+			album:  newYorkPhotos("2020-06-01T14:00:00", "2020-08-20T14:00:00"),
+			expect: "season",
+		},
+		"year": {
+			album:  newYorkPhotos("2020-01-01T14:00:00", "2020-12-20T14:00:00"),
+			expect: "year",
+		},
+		"custom thresholds": {
+			album: newYorkPhotos("2020-03-01T14:00:00", "2020-03-14T14:00:00"),
+			periods: &Periods{
+				PartOfDay: time.Hour,
+				Day:       24 * time.Hour,
+				FewDays:   72 * time.Hour,
+				Week:      14 * 24 * time.Hour,
+			},
+			expect: "week",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			periods := DefaultPeriods
+			if test.periods != nil {
+				periods = *test.periods
+			}
+			require.Equal(t, test.expect, albumPeriod(test.album, periods))
+		})
+	}
+}
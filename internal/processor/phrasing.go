@@ -0,0 +1,93 @@
+package processor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/adrianos93/nomenclator/internal/locator"
+)
+
+// WeatherPhrasing is a custom type used to configure how mixed weather is described in titles
+type WeatherPhrasing struct {
+	// Transition is the share of votes the leading adjective must have in both the first and the second half
+	// of the album for the title to describe a change, e.g. "rainy then sunny".
+	Transition float64
+	// TieMargin is how close, as a share of all votes, the two leading adjectives must be for the weather
+	// to be described as "changeable".
+	TieMargin float64
+	// Mostly is the share of votes under which the leading adjective is qualified, e.g. "mostly sunny".
+	Mostly float64
+}
+
+// DefaultWeatherPhrasing is the WeatherPhrasing configuration used by WithMixedWeather
+var DefaultWeatherPhrasing = WeatherPhrasing{
+	Transition: 0.7,
+	TieMargin:  0.1,
+	Mostly:     0.75,
+}
+
+// WithWeatherPhrasing enables describing mixed weather in titles, using the provided thresholds
+func WithWeatherPhrasing(phrasing WeatherPhrasing) ProcessorOptions {
+	return func(p *Processor) {
+		p.phrasing = &phrasing
+	}
+}
+
+// WithMixedWeather enables describing mixed weather in titles, using the default thresholds
+func WithMixedWeather() ProcessorOptions {
+	return WithWeatherPhrasing(DefaultWeatherPhrasing)
+}
+
+// mixedWeather is a helper function used to describe the weather of an album that changed over time,
+// e.g. "rainy then sunny", "changeable" or "mostly sunny". The leading adjective is returned when the
+// weather was consistent. The reason for the phrasing is returned along with it.
+func mixedWeather(album []locator.Location, rules *WeatherRules, aggregation WeatherAggregation, phrasing WeatherPhrasing) (weather, string) {
+	if len(album) == 0 {
+		return weather{}, ""
+	}
+	photos := make([]locator.Location, len(album))
+	copy(photos, album)
+	sort.SliceStable(photos, func(i, j int) bool { return photos[i].Date.Before(photos[j].Date) })
+
+	midpoint := photos[0].Date.Add(photos[len(photos)-1].Date.Sub(photos[0].Date) / 2)
+	split := sort.Search(len(photos), func(i int) bool { return photos[i].Date.After(midpoint) })
+	if split >= 2 && len(photos)-split >= 2 {
+		before, beforeShare := leadingAdjective(weatherVotes(photos[:split], rules, aggregation), rules)
+		after, afterShare := leadingAdjective(weatherVotes(photos[split:], rules, aggregation), rules)
+		if before != after && beforeShare >= phrasing.Transition && afterShare >= phrasing.Transition {
+			return weather{adjective: before, then: after}, fmt.Sprintf("%s has %.0f%% of the votes in the first half of the album and %s %.0f%% in the second, at least %.0f%%",
+				before, beforeShare*100, after, afterShare*100, phrasing.Transition*100)
+		}
+	}
+
+	votes := weatherVotes(photos, rules, aggregation)
+	leader, share := leadingAdjective(votes, rules)
+	delete(votes, leader)
+	_, runnerUpShare := leadingAdjective(votes, rules)
+	// the runner up share is relative to the remaining votes, so bring it back to a share of all votes.
+	runnerUpShare *= 1 - share
+	switch {
+	case share < 1 && share-runnerUpShare <= phrasing.TieMargin:
+		return weather{adjective: "changeable"}, fmt.Sprintf("%s has %.0f%% of the votes, within %.0f%% of the runner up",
+			leader, share*100, phrasing.TieMargin*100)
+	case share < 0.5:
+		return weather{adjective: "changeable"}, fmt.Sprintf("%s has %.0f%% of the votes, less than half", leader, share*100)
+	case share < phrasing.Mostly:
+		return weather{adjective: leader, mostly: true}, fmt.Sprintf("%s has %.0f%% of the votes, less than %.0f%%",
+			leader, share*100, phrasing.Mostly*100)
+	}
+	return weather{adjective: leader}, fmt.Sprintf("%s has %.0f%% of the votes", leader, share*100)
+}
+
+// leadingAdjective is a helper function used to return the adjective with the most votes and its share of all votes
+func leadingAdjective(votes map[string]float64, rules *WeatherRules) (string, float64) {
+	var total float64
+	for _, n := range votes {
+		total += n
+	}
+	if total == 0 {
+		return "", 0
+	}
+	leader := topAdjective(votes, rules)
+	return leader, votes[leader] / total
+}
@@ -0,0 +1,141 @@
+package processor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_Process_mixedWeather(t *testing.T) {
+	newYork := locator.Location{City: "New York", Country: "USA"}
+	for name, test := range map[string]struct {
+		input    [][]string
+		phrasing WeatherPhrasing
+
+		weathermanCalls []mock.Call
+
+		expect string
+	}{
+		"rainy then sunny weekend": {
+			input: [][]string{
+				{"2020-03-28T14:12:19Z", "40.728808", "-73.996106"},
+				{"2020-03-28T18:20:10Z", "40.728656", "-73.998790"},
+				{"2020-03-29T20:32:02Z", "40.727160", "-73.996044"},
+				{"2020-03-30T14:12:19Z", "40.728808", "-73.996106"},
+			},
+			phrasing: DefaultWeatherPhrasing,
+			weathermanCalls: []mock.Call{
+				{Method: "CheckWeather", Arguments: []interface{}{40.728808, -73.996106, dateParser("2020-03-28T14:12:19")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Rain"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.728656, -73.998790, dateParser("2020-03-28T18:20:10")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Rain, Overcast"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.727160, -73.996044, dateParser("2020-03-29T20:32:02")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Clear"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.728808, -73.996106, dateParser("2020-03-30T14:12:19")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Partially cloudy"}, nil}},
+			},
+			expect: "A rainy then sunny weekend in New York",
+		},
+		"changeable few days": {
+			input: [][]string{
+				{"2020-02-11T14:12:19Z", "40.728808", "-73.996106"},
+				{"2020-02-12T14:20:10Z", "40.728656", "-73.998790"},
+				{"2020-02-12T18:32:02Z", "40.727160", "-73.996044"},
+				{"2020-02-13T14:12:19Z", "40.728808", "-73.996106"},
+			},
+			phrasing: DefaultWeatherPhrasing,
+			weathermanCalls: []mock.Call{
+				{Method: "CheckWeather", Arguments: []interface{}{40.728808, -73.996106, dateParser("2020-02-11T14:12:19")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Snow"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.728656, -73.998790, dateParser("2020-02-12T14:20:10")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Clear"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.727160, -73.996044, dateParser("2020-02-12T18:32:02")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Snow"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.728808, -73.996106, dateParser("2020-02-13T14:12:19")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Clear"}, nil}},
+			},
+			expect: "A changeable few days in New York",
+		},
+		"mostly sunny week": {
+			input: [][]string{
+				{"2020-03-28T14:12:19Z", "40.728808", "-73.996106"},
+				{"2020-03-29T14:20:10Z", "40.728656", "-73.998790"},
+				{"2020-03-30T14:32:02Z", "40.727160", "-73.996044"},
+				{"2020-03-31T14:32:02Z", "40.727160", "-73.996044"},
+				{"2020-04-01T14:32:02Z", "40.727160", "-73.996044"},
+			},
+			phrasing: DefaultWeatherPhrasing,
+			weathermanCalls: []mock.Call{
+				{Method: "CheckWeather", Arguments: []interface{}{40.728808, -73.996106, dateParser("2020-03-28T14:12:19")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Clear"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.728656, -73.998790, dateParser("2020-03-29T14:20:10")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Rain"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.727160, -73.996044, dateParser("2020-03-30T14:32:02")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Clear"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.727160, -73.996044, dateParser("2020-03-31T14:32:02")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Overcast"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.727160, -73.996044, dateParser("2020-04-01T14:32:02")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Clear"}, nil}},
+			},
+			expect: "A mostly sunny week in New York",
+		},
+		"consistently rainy day": {
+			input: [][]string{
+				{"2020-03-30T14:12:19Z", "40.728808", "-73.996106"},
+				{"2020-03-30T20:20:10Z", "40.728656", "-73.998790"},
+			},
+			phrasing: DefaultWeatherPhrasing,
+			weathermanCalls: []mock.Call{
+				{Method: "CheckWeather", Arguments: []interface{}{40.728808, -73.996106, dateParser("2020-03-30T14:12:19")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Rain"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.728656, -73.998790, dateParser("2020-03-30T20:20:10")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Drizzle"}, nil}},
+			},
+			expect: "A rainy day in New York",
+		},
+		"stricter transition threshold": {
+			input: [][]string{
+				{"2020-03-28T14:12:19Z", "40.728808", "-73.996106"},
+				{"2020-03-28T18:20:10Z", "40.728656", "-73.998790"},
+				{"2020-03-28T19:20:10Z", "40.728656", "-73.998790"},
+				{"2020-03-29T20:32:02Z", "40.727160", "-73.996044"},
+				{"2020-03-30T14:12:19Z", "40.728808", "-73.996106"},
+			},
+			phrasing: WeatherPhrasing{Transition: 0.9, TieMargin: 0.1, Mostly: 0.5},
+			weathermanCalls: []mock.Call{
+				{Method: "CheckWeather", Arguments: []interface{}{40.728808, -73.996106, dateParser("2020-03-28T14:12:19")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Rain"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.728656, -73.998790, dateParser("2020-03-28T18:20:10")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Rain"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.728656, -73.998790, dateParser("2020-03-28T19:20:10")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Clear"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.727160, -73.996044, dateParser("2020-03-29T20:32:02")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Clear"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.728808, -73.996106, dateParser("2020-03-30T14:12:19")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Clear"}, nil}},
+			},
+			expect: "A sunny weekend in New York",
+		},
+		"wider tie margin": {
+			input: [][]string{
+				{"2020-03-30T14:12:19Z", "40.728808", "-73.996106"},
+				{"2020-03-30T15:20:10Z", "40.728656", "-73.998790"},
+				{"2020-03-30T16:32:02Z", "40.727160", "-73.996044"},
+				{"2020-03-30T17:12:19Z", "40.728808", "-73.996106"},
+				{"2020-03-30T18:12:19Z", "40.728808", "-73.996106"},
+			},
+			phrasing: WeatherPhrasing{Transition: 0.7, TieMargin: 0.3, Mostly: 0.75},
+			weathermanCalls: []mock.Call{
+				{Method: "CheckWeather", Arguments: []interface{}{40.728808, -73.996106, dateParser("2020-03-30T14:12:19")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Fog"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.728656, -73.998790, dateParser("2020-03-30T15:20:10")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Clear"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.727160, -73.996044, dateParser("2020-03-30T16:32:02")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Fog"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.728808, -73.996106, dateParser("2020-03-30T17:12:19")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Clear"}, nil}},
+				{Method: "CheckWeather", Arguments: []interface{}{40.728808, -73.996106, dateParser("2020-03-30T18:12:19")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Fog"}, nil}},
+			},
+			expect: "A changeable day in New York",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			locatorDouble := &mockLocator{}
+			locatorDouble.Test(t)
+			defer locatorDouble.AssertExpectations(t)
+			locatorDouble.On("Locate", mock.Anything, mock.Anything).Return(newYork, nil)
+
+			weathermanDouble := &mockWeatherman{}
+			weathermanDouble.Test(t)
+			defer weathermanDouble.AssertExpectations(t)
+			for _, call := range test.weathermanCalls {
+				weathermanDouble.On(call.Method, call.Arguments...).Return(call.ReturnArguments...)
+			}
+
+			p := New(locatorDouble, weathermanDouble, WithWeatherPhrasing(test.phrasing))
+			got, errs := p.Process(test.input)
+			require.Empty(t, errs, fmt.Sprint(errs))
+			require.Equal(t, test.expect, got)
+		})
+	}
+}
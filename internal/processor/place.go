@@ -0,0 +1,112 @@
+package processor
+
+import (
+	"fmt"
+
+	"github.com/adrianos93/nomenclator/internal/locator"
+)
+
+// PlaceLevel is a custom type used to select the administrative level of the place named in titles
+type PlaceLevel string
+
+const (
+	// AutoPlace picks the most specific level shared by most photos
+	AutoPlace PlaceLevel = "auto"
+	// CityPlace names the city, as reported by Locator
+	CityPlace     PlaceLevel = "city"
+	Neighbourhood PlaceLevel = "neighbourhood"
+	Locality      PlaceLevel = "locality"
+	County        PlaceLevel = "county"
+	Region        PlaceLevel = "region"
+	Country       PlaceLevel = "country"
+	Continent     PlaceLevel = "continent"
+)
+
+// placeLevels lists the administrative levels from the most to the least specific
+var placeLevels = []PlaceLevel{Neighbourhood, Locality, County, Region, Country, Continent}
+
+// placeCoverage is the share of photos a place must cover for AutoPlace to pick its level
+const placeCoverage = 0.8
+
+// WithPlaceLevel sets the administrative level of the place named in titles
+func WithPlaceLevel(level PlaceLevel) ProcessorOptions {
+	return func(p *Processor) {
+		p.placeLevel = level
+	}
+}
+
+// ParsePlaceLevel is used to return the PlaceLevel named s
+func ParsePlaceLevel(s string) (PlaceLevel, error) {
+	switch level := PlaceLevel(s); level {
+	case AutoPlace, CityPlace, Neighbourhood, Locality, County, Region, Country, Continent:
+		return level, nil
+	}
+	return "", fmt.Errorf("unknown place level %q, use one of %s, %s, %s, %s, %s, %s, %s, %s",
+		s, AutoPlace, CityPlace, Neighbourhood, Locality, County, Region, Country, Continent)
+}
+
+// albumPlace is a helper function used to return the name of the place an album was taken in.
+// With AutoPlace, the most specific level whose most common place covers most photos is used,
+// e.g. a neighbourhood for a walk, or a region for a road trip. The city is used as a fallback
+// when no level qualifies, or the requested level is missing from the locations. The level of the place is
+// returned along with its name.
+func albumPlace(album []locator.Location, level PlaceLevel) (string, PlaceLevel) {
+	switch level {
+	case AutoPlace, "":
+		for _, level := range placeLevels {
+			place, count := commonPlace(album, level)
+			if place != "" && float64(count) >= placeCoverage*float64(len(album)) {
+				return place, level
+			}
+		}
+	case CityPlace:
+	default:
+		if place, _ := commonPlace(album, level); place != "" {
+			return place, level
+		}
+	}
+	return albumCity(album), CityPlace
+}
+
+// commonPlace is a helper function used to return the most commonly occurring place at an
+// administrative level, and the number of photos taken there
+func commonPlace(album []locator.Location, level PlaceLevel) (string, int) {
+	m := make(map[string]int, len(album))
+	var count int
+	var place string
+	for _, photo := range album {
+		name := placeName(photo, level)
+		if name == "" {
+			continue
+		}
+		m[name]++
+		if m[name] > count {
+			count = m[name]
+			place = name
+		}
+	}
+	return place, count
+}
+
+// placeName is a helper function used to return the name of a location at an administrative level.
+// The city stands in for a missing region, as Locator fills it from the region.
+func placeName(location locator.Location, level PlaceLevel) string {
+	switch level {
+	case Neighbourhood:
+		return location.Neighbourhood
+	case Locality:
+		return location.Locality
+	case County:
+		return location.County
+	case Region:
+		if location.Region == "" {
+			return location.City
+		}
+		return location.Region
+	case Country:
+		return location.Country
+	case Continent:
+		return location.Continent
+	}
+	return location.City
+}
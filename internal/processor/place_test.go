@@ -0,0 +1,136 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	positano = locator.Location{
+		City:      "Campania",
+		Locality:  "Positano",
+		County:    "Salerno",
+		Region:    "Campania",
+		Country:   "Italy",
+		Continent: "Europe",
+	}
+	amalfi = locator.Location{
+		City:      "Campania",
+		Locality:  "Amalfi",
+		County:    "Salerno",
+		Region:    "Campania",
+		Country:   "Italy",
+		Continent: "Europe",
+	}
+	naples = locator.Location{
+		City:      "Campania",
+		Locality:  "Naples",
+		County:    "Naples",
+		Region:    "Campania",
+		Country:   "Italy",
+		Continent: "Europe",
+	}
+	rome = locator.Location{
+		City:      "Lazio",
+		Locality:  "Rome",
+		County:    "Rome",
+		Region:    "Lazio",
+		Country:   "Italy",
+		Continent: "Europe",
+	}
+	greenwichVillage = locator.Location{
+		City:          "New York",
+		Neighbourhood: "Greenwich Village",
+		Locality:      "New York",
+		County:        "New York County",
+		Region:        "New York",
+		Country:       "United States",
+		Continent:     "North America",
+	}
+)
+
+func TestProcessor_albumPlace(t *testing.T) {
+	for name, test := range map[string]struct {
+		album []locator.Location
+		level PlaceLevel
+
+		expect string
+	}{
+		"empty album": {
+			level:  AutoPlace,
+			expect: "",
+		},
+		"neighbourhood walk": {
+			album:  []locator.Location{greenwichVillage, greenwichVillage, greenwichVillage},
+			level:  AutoPlace,
+			expect: "Greenwich Village",
+		},
+		"small town": {
+			album:  []locator.Location{positano, positano, positano, positano, amalfi},
+			level:  AutoPlace,
+			expect: "Positano",
+		},
+		"towns in the same county": {
+			album:  []locator.Location{positano, positano, amalfi, amalfi, amalfi},
+			level:  AutoPlace,
+			expect: "Salerno",
+		},
+		"towns in the same region": {
+			album:  []locator.Location{positano, amalfi, naples, naples, naples},
+			level:  AutoPlace,
+			expect: "Campania",
+		},
+		"regions in the same country": {
+			album:  []locator.Location{positano, amalfi, naples, rome, rome},
+			level:  AutoPlace,
+			expect: "Italy",
+		},
+		"continents": {
+			album:  []locator.Location{positano, greenwichVillage},
+			level:  AutoPlace,
+			expect: "Campania",
+		},
+		"only the city known": {
+			album:  []locator.Location{{City: "London"}, {City: "London"}},
+			level:  AutoPlace,
+			expect: "London",
+		},
+		"forced locality": {
+			album:  []locator.Location{positano, amalfi, naples, naples, rome},
+			level:  Locality,
+			expect: "Naples",
+		},
+		"forced country": {
+			album:  []locator.Location{positano, positano},
+			level:  Country,
+			expect: "Italy",
+		},
+		"forced city": {
+			album:  []locator.Location{positano, positano},
+			level:  CityPlace,
+			expect: "Campania",
+		},
+		"forced level missing": {
+			album:  []locator.Location{positano, positano},
+			level:  Neighbourhood,
+			expect: "Campania",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, _ := albumPlace(test.album, test.level)
+			require.Equal(t, test.expect, got)
+		})
+	}
+}
+
+func TestProcessor_ParsePlaceLevel(t *testing.T) {
+	for _, name := range []string{"auto", "city", "neighbourhood", "locality", "county", "region", "country", "continent"} {
+		got, err := ParsePlaceLevel(name)
+		require.NoError(t, err)
+		require.Equal(t, PlaceLevel(name), got)
+	}
+	_, err := ParsePlaceLevel("street")
+	require.Error(t, err)
+}
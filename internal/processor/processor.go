@@ -3,11 +3,11 @@ package processor
 import (
 	"errors"
 	"fmt"
-	"regexp"
+	"math"
 	"strconv"
-	"strings"
 	"time"
 
+	"github.com/adrianos93/nomenclator/internal/i18n"
 	"github.com/adrianos93/nomenclator/internal/locator"
 	"github.com/adrianos93/nomenclator/internal/weatherman"
 )
@@ -24,62 +24,235 @@ type Weatherman interface {
 
 // Processor is an interface for interacting with the processing piece of analysing photo metadata
 type Processor struct {
-	locator    Locator
-	weatherman Weatherman
+	locator      Locator
+	weatherman   Weatherman
+	validation   Validation
+	calendar     bool
+	periods      Periods
+	weatherRules *WeatherRules
+	aggregation  WeatherAggregation
+	phrasing     *WeatherPhrasing
+	placeLevel   PlaceLevel
+	catalog      *i18n.Catalog
+	suggestions  int
+	explain      bool
+	landmarks    Landmarks
+	travel       *TravelModes
+	light        bool
+	nightSky     bool
+	elevation    Elevation
+	coastline    Coastline
+	terrain      Terrain
+	progress     func(error)
+	now          func() time.Time
+}
+
+type ProcessorOptions func(*Processor)
+
+// Album is a custom type used to describe the result of processing an album
+type Album struct {
+	Title string `json:"title"`
+	// Suggestions lists alternative titles, best first, when enabled with WithSuggestions
+	Suggestions []Suggestion `json:"suggestions,omitempty"`
+	// Photos is the number of photos the title is based on
+	Photos int `json:"photos"`
+	// Travel describes how the album moved through space, when enabled with WithTravel
+	Travel *Travel `json:"travel,omitempty"`
+	// Sky describes the moon and the stars over albums taken at night, when enabled with WithNightSky
+	Sky *Sky `json:"sky,omitempty"`
+	// Altitude is the range of elevations the photos were taken at, when enabled with WithElevation
+	Altitude *Altitude `json:"altitude,omitempty"`
+	// Explanation describes how the title was derived, when enabled with WithExplain
+	Explanation *Explanation `json:"explanation,omitempty"`
 }
 
 // Metadata is a custom type for storing photo metadata
 type Metadata struct {
-	latitude, longitude float64
-	date                time.Time
+	Latitude, Longitude float64
+	Date                time.Time
+}
+
+// WithCalendar enables holiday, named event and season detection in titles
+func WithCalendar() ProcessorOptions {
+	return func(p *Processor) {
+		p.calendar = true
+	}
+}
+
+// WithProgress calls progress once for every photo of an album as it is processed, with the error it was discarded for
+// or nil, so that the progress of large albums can be tracked
+func WithProgress(progress func(error)) ProcessorOptions {
+	return func(p *Processor) {
+		p.progress = progress
+	}
 }
 
 // New returns a new Processor
-func New(l Locator, w Weatherman) *Processor {
-	return &Processor{
-		locator:    l,
-		weatherman: w,
+func New(l Locator, w Weatherman, options ...ProcessorOptions) *Processor {
+	processor := &Processor{
+		locator:      l,
+		weatherman:   w,
+		validation:   DefaultValidation,
+		periods:      DefaultPeriods,
+		weatherRules: DefaultWeatherRules,
+		aggregation:  PerPhoto,
+		placeLevel:   AutoPlace,
+		terrain:      DefaultTerrain,
+		catalog:      i18n.English,
+		now:          time.Now,
+	}
+	for _, option := range options {
+		option(processor)
 	}
+	return processor
 }
 
 // Process is used to process the data obtained from a source file and returning
 // a title based on common features of the pictures composing an album.
 func (p *Processor) Process(data [][]string) (string, []error) {
 	errs := make([]error, 0, len(data))
-	albumMetadata := make([]locator.Location, 0, len(data))
+	album := make([]Metadata, 0, len(data))
 	for _, row := range data {
-		metadata, err := mapDataRowToStruct(row)
+		metadata, err := ParseRow(row)
 		if err != nil {
 			errs = append(errs, fmt.Errorf("invalid photo: %w", err))
 			continue
 		}
-		photoMetadata, err := p.locator.Locate(metadata.latitude, metadata.longitude)
+		album = append(album, metadata)
+	}
+	title, albumErrs := p.ProcessMetadata(album)
+	return title, append(errs, albumErrs...)
+}
+
+// ProcessMetadata is used to return a title based on common features of the pictures composing an album,
+// for photo metadata that has already been decoded from its source.
+// Photos failing validation are reported without any API being called for them.
+func (p *Processor) ProcessMetadata(album []Metadata) (string, []error) {
+	result, errs := p.ProcessAlbum(album)
+	return result.Title, errs
+}
+
+// ProcessAlbum is used to return the title of an album along with the other results of processing it,
+// for photo metadata that has already been decoded from its source.
+func (p *Processor) ProcessAlbum(album []Metadata) (Album, []error) {
+	albumMetadata, errs := p.locate(album)
+	if len(albumMetadata) == 0 {
+		return Album{}, errs
+	}
+	f := facets{
+		weather: weather{adjective: weatherConditions(albumMetadata, p.weatherRules, p.aggregation)},
+		period:  albumPeriod(albumMetadata, p.periods),
+	}
+	f.place, f.placeLevel = albumPlace(albumMetadata, p.placeLevel)
+	if p.placeLevel == AutoPlace || p.placeLevel == "" {
+		if l, share := p.albumLandmark(albumMetadata); share >= placeCoverage {
+			f.place, f.placeLevel, f.preposition = l.Name, LandmarkPlace, l.Preposition
+		}
+		if terrain, _ := p.albumTerrain(albumMetadata); terrain != "" && broad(f.placeLevel) {
+			f.place, f.placeLevel = terrain, TerrainPlace
+		}
+	}
+	if p.phrasing != nil {
+		f.weather, _ = mixedWeather(albumMetadata, p.weatherRules, p.aggregation, *p.phrasing)
+	}
+	if p.light {
+		f = lightFacets(albumMetadata, f)
+	}
+	var sky *Sky
+	if p.nightSky {
+		sky = albumSky(albumMetadata)
+		f = skyFacets(sky, f)
+	}
+	if p.calendar {
+		f.event = albumEvent(albumMetadata, f.period)
+		f.season = albumSeason(albumMetadata, f.period)
+	}
+	var travel Travel
+	if p.travel != nil {
+		travel = albumTravel(albumMetadata, *p.travel)
+		f.travel = string(travel.Mode)
+	}
+	result := Album{
+		Title:  f.title(p.catalog),
+		Photos: len(albumMetadata),
+		Sky:    sky,
+	}
+	if p.travel != nil {
+		result.Travel = &travel
+	}
+	if p.elevation != nil {
+		result.Altitude = albumAltitude(albumMetadata)
+	}
+	if p.suggestions > 0 {
+		result.Suggestions = p.suggest(albumMetadata, f)
+	}
+	if p.explain {
+		result.Explanation = p.explainAlbum(albumMetadata, f)
+	}
+	return result, errs
+}
+
+// locate is a helper function used to validate the photos of an album and look up their location and weather.
+// Photos failing validation are reported without any API being called for them.
+func (p *Processor) locate(album []Metadata) ([]locator.Location, []error) {
+	errs := make([]error, 0, len(album))
+	valid := make([]Metadata, 0, len(album))
+	for _, metadata := range album {
+		if err := p.validate(metadata); err != nil {
+			errs = append(errs, p.report(fmt.Errorf("invalid photo: %w", err)))
+			continue
+		}
+		valid = append(valid, metadata)
+	}
+	outliers := p.outliers(valid)
+
+	albumMetadata := make([]locator.Location, 0, len(valid))
+	for i, metadata := range valid {
+		if err, found := outliers[i]; found {
+			errs = append(errs, p.report(fmt.Errorf("excluded photo: %w", err)))
+			continue
+		}
+		photoMetadata, err := p.locator.Locate(metadata.Latitude, metadata.Longitude)
 		if err != nil {
-			errs = append(errs, fmt.Errorf("invalid photo: %w", err))
+			errs = append(errs, p.report(fmt.Errorf("invalid photo: %w", err)))
 			continue
 		}
-		photoMetadata.Date = metadata.date
-		weatherCondition, err := p.weatherman.CheckWeather(metadata.latitude, metadata.longitude, metadata.date)
+		photoMetadata.Latitude, photoMetadata.Longitude = metadata.Latitude, metadata.Longitude
+		photoMetadata.Elevation = p.photoElevation(metadata.Latitude, metadata.Longitude)
+		photoMetadata.Date = metadata.Date
+		weatherCondition, err := p.weatherman.CheckWeather(metadata.Latitude, metadata.Longitude, metadata.Date)
 		if err != nil {
-			errs = append(errs, fmt.Errorf("invalid photo: %w", err))
+			errs = append(errs, p.report(fmt.Errorf("invalid photo: %w", err)))
 			continue
 		}
 		photoMetadata.Weather = weatherCondition.Conditions
+		photoMetadata.CloudCover = math.NaN()
+		if weatherCondition.CloudCover != nil {
+			photoMetadata.CloudCover = *weatherCondition.CloudCover
+		}
+		p.report(nil)
 
 		albumMetadata = append(albumMetadata, photoMetadata)
 	}
-	if len(albumMetadata) == 0 {
-		return "", errs
+	return albumMetadata, errs
+}
+
+// report is a helper function used to tell the progress callback, if any, that a photo was processed, returning its error
+func (p *Processor) report(err error) error {
+	if p.progress != nil {
+		p.progress(err)
 	}
-	title := "A " + weatherConditions(albumMetadata) + " " + albumPeriod(albumMetadata) + " in " + albumCity(albumMetadata)
-	return title, errs
+	return err
 }
 
-// mapDataRowToStruct is a helper function used to map raw photo metadata to the Metadata custom type
-func mapDataRowToStruct(metadata []string) (Metadata, error) {
+// ParseRow is used to map a row of raw photo metadata following the CSV schema to the Metadata custom type
+func ParseRow(metadata []string) (Metadata, error) {
 	if len(metadata) < 1 {
 		return Metadata{}, errors.New("empty row")
 	}
+	if len(metadata) < 3 {
+		return Metadata{}, errors.New("row must contain a date, a latitude and a longitude")
+	}
 	date, err := time.Parse("2006-01-02T15:04:05Z", metadata[0])
 	if err != nil {
 		return Metadata{}, fmt.Errorf("invalid date: %w", err)
@@ -94,9 +267,9 @@ func mapDataRowToStruct(metadata []string) (Metadata, error) {
 		return Metadata{}, err
 	}
 	return Metadata{
-		latitude:  latitude,
-		longitude: longitude,
-		date:      date,
+		Latitude:  latitude,
+		Longitude: longitude,
+		Date:      date,
 	}, nil
 }
 
@@ -114,88 +287,3 @@ func albumCity(album []locator.Location) string {
 	}
 	return city
 }
-
-// albumPeriod is a helper function used to return the period of time the album was composed in.
-func albumPeriod(album []locator.Location) string {
-	if len(album) < 1 {
-		return ""
-	}
-	var period string
-	weekendRegExp := regexp.MustCompile(`Saturday|Sunday|Friday`)
-	minDate, maxDate := album[0].Date, album[0].Date
-	for _, photo := range album {
-		if photo.Date.Before(minDate) {
-			minDate = photo.Date
-		}
-		if photo.Date.After(maxDate) {
-			maxDate = photo.Date
-		}
-	}
-	days := maxDate.Sub(minDate).Hours() / 24
-	switch {
-	case days > 3:
-		period = "week"
-	case days > 1:
-		period = "few days"
-		if weekendRegExp.MatchString(maxDate.Weekday().String()) || weekendRegExp.MatchString(minDate.Weekday().String()) {
-			period = "weekend"
-		}
-	default:
-		period = "day"
-	}
-	return period
-}
-
-// weatherConditions is a helper function used to analyse the data returned by Weatherman and translate it to something more title friendly.
-func weatherConditions(album []locator.Location) string {
-	m := make(map[string]int, len(album))
-	var count int
-	var weather string
-	rainyRegExp := regexp.MustCompile(`rain|drizzle|shower`)
-	snowyRegExp := regexp.MustCompile(`snow`)
-	stormyRegExp := regexp.MustCompile(`storm|thunder|tornado`)
-	icyRegExp := regexp.MustCompile(`ice|icy`)
-	foggyRegExp := regexp.MustCompile(`mist|overcast|fog`)
-	for _, photo := range album {
-		switch {
-		case rainyRegExp.MatchString(strings.ToLower(photo.Weather)):
-			m["rainy"]++
-			if m["rainy"] > count {
-				count = m["rainy"]
-				weather = "rainy"
-			}
-		case snowyRegExp.MatchString(strings.ToLower(photo.Weather)):
-			m["snowy"]++
-			if m["snowy"] > count {
-				count = m["snowy"]
-				weather = "snowy"
-			}
-		case stormyRegExp.MatchString(strings.ToLower(photo.Weather)):
-			m["stormy"]++
-			if m["stormy"] > count {
-				count = m["stormy"]
-				weather = "stormy"
-			}
-		case icyRegExp.MatchString(strings.ToLower(photo.Weather)):
-			m["chilly"]++
-			if m["chilly"] > count {
-				count = m["icy"]
-				weather = "chilly"
-			}
-		case foggyRegExp.MatchString(strings.ToLower(photo.Weather)):
-			m["foggy"]++
-			if m["foggy"] > count {
-				count = m["foggy"]
-				weather = "foggy"
-			}
-		default:
-			m["sunny"]++
-			if m["sunny"] > count {
-				count = m["sunny"]
-				weather = "sunny"
-			}
-		}
-
-	}
-	return weather
-}
@@ -87,7 +87,7 @@ func TestProcessor_Process(t *testing.T) {
 
 			expect: "A snowy few days in New York",
 		},
-		"get stormy day": {
+		"get stormy morning": {
 			input: [][]string{
 				{"2020-02-11T14:12:19Z", "40.728808", "-73.996106"},
 			},
@@ -98,9 +98,9 @@ func TestProcessor_Process(t *testing.T) {
 				{Method: "CheckWeather", Arguments: []interface{}{40.728808, -73.996106, dateParser("2020-02-11T14:12:19")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Tornado"}, nil}},
 			},
 
-			expect: "A stormy day in New York",
+			expect: "A stormy morning in New York",
 		},
-		"get chilly day": {
+		"get chilly morning": {
 			input: [][]string{
 				{"2020-02-11T14:12:19Z", "40.728808", "-73.996106"},
 			},
@@ -111,7 +111,7 @@ func TestProcessor_Process(t *testing.T) {
 				{Method: "CheckWeather", Arguments: []interface{}{40.728808, -73.996106, dateParser("2020-02-11T14:12:19")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Ice"}, nil}},
 			},
 
-			expect: "A chilly day in New York",
+			expect: "A chilly morning in New York",
 		},
 		"sunny week": {
 			input: [][]string{
@@ -149,7 +149,12 @@ func TestProcessor_Process(t *testing.T) {
 			weathermanCalls: []mock.Call{
 				{Method: "CheckWeather", Arguments: []interface{}{40.728656, -73.998790, dateParser("2020-03-29T14:20:10")}, ReturnArguments: []interface{}{weatherman.Forecast{Conditions: "Rain"}, nil}},
 			},
-			expect: "A rainy day in New York",
+			expect: "A rainy morning in New York",
+		},
+		"short row": {
+			input: [][]string{
+				{"2020-03-30T14:12:19Z", "40.728808"},
+			},
 		},
 		"coordinates fail": {
 			input: [][]string{
@@ -187,10 +192,7 @@ func TestProcessor_Process(t *testing.T) {
 				weathermanDouble.On(call.Method, call.Arguments...).Return(call.ReturnArguments...)
 			}
 
-			p := &Processor{
-				locator:    locatorDouble,
-				weatherman: weathermanDouble,
-			}
+			p := New(locatorDouble, weathermanDouble)
 
 			got, errs := p.Process(test.input)
 			if len(errs) > 0 {
@@ -200,3 +202,22 @@ func TestProcessor_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessor_ProcessAlbum_progress(t *testing.T) {
+	l := &mockLocator{}
+	w := &mockWeatherman{}
+	l.On("Locate", 40.728808, -73.996106).Return(locator.Location{City: "New York", Country: "USA"}, nil)
+	l.On("Locate", 40.728656, -73.998790).Return(locator.Location{}, fmt.Errorf("quota exceeded"))
+	w.On("CheckWeather", 40.728808, -73.996106, dateParser("2020-03-30T14:12:19")).Return(weatherman.Forecast{Conditions: "Rain"}, nil)
+
+	var reported []error
+	p := New(l, w, WithProgress(func(err error) { reported = append(reported, err) }))
+	_, errs := p.ProcessAlbum([]Metadata{
+		{Latitude: 40.728808, Longitude: -73.996106, Date: dateParser("2020-03-30T14:12:19")},
+		{Latitude: 140, Longitude: -73.996106, Date: dateParser("2020-03-30T14:20:10")},
+		{Latitude: 40.728656, Longitude: -73.998790, Date: dateParser("2020-03-30T14:32:02")},
+	})
+	require.Len(t, errs, 2)
+	require.Len(t, reported, 3)
+	require.ElementsMatch(t, []error{nil, errs[0], errs[1]}, reported)
+}
@@ -0,0 +1,136 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/adrianos93/nomenclator/internal/astro"
+	"github.com/adrianos93/nomenclator/internal/locator"
+)
+
+// Sky is a custom type used to describe the night sky an album was taken under
+type Sky struct {
+	// Moon is the phase of the moon halfway through the album, e.g. "waxing gibbous", and Illumination the
+	// fraction of its disc lit by the sun
+	Moon         string  `json:"moon"`
+	Illumination float64 `json:"illumination"`
+	// MoonUp is the share of photos taken with the moon above the horizon
+	MoonUp float64 `json:"moon_up"`
+	// CloudCover is the average percentage of the sky covered by clouds, when the weather provider reports it
+	CloudCover *float64 `json:"cloud_cover,omitempty"`
+	// Facet is the night sky named in the title, either "full moon" or "starry", if any
+	Facet string `json:"facet,omitempty"`
+}
+
+const (
+	// fullMoon is the lowest illumination of a moon described as full
+	fullMoon = 0.9
+	// moonlit is the highest cloud cover in percent a full moon is still seen through
+	moonlit = 50
+	// starry is the highest cloud cover in percent stars are seen through, with a dim moon or none at all
+	starry = 20
+	// dimMoon is the highest illumination of a moon leaving the stars visible
+	dimMoon = 0.5
+)
+
+// WithNightSky enables describing the moon and the stars over albums taken at night,
+// e.g. "A night under a full moon in Positano" or "A starry night in Positano"
+func WithNightSky() ProcessorOptions {
+	return func(p *Processor) {
+		p.nightSky = true
+	}
+}
+
+// albumSky is a helper function used to describe the night sky of an album, or nil unless at least 80% of its
+// photos were taken at night
+func albumSky(album []locator.Location) *Sky {
+	if light, share := albumLight(album); light != astro.Night || share < placeCoverage {
+		return nil
+	}
+	minDate, maxDate := album[0].Date, album[0].Date
+	var up, clouds int
+	var cover float64
+	for _, photo := range album {
+		if photo.Date.Before(minDate) {
+			minDate = photo.Date
+		}
+		if photo.Date.After(maxDate) {
+			maxDate = photo.Date
+		}
+		if astro.Moon(photo.Date, photo.Latitude, photo.Longitude).Elevation > 0 {
+			up++
+		}
+		if !math.IsNaN(photo.CloudCover) {
+			cover += photo.CloudCover
+			clouds++
+		}
+	}
+	midpoint := minDate.Add(maxDate.Sub(minDate) / 2)
+	moon := astro.Moon(midpoint, album[0].Latitude, album[0].Longitude)
+	sky := &Sky{
+		Moon:         moon.Name,
+		Illumination: math.Round(moon.Illumination*100) / 100,
+		MoonUp:       float64(up) / float64(len(album)),
+	}
+	if clouds > 0 {
+		average := cover / float64(clouds)
+		sky.CloudCover = &average
+	}
+	sky.Facet = skyFacet(sky, moon.Illumination)
+	return sky
+}
+
+// skyFacet is a helper function used to name the night sky of an album. A full moon needs to be up for most photos
+// and seen through a mostly clear sky, while stars need a clear sky and a dim moon, or one below the horizon.
+// Nothing is named when the cloud cover is unknown.
+func skyFacet(sky *Sky, illumination float64) string {
+	if sky.CloudCover == nil {
+		return ""
+	}
+	switch {
+	case illumination >= fullMoon && sky.MoonUp >= 0.5 && *sky.CloudCover <= moonlit:
+		return "full moon"
+	case *sky.CloudCover <= starry && (illumination <= dimMoon || sky.MoonUp < 1-placeCoverage):
+		return "starry"
+	}
+	return ""
+}
+
+// skyFacets is a helper function used to name the night sky of albums taken within a part of the day.
+// The night sky replaces the weather, which describes the daytime.
+func skyFacets(sky *Sky, f facets) facets {
+	switch f.period {
+	case "morning", "afternoon", "evening", "night":
+	default:
+		return f
+	}
+	if sky == nil || sky.Facet == "" {
+		return f
+	}
+	f.sky, f.weather = sky.Facet, weather{}
+	if sky.Facet == "starry" {
+		f.weather = weather{adjective: "starry"}
+	}
+	return f
+}
+
+// skyReason is a helper function used to describe the rule deciding the night sky of an album
+func skyReason(sky *Sky, value string) string {
+	if sky == nil {
+		return fmt.Sprintf("fewer than %.0f%% of the photos were taken at night", placeCoverage*100)
+	}
+	reason := fmt.Sprintf("%s %.0f%% lit, above the horizon for %.0f%% of the photos", sky.Moon, sky.Illumination*100, sky.MoonUp*100)
+	if sky.CloudCover == nil {
+		return reason + ", with unknown cloud cover"
+	}
+	reason = fmt.Sprintf("%s, under %.0f%% cloud cover", reason, *sky.CloudCover)
+	switch value {
+	case "full moon":
+		return fmt.Sprintf("%s, at least %.0f%% lit, up for half the photos and seen through at most %d%% cloud cover",
+			reason, fullMoon*100, moonlit)
+	case "starry":
+		return fmt.Sprintf("%s, at most %d%% cloud cover with a moon at most %.0f%% lit or down",
+			reason, starry, dimMoon*100)
+	}
+	return reason + ", which is neither a full moon through a mostly clear sky nor a clear sky with a dim moon"
+}
@@ -0,0 +1,111 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/adrianos93/nomenclator/internal/i18n"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_ProcessAlbum_nightSky(t *testing.T) {
+	clear, overcast := 10.0, 90.0
+	lunarEclipse := []Metadata{
+		{Latitude: 40.627883, Longitude: 14.366858, Date: dateParser("2019-07-16T21:05:00")},
+		{Latitude: 40.627808, Longitude: 14.364933, Date: dateParser("2019-07-16T21:40:00")},
+		{Latitude: 40.628197, Longitude: 14.367075, Date: dateParser("2019-07-16T22:15:00")},
+	}
+	newMoon := []Metadata{
+		{Latitude: 40.627883, Longitude: 14.366858, Date: dateParser("2019-07-02T21:05:00")},
+		{Latitude: 40.627808, Longitude: 14.364933, Date: dateParser("2019-07-02T21:40:00")},
+		{Latitude: 40.628197, Longitude: 14.367075, Date: dateParser("2019-07-02T22:15:00")},
+	}
+	for name, test := range map[string]struct {
+		input      []Metadata
+		conditions string
+		cloudCover *float64
+		options    []ProcessorOptions
+
+		expect    string
+		expectSky *Sky
+	}{
+		"full moon": {
+			input:      lunarEclipse,
+			cloudCover: &clear,
+			options:    []ProcessorOptions{WithNightSky()},
+			expect:     "A night under a full moon in Positano",
+			expectSky:  &Sky{Moon: "full moon", Illumination: 1, MoonUp: 1, CloudCover: &clear, Facet: "full moon"},
+		},
+		"translated full moon": {
+			input:      lunarEclipse,
+			cloudCover: &clear,
+			options:    []ProcessorOptions{WithNightSky(), WithCatalog(i18n.Italian)},
+			expect:     "Una notte di luna piena a Positano",
+			expectSky:  &Sky{Moon: "full moon", Illumination: 1, MoonUp: 1, CloudCover: &clear, Facet: "full moon"},
+		},
+		"full moon behind the clouds": {
+			input:      lunarEclipse,
+			conditions: "Rain",
+			cloudCover: &overcast,
+			options:    []ProcessorOptions{WithNightSky()},
+			expect:     "A rainy night in Positano",
+			expectSky:  &Sky{Moon: "full moon", Illumination: 1, MoonUp: 1, CloudCover: &overcast},
+		},
+		"starry night": {
+			input:      newMoon,
+			cloudCover: &clear,
+			options:    []ProcessorOptions{WithNightSky()},
+			expect:     "A starry night in Positano",
+			expectSky:  &Sky{Moon: "new moon", Illumination: 0, MoonUp: 0, CloudCover: &clear, Facet: "starry"},
+		},
+		"starry night out": {
+			input:      newMoon,
+			cloudCover: &clear,
+			options:    []ProcessorOptions{WithNightSky(), WithLight(), WithCatalog(i18n.Spanish)},
+			expect:     "Una noche de fiesta estrellada en Positano",
+			expectSky:  &Sky{Moon: "new moon", Illumination: 0, MoonUp: 0, CloudCover: &clear, Facet: "starry"},
+		},
+		"unknown cloud cover": {
+			input:     newMoon,
+			options:   []ProcessorOptions{WithNightSky()},
+			expect:    "A sunny night in Positano",
+			expectSky: &Sky{Moon: "new moon", Illumination: 0, MoonUp: 0},
+		},
+		"night sky disabled": {
+			input:      lunarEclipse,
+			cloudCover: &clear,
+			expect:     "A sunny night in Positano",
+		},
+		"daylight": {
+			input: []Metadata{
+				{Latitude: 40.627883, Longitude: 14.366858, Date: dateParser("2019-07-16T09:05:00")},
+				{Latitude: 40.627808, Longitude: 14.364933, Date: dateParser("2019-07-16T10:40:00")},
+			},
+			cloudCover: &clear,
+			options:    []ProcessorOptions{WithNightSky()},
+			expect:     "A sunny morning in Positano",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			locatorDouble := &mockLocator{}
+			locatorDouble.Test(t)
+			locatorDouble.On("Locate", mock.Anything, mock.Anything).Return(positano, nil)
+
+			conditions := test.conditions
+			if conditions == "" {
+				conditions = "Clear"
+			}
+			weathermanDouble := &mockWeatherman{}
+			weathermanDouble.Test(t)
+			weathermanDouble.On("CheckWeather", mock.Anything, mock.Anything, mock.Anything).
+				Return(weatherman.Forecast{Conditions: conditions, CloudCover: test.cloudCover}, nil)
+
+			p := New(locatorDouble, weathermanDouble, test.options...)
+			got, errs := p.ProcessAlbum(test.input)
+			require.Empty(t, errs)
+			require.Equal(t, test.expect, got.Title)
+			require.Equal(t, test.expectSky, got.Sky)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package processor
+
+import (
+	"math"
+	"sort"
+
+	"github.com/adrianos93/nomenclator/internal/calendar"
+	"github.com/adrianos93/nomenclator/internal/geo"
+	"github.com/adrianos93/nomenclator/internal/locator"
+)
+
+// Suggestion is a custom type used to describe an alternative title, and how well it covers the album from 0 to 1
+type Suggestion struct {
+	Title string  `json:"title"`
+	Score float64 `json:"score"`
+}
+
+// itineraryStops is the largest number of places listed in an itinerary title
+const itineraryStops = 3
+
+// WithSuggestions enables returning up to n alternative titles, ranked by how well they cover the album
+func WithSuggestions(n int) ProcessorOptions {
+	return func(p *Processor) {
+		p.suggestions = n
+	}
+}
+
+// candidate is used to store a combination of facets a suggestion is composed of, and its score
+type candidate struct {
+	facets facets
+	score  float64
+}
+
+// suggest is a helper function used to compose alternative titles from different combinations of the album facets:
+// the full title, weather, period and place, the area around a landmark or terrain, event and place, the itinerary, and season
+// and country.
+// Each facet scores the share of photos it holds true for, e.g. the share of photos taken in the place,
+// and a title scores the product of the scores of its facets.
+func (p *Processor) suggest(album []locator.Location, f facets) []Suggestion {
+	weatherScore := p.weatherShare(album, f.weather)
+	placeScore := placeShare(album, f.place, f.placeLevel)
+	switch f.placeLevel {
+	case LandmarkPlace:
+		_, placeScore = p.albumLandmark(album)
+	case TerrainPlace:
+		_, placeScore = p.albumTerrain(album)
+	}
+	eventScore := eventShare(album, f.event)
+	seasonScore := seasonShare(album, f.season)
+
+	candidates := []candidate{
+		{facets: f, score: weatherScore * placeScore * eventScore * seasonScore},
+		{facets: facets{weather: f.weather, period: f.period, place: f.place, placeLevel: f.placeLevel}, score: weatherScore * placeScore},
+	}
+	if f.placeLevel == LandmarkPlace || f.placeLevel == TerrainPlace {
+		place, level := albumPlace(album, p.placeLevel)
+		candidates = append(candidates, candidate{
+			facets: facets{weather: f.weather, period: f.period, place: place, placeLevel: level},
+			score:  weatherScore * placeShare(album, place, level),
+		})
+	}
+	if f.event.holiday != "" {
+		candidates = append(candidates, candidate{
+			facets: facets{period: f.period, event: f.event, place: f.place, placeLevel: f.placeLevel},
+			score:  eventScore * placeScore,
+		})
+	}
+	if places, share := albumItinerary(album); len(places) > 1 {
+		candidates = append(candidates, candidate{
+			facets: facets{weather: f.weather, period: f.period, places: places},
+			score:  weatherScore * share,
+		})
+	}
+	if country, count := commonPlace(album, Country); f.season != "" && country != "" {
+		candidates = append(candidates, candidate{
+			facets: facets{period: f.period, season: f.season, place: country, placeLevel: Country},
+			score:  seasonScore * float64(count) / float64(len(album)),
+		})
+	}
+
+	suggestions := make([]Suggestion, 0, len(candidates))
+	seen := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		title := c.facets.title(p.catalog)
+		if seen[title] {
+			continue
+		}
+		seen[title] = true
+		suggestions = append(suggestions, Suggestion{Title: title, Score: math.Round(c.score*1000) / 1000})
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool { return suggestions[i].Score > suggestions[j].Score })
+	if len(suggestions) > p.suggestions {
+		suggestions = suggestions[:p.suggestions]
+	}
+	return suggestions
+}
+
+// weatherShare is a helper function used to return the share of the weather votes of an album a weather holds true for.
+// Changeable weather holds true for the whole album.
+func (p *Processor) weatherShare(album []locator.Location, w weather) float64 {
+	if w.adjective == "changeable" {
+		return 1
+	}
+	votes := weatherVotes(album, p.weatherRules, p.aggregation)
+	var total float64
+	for _, n := range votes {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	share := votes[w.adjective]
+	if w.then != "" {
+		share += votes[w.then]
+	}
+	return share / total
+}
+
+// placeShare is a helper function used to return the share of photos taken in a place
+func placeShare(album []locator.Location, place string, level PlaceLevel) float64 {
+	if len(album) == 0 {
+		return 0
+	}
+	var count int
+	for _, photo := range album {
+		if placeName(photo, level) == place {
+			count++
+		}
+	}
+	return float64(count) / float64(len(album))
+}
+
+// eventShare is a helper function used to return the share of photos taken on the holiday of an event.
+// Holiday weekends hold true for the whole album, as they are only named for short trips around the holiday.
+func eventShare(album []locator.Location, e event) float64 {
+	if e.holiday == "" || e.period == "weekend" {
+		return 1
+	}
+	if len(album) == 0 {
+		return 0
+	}
+	country := albumCountry(album)
+	var count int
+	for _, photo := range album {
+		if h, found := calendar.On(country, geo.LocalTime(photo.Date, photo.Longitude)); found && h.ID == e.holiday {
+			count++
+		}
+	}
+	return float64(count) / float64(len(album))
+}
+
+// seasonShare is a helper function used to return the share of photos taken in a season
+func seasonShare(album []locator.Location, season string) float64 {
+	if season == "" {
+		return 1
+	}
+	if len(album) == 0 {
+		return 0
+	}
+	var count int
+	for _, photo := range album {
+		if calendar.Season(geo.LocalTime(photo.Date, photo.Longitude), photo.Latitude) == season {
+			count++
+		}
+	}
+	return float64(count) / float64(len(album))
+}
+
+// albumItinerary is a helper function used to return the most visited localities of an album, in the order they were
+// first visited, along with the share of photos taken in them. The city stands in for photos without a locality.
+func albumItinerary(album []locator.Location) ([]string, float64) {
+	if len(album) == 0 {
+		return nil, 0
+	}
+	photos := make([]locator.Location, len(album))
+	copy(photos, album)
+	sort.SliceStable(photos, func(i, j int) bool { return photos[i].Date.Before(photos[j].Date) })
+
+	m := make(map[string]int, len(photos))
+	var order []string
+	for _, photo := range photos {
+		place := placeName(photo, Locality)
+		if place == "" {
+			place = photo.City
+		}
+		if place == "" {
+			continue
+		}
+		if m[place] == 0 {
+			order = append(order, place)
+		}
+		m[place]++
+	}
+	visited := make([]string, len(order))
+	copy(visited, order)
+	sort.SliceStable(visited, func(i, j int) bool { return m[visited[i]] > m[visited[j]] })
+	if len(visited) > itineraryStops {
+		visited = visited[:itineraryStops]
+	}
+	stops := make(map[string]bool, len(visited))
+	var count int
+	for _, place := range visited {
+		stops[place] = true
+		count += m[place]
+	}
+	places := make([]string, 0, len(visited))
+	for _, place := range order {
+		if stops[place] {
+			places = append(places, place)
+		}
+	}
+	return places, float64(count) / float64(len(album))
+}
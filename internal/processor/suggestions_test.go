@@ -0,0 +1,125 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/adrianos93/nomenclator/internal/i18n"
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_ProcessAlbum_suggestions(t *testing.T) {
+	coastTrip := []Metadata{
+		{Latitude: 40.851799, Longitude: 14.268120, Date: dateParser("2019-10-05T09:12:19")},
+		{Latitude: 40.852799, Longitude: 14.268120, Date: dateParser("2019-10-06T10:20:10")},
+		{Latitude: 40.634800, Longitude: 14.602680, Date: dateParser("2019-10-08T11:32:02")},
+		{Latitude: 40.628197, Longitude: 14.367075, Date: dateParser("2019-10-10T12:12:19")},
+		{Latitude: 40.627808, Longitude: 14.364933, Date: dateParser("2019-10-11T13:12:19")},
+	}
+	coastLocations := map[float64]locator.Location{
+		40.851799: naples,
+		40.852799: naples,
+		40.634800: amalfi,
+		40.628197: positano,
+		40.627808: positano,
+	}
+	for name, test := range map[string]struct {
+		input       []Metadata
+		locations   map[float64]locator.Location
+		conditions  map[float64]string
+		suggestions int
+		options     []ProcessorOptions
+
+		expect Album
+	}{
+		"suggestions disabled": {
+			input:     coastTrip,
+			locations: coastLocations,
+			expect:    Album{Title: "A sunny autumn week in Campania", Photos: 5},
+		},
+		"ranked suggestions": {
+			input:       coastTrip,
+			locations:   coastLocations,
+			conditions:  map[float64]string{40.634800: "Rain"},
+			suggestions: 3,
+			expect: Album{
+				Title: "A sunny autumn week in Campania",
+				Suggestions: []Suggestion{
+					{Title: "An autumn week in Italy", Score: 1},
+					{Title: "A sunny autumn week in Campania", Score: 0.8},
+					{Title: "A sunny week in Campania", Score: 0.8},
+				},
+				Photos: 5,
+			},
+		},
+		"itinerary": {
+			input:       coastTrip,
+			locations:   coastLocations,
+			conditions:  map[float64]string{40.634800: "Rain"},
+			suggestions: 10,
+			expect: Album{
+				Title: "A sunny autumn week in Campania",
+				Suggestions: []Suggestion{
+					{Title: "An autumn week in Italy", Score: 1},
+					{Title: "A sunny autumn week in Campania", Score: 0.8},
+					{Title: "A sunny week in Campania", Score: 0.8},
+					{Title: "A sunny week through Naples, Amalfi and Positano", Score: 0.8},
+				},
+				Photos: 5,
+			},
+		},
+		"event": {
+			input: []Metadata{
+				{Latitude: 40.628197, Longitude: 14.367075, Date: dateParser("2019-10-31T18:26:12")},
+				{Latitude: 40.627808, Longitude: 14.364933, Date: dateParser("2019-10-31T18:35:23")},
+				{Latitude: 40.627883, Longitude: 14.366858, Date: dateParser("2019-11-01T09:52:59")},
+			},
+			locations:   map[float64]locator.Location{40.628197: positano, 40.627808: positano, 40.627883: amalfi},
+			suggestions: 5,
+			expect: Album{
+				Title: "A sunny Halloween night in Salerno",
+				Suggestions: []Suggestion{
+					{Title: "A sunny day in Salerno", Score: 1},
+					{Title: "A sunny day through Positano and Amalfi", Score: 1},
+					{Title: "A sunny Halloween night in Salerno", Score: 0.667},
+					{Title: "A Halloween night in Salerno", Score: 0.667},
+				},
+				Photos: 3,
+			},
+		},
+		"translated": {
+			input:       coastTrip,
+			locations:   coastLocations,
+			suggestions: 1,
+			options:     []ProcessorOptions{WithCatalog(i18n.Italian)},
+			expect: Album{
+				Title:       "Una settimana autunnale soleggiata in Campania",
+				Suggestions: []Suggestion{{Title: "Una settimana autunnale soleggiata in Campania", Score: 1}},
+				Photos:      5,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			locatorDouble := &mockLocator{}
+			locatorDouble.Test(t)
+			for latitude, location := range test.locations {
+				locatorDouble.On("Locate", latitude, mock.Anything).Return(location, nil)
+			}
+
+			weathermanDouble := &mockWeatherman{}
+			weathermanDouble.Test(t)
+			for latitude, conditions := range test.conditions {
+				weathermanDouble.On("CheckWeather", latitude, mock.Anything, mock.Anything).Return(weatherman.Forecast{Conditions: conditions}, nil)
+			}
+			weathermanDouble.On("CheckWeather", mock.Anything, mock.Anything, mock.Anything).Return(weatherman.Forecast{Conditions: "Clear"}, nil)
+
+			options := append([]ProcessorOptions{WithCalendar(), WithSuggestions(test.suggestions)}, test.options...)
+			p := New(locatorDouble, weathermanDouble, options...)
+			got, errs := p.ProcessAlbum(test.input)
+			require.Empty(t, errs)
+			require.Equal(t, test.expect, got)
+		})
+	}
+}
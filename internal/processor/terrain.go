@@ -0,0 +1,128 @@
+package processor
+
+import (
+	"math"
+
+	"github.com/adrianos93/nomenclator/internal/locator"
+)
+
+// Elevation is an interface for looking up the elevation of coordinates in metres, e.g. the elevation package
+type Elevation interface {
+	Elevation(latitude, longitude float64) (float64, error)
+}
+
+// Coastline is an interface for telling how far coordinates are from the nearest coastline in kilometres,
+// along with its name, e.g. the coast package
+type Coastline interface {
+	Distance(latitude, longitude float64) (float64, string)
+}
+
+// TerrainPlace is the level of places that are kinds of terrain, either mountains or coast, rather than
+// administrative areas
+const TerrainPlace PlaceLevel = "terrain"
+
+// Terrain is a custom type used to configure the thresholds albums are described as taken in the mountains or on the coast
+type Terrain struct {
+	// Mountains is the lowest elevation in metres of photos taken in the mountains
+	Mountains float64
+	// Coast is the farthest distance in kilometres from the coastline of photos taken on the coast
+	Coast float64
+}
+
+// DefaultTerrain is the Terrain configuration used unless overridden
+var DefaultTerrain = Terrain{
+	Mountains: 1000,
+	Coast:     2,
+}
+
+// Altitude is a custom type used to describe the lowest and highest elevation photos were taken at, in metres
+type Altitude struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// WithElevation enables looking up the elevation photos were taken at, to describe albums taken in the mountains,
+// e.g. "A snowy week in the mountains", and report their altitude range. Along with WithLandmarks, the mountain range
+// is named instead, e.g. "A snowy week in the Alps"
+func WithElevation(elevation Elevation) ProcessorOptions {
+	return func(p *Processor) {
+		p.elevation = elevation
+	}
+}
+
+// WithCoastline enables describing albums taken along the coast, e.g. "A sunny day on the coast"
+func WithCoastline(coastline Coastline) ProcessorOptions {
+	return func(p *Processor) {
+		p.coastline = coastline
+	}
+}
+
+// WithTerrain replaces the default Terrain thresholds
+func WithTerrain(terrain Terrain) ProcessorOptions {
+	return func(p *Processor) {
+		p.terrain = terrain
+	}
+}
+
+// photoElevation is a helper function used to return the elevation a photo was taken at, or NaN when unknown,
+// e.g. when no elevation data covers it
+func (p *Processor) photoElevation(latitude, longitude float64) float64 {
+	if p.elevation == nil {
+		return math.NaN()
+	}
+	elevation, err := p.elevation.Elevation(latitude, longitude)
+	if err != nil {
+		return math.NaN()
+	}
+	return elevation
+}
+
+// albumAltitude is a helper function used to return the range of elevations the photos of an album were taken at,
+// or nil when none is known
+func albumAltitude(album []locator.Location) *Altitude {
+	var altitude *Altitude
+	for _, photo := range album {
+		if math.IsNaN(photo.Elevation) {
+			continue
+		}
+		if altitude == nil {
+			altitude = &Altitude{Min: photo.Elevation, Max: photo.Elevation}
+			continue
+		}
+		altitude.Min = math.Min(altitude.Min, photo.Elevation)
+		altitude.Max = math.Max(altitude.Max, photo.Elevation)
+	}
+	return altitude
+}
+
+// albumTerrain is a helper function used to return the terrain most photos of an album were taken in, either
+// mountains or coast, along with the share of photos taken in it. Mountains take precedence over the coast.
+func (p *Processor) albumTerrain(album []locator.Location) (string, float64) {
+	if len(album) == 0 {
+		return "", 0
+	}
+	var mountains, coast int
+	for _, photo := range album {
+		if photo.Elevation >= p.terrain.Mountains {
+			mountains++
+		}
+		if p.coastline != nil {
+			if distance, _ := p.coastline.Distance(photo.Latitude, photo.Longitude); distance <= p.terrain.Coast {
+				coast++
+			}
+		}
+	}
+	switch {
+	case float64(mountains) >= placeCoverage*float64(len(album)):
+		return "mountains", float64(mountains) / float64(len(album))
+	case float64(coast) >= placeCoverage*float64(len(album)):
+		return "coast", float64(coast) / float64(len(album))
+	}
+	return "", 0
+}
+
+// broad is a helper function used to tell whether a place level is broader than a town, so that the terrain
+// describes an album better, e.g. "on the coast" rather than "in California"
+func broad(level PlaceLevel) bool {
+	return level != Neighbourhood && level != Locality && level != LandmarkPlace
+}
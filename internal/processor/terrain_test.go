@@ -0,0 +1,140 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/adrianos93/nomenclator/internal/coast"
+	"github.com/adrianos93/nomenclator/internal/elevation"
+	"github.com/adrianos93/nomenclator/internal/landmark"
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_ProcessAlbum_terrain(t *testing.T) {
+	alps := landmark.New([]landmark.Landmark{
+		{Name: "Alps", Kind: "mountains", Preposition: "in the", Latitude: 45.9, Longitude: 7.4, Radius: 220},
+	})
+	coastline := coast.New([]coast.Coastline{
+		{Name: "Santa Monica Bay", Points: [][2]float64{{34.0350, -118.6800}, {34.0100, -118.5200}, {33.9850, -118.4800}, {33.9700, -118.4550}}},
+	})
+	valais := func(locality string) locator.Location {
+		return locator.Location{City: "Valais", Locality: locality, County: "Visp", Region: "Valais", Country: "Switzerland", Continent: "Europe"}
+	}
+	california := func(locality string) locator.Location {
+		return locator.Location{City: "California", Locality: locality, County: "Los Angeles County", Region: "California", Country: "United States", Continent: "North America"}
+	}
+	skiWeek := []Metadata{
+		{Latitude: 46.020700, Longitude: 7.749100, Date: dateParser("2019-02-04T10:00:00")},
+		{Latitude: 46.001500, Longitude: 7.757700, Date: dateParser("2019-02-05T11:00:00")},
+		{Latitude: 45.983300, Longitude: 7.730600, Date: dateParser("2019-02-06T12:00:00")},
+		{Latitude: 46.108000, Longitude: 7.930000, Date: dateParser("2019-02-08T13:00:00")},
+	}
+	skiWeekLocations := map[float64]locator.Location{
+		46.020700: valais("Zermatt"),
+		46.001500: valais("Zermatt"),
+		45.983300: valais("Zermatt"),
+		46.108000: valais("Saas-Fee"),
+	}
+	skiWeekElevations := elevation.Fake{46.020700: 1608, 46.001500: 2582, 45.983300: 3883, 46.108000: 1800}
+	beachDay := []Metadata{
+		{Latitude: 34.008600, Longitude: -118.498600, Date: dateParser("2019-07-06T17:00:00")},
+		{Latitude: 33.985000, Longitude: -118.472500, Date: dateParser("2019-07-06T19:00:00")},
+		{Latitude: 33.990000, Longitude: -118.475000, Date: dateParser("2019-07-06T21:00:00")},
+	}
+	beachDayLocations := map[float64]locator.Location{
+		34.008600: california("Santa Monica"),
+		33.985000: california("Venice"),
+		33.990000: california("Venice"),
+	}
+	for name, test := range map[string]struct {
+		input      []Metadata
+		locations  map[float64]locator.Location
+		conditions string
+		options    []ProcessorOptions
+
+		expect         string
+		expectAltitude *Altitude
+	}{
+		"mountain range": {
+			input:          skiWeek,
+			locations:      skiWeekLocations,
+			conditions:     "Snow",
+			options:        []ProcessorOptions{WithElevation(skiWeekElevations), WithLandmarks(alps)},
+			expect:         "A snowy week in the Alps",
+			expectAltitude: &Altitude{Min: 1608, Max: 3883},
+		},
+		"mountain range without elevation": {
+			input:      skiWeek,
+			locations:  skiWeekLocations,
+			conditions: "Snow",
+			options:    []ProcessorOptions{WithLandmarks(alps)},
+			expect:     "A snowy week in Visp",
+		},
+		"mountains": {
+			input:          skiWeek,
+			locations:      skiWeekLocations,
+			conditions:     "Snow",
+			options:        []ProcessorOptions{WithElevation(skiWeekElevations)},
+			expect:         "A snowy week in the mountains",
+			expectAltitude: &Altitude{Min: 1608, Max: 3883},
+		},
+		"below the mountains": {
+			input:          skiWeek,
+			locations:      skiWeekLocations,
+			conditions:     "Snow",
+			options:        []ProcessorOptions{WithElevation(skiWeekElevations), WithTerrain(Terrain{Mountains: 2000, Coast: 2})},
+			expect:         "A snowy week in Visp",
+			expectAltitude: &Altitude{Min: 1608, Max: 3883},
+		},
+		"missing elevations": {
+			input:          skiWeek,
+			locations:      skiWeekLocations,
+			conditions:     "Snow",
+			options:        []ProcessorOptions{WithElevation(elevation.Fake{46.020700: 1608})},
+			expect:         "A snowy week in Visp",
+			expectAltitude: &Altitude{Min: 1608, Max: 1608},
+		},
+		"coast": {
+			input:     beachDay,
+			locations: beachDayLocations,
+			options:   []ProcessorOptions{WithCoastline(coastline)},
+			expect:    "A sunny day on the coast",
+		},
+		"coast within a town": {
+			input:     beachDay[1:],
+			locations: beachDayLocations,
+			options:   []ProcessorOptions{WithCoastline(coastline)},
+			expect:    "A sunny day in Venice",
+		},
+		"forced place level": {
+			input:     beachDay,
+			locations: beachDayLocations,
+			options:   []ProcessorOptions{WithCoastline(coastline), WithPlaceLevel(Region)},
+			expect:    "A sunny day in California",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			locatorDouble := &mockLocator{}
+			locatorDouble.Test(t)
+			for latitude, location := range test.locations {
+				locatorDouble.On("Locate", latitude, mock.Anything).Return(location, nil)
+			}
+
+			conditions := test.conditions
+			if conditions == "" {
+				conditions = "Clear"
+			}
+			weathermanDouble := &mockWeatherman{}
+			weathermanDouble.Test(t)
+			weathermanDouble.On("CheckWeather", mock.Anything, mock.Anything, mock.Anything).Return(weatherman.Forecast{Conditions: conditions}, nil)
+
+			p := New(locatorDouble, weathermanDouble, test.options...)
+			got, errs := p.ProcessAlbum(test.input)
+			require.Empty(t, errs)
+			require.Equal(t, test.expect, got.Title)
+			require.Equal(t, test.expectAltitude, got.Altitude)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package processor
+
+import "github.com/adrianos93/nomenclator/internal/i18n"
+
+// facets is used to store the features of an album a title is composed of
+type facets struct {
+	weather    weather
+	period     string
+	event      event
+	season     string
+	place      string
+	placeLevel PlaceLevel
+	// preposition introduces a landmark place in English, e.g. "on the"
+	preposition string
+	// light is the natural light of the album, e.g. golden hour
+	light string
+	// sky is the night sky of the album, e.g. full moon
+	sky string
+	// travel is the travel mode of the album, replacing the period
+	travel string
+	// places lists the stops of an itinerary, replacing the place
+	places []string
+}
+
+// weather is used to describe the weather of an album, an adjective possibly changing into another one,
+// or only prevailing over most of the album
+type weather struct {
+	adjective, then string
+	mostly          bool
+}
+
+// event is used to describe the holiday an album was taken on, and whether it covers its weekend or its night
+type event struct {
+	holiday string
+	name    string
+	period  string
+}
+
+// WithCatalog sets the language titles are composed in
+func WithCatalog(catalog *i18n.Catalog) ProcessorOptions {
+	return func(p *Processor) {
+		p.catalog = catalog
+	}
+}
+
+// title is used to compose the album title from its facets, in the language of catalog
+func (f facets) title(catalog *i18n.Catalog) string {
+	return catalog.Title(i18n.Title{
+		Weather:       f.weather.adjective,
+		Then:          f.weather.then,
+		Mostly:        f.weather.mostly,
+		Period:        f.period,
+		Holiday:       f.event.holiday,
+		HolidayName:   f.event.name,
+		HolidayPeriod: f.event.period,
+		Season:        f.season,
+		Place:         f.place,
+		PlaceLevel:    string(f.placeLevel),
+		Preposition:   f.preposition,
+		Places:        f.places,
+		Travel:        f.travel,
+		Light:         f.light,
+		Sky:           f.sky,
+	})
+}
@@ -0,0 +1,69 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/adrianos93/nomenclator/internal/i18n"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_facets_title(t *testing.T) {
+	for name, test := range map[string]struct {
+		input   facets
+		catalog *i18n.Catalog
+
+		expect string
+	}{
+		"weather, period and place": {
+			input:  facets{weather: weather{adjective: "rainy"}, period: "weekend", place: "New York"},
+			expect: "A rainy weekend in New York",
+		},
+		"event replaces the period": {
+			input:  facets{weather: weather{adjective: "snowy"}, period: "day", event: event{holiday: "christmas", name: "Christmas"}, season: "winter", place: "New York"},
+			expect: "A snowy Christmas in New York",
+		},
+		"season precedes the period": {
+			input:  facets{weather: weather{adjective: "sunny"}, period: "week", season: "autumn", place: "Positano"},
+			expect: "A sunny autumn week in Positano",
+		},
+		"article agrees with the first word": {
+			input:  facets{period: "week", season: "autumn", place: "Positano"},
+			expect: "An autumn week in Positano",
+		},
+		"article agrees with the event": {
+			input:  facets{period: "weekend", event: event{holiday: "easter", name: "Easter", period: "weekend"}, place: "New York"},
+			expect: "An Easter weekend in New York",
+		},
+		"weather change": {
+			input:  facets{weather: weather{adjective: "rainy", then: "sunny"}, period: "weekend", place: "New York"},
+			expect: "A rainy then sunny weekend in New York",
+		},
+		"prevailing weather": {
+			input:  facets{weather: weather{adjective: "sunny", mostly: true}, period: "week", place: "New York"},
+			expect: "A mostly sunny week in New York",
+		},
+		"italian": {
+			input:   facets{weather: weather{adjective: "rainy"}, period: "morning", place: "Positano", placeLevel: Locality},
+			catalog: i18n.Italian,
+			expect:  "Una mattina piovosa a Positano",
+		},
+		"german": {
+			input:   facets{weather: weather{adjective: "sunny"}, period: "weekend", event: event{holiday: "easter", name: "Easter", period: "weekend"}, place: "New York"},
+			catalog: i18n.German,
+			expect:  "Ein sonniges Osterwochenende in New York",
+		},
+		"spanish": {
+			input:   facets{weather: weather{adjective: "rainy"}, period: "few days", place: "Madrid"},
+			catalog: i18n.Spanish,
+			expect:  "Unos días lluviosos en Madrid",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			catalog := test.catalog
+			if catalog == nil {
+				catalog = i18n.English
+			}
+			require.Equal(t, test.expect, test.input.title(catalog))
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/geo"
+	"github.com/adrianos93/nomenclator/internal/locator"
+)
+
+// TravelMode is the way an album moved through space, e.g. a road trip
+type TravelMode string
+
+const (
+	WalkingTour TravelMode = "walking tour"
+	RoadTrip    TravelMode = "road trip"
+	Hike        TravelMode = "hike"
+	BoatTrip    TravelMode = "boat trip"
+	Flights     TravelMode = "multi-flight journey"
+)
+
+// TravelModes is a custom type used to configure the thresholds albums are classified into travel modes with
+type TravelModes struct {
+	// WalkingSpeed is the fastest speed between shots, in km/h, of albums taken on foot
+	WalkingSpeed float64
+	// NoiseTime and NoiseDistance, in kilometres, are the noise floor of legs between shots: shorter legs, e.g. between
+	// burst shots whose GPS positions jitter, count towards the distance but not towards the speeds
+	NoiseTime     time.Duration
+	NoiseDistance float64
+	// WalkingDistance is the shortest track of a walking tour, and HikeDistance of a hike, in kilometres
+	WalkingDistance float64
+	HikeDistance    float64
+	// RoadTripDistance is the shortest track of a road trip, in kilometres
+	RoadTripDistance float64
+	// Water is the share of time spent on water from which an album is a boat trip
+	Water float64
+	// FlightSpeed is the slowest speed between shots, in km/h, and FlightDistance the shortest distance, in kilometres,
+	// of a leg taken by plane. Albums with at least Flights of them are multi-flight journeys.
+	FlightSpeed    float64
+	FlightDistance float64
+	Flights        int
+}
+
+// DefaultTravelModes is the TravelModes configuration used by WithTravel
+var DefaultTravelModes = TravelModes{
+	WalkingSpeed:     7,
+	NoiseTime:        30 * time.Second,
+	NoiseDistance:    0.05,
+	WalkingDistance:  1,
+	HikeDistance:     5,
+	RoadTripDistance: 100,
+	Water:            0.5,
+	FlightSpeed:      250,
+	FlightDistance:   150,
+	Flights:          2,
+}
+
+// Track is a custom type used to describe how the photos of an album moved through space, in the order they were taken
+type Track struct {
+	// Distance is the length of the track in kilometres
+	Distance float64 `json:"distance"`
+	// MaxSpeed, MedianSpeed and Speed are the fastest, median and 90th percentile speeds between shots, in km/h,
+	// over the legs above the noise floor. Albums are classified by Speed, so that a single bad leg does not decide their mode.
+	MaxSpeed    float64 `json:"max_speed"`
+	MedianSpeed float64 `json:"median_speed"`
+	Speed       float64 `json:"speed"`
+	// Straightness is the distance between the first and last photo over the length of the track, from 0 for
+	// a loop to 1 for a straight line
+	Straightness float64 `json:"straightness"`
+	// Water is the share of time spent on water
+	Water float64 `json:"water"`
+	// Flights is the number of legs taken by plane
+	Flights int `json:"flights"`
+}
+
+// Travel is a custom type used to describe the travel mode of an album, empty when none applies, and its track
+type Travel struct {
+	Mode TravelMode `json:"mode,omitempty"`
+	Track
+}
+
+// WithTravelModes enables naming albums after the way they moved through space, e.g. "A rainy road trip through
+// Campania", using the provided thresholds
+func WithTravelModes(modes TravelModes) ProcessorOptions {
+	return func(p *Processor) {
+		p.travel = &modes
+	}
+}
+
+// WithTravel enables naming albums after the way they moved through space, using the default thresholds
+func WithTravel() ProcessorOptions {
+	return WithTravelModes(DefaultTravelModes)
+}
+
+// albumTrack is a helper function used to compute the track of an album from the coordinates of its photos,
+// in the order they were taken. Legs between photos taken at the same instant, or below the noise floor, count towards the distance only.
+func albumTrack(album []locator.Location, modes TravelModes) Track {
+	if len(album) == 0 {
+		return Track{}
+	}
+	photos := make([]locator.Location, len(album))
+	copy(photos, album)
+	sort.SliceStable(photos, func(i, j int) bool { return photos[i].Date.Before(photos[j].Date) })
+
+	var track Track
+	var speeds []float64
+	var total, water time.Duration
+	for i := 1; i < len(photos); i++ {
+		from, to := photos[i-1], photos[i]
+		distance := geo.Distance(from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+		track.Distance += distance
+		elapsed := to.Date.Sub(from.Date)
+		if elapsed <= 0 {
+			continue
+		}
+		total += elapsed
+		if onWater(from) && onWater(to) {
+			water += elapsed
+		}
+		if elapsed < modes.NoiseTime || distance < modes.NoiseDistance {
+			continue
+		}
+		speed := distance / elapsed.Hours()
+		speeds = append(speeds, speed)
+		if speed >= modes.FlightSpeed && distance >= modes.FlightDistance {
+			track.Flights++
+		}
+	}
+	if len(speeds) > 0 {
+		sort.Float64s(speeds)
+		track.MaxSpeed = speeds[len(speeds)-1]
+		track.MedianSpeed = speeds[len(speeds)/2]
+		track.Speed = speeds[int(math.Ceil(0.9*float64(len(speeds))))-1]
+	}
+	if track.Distance > 0 {
+		first, last := photos[0], photos[len(photos)-1]
+		track.Straightness = geo.Distance(first.Latitude, first.Longitude, last.Latitude, last.Longitude) / track.Distance
+	}
+	switch {
+	case total > 0:
+		track.Water = water.Hours() / total.Hours()
+	default:
+		var count int
+		for _, photo := range photos {
+			if onWater(photo) {
+				count++
+			}
+		}
+		track.Water = float64(count) / float64(len(photos))
+	}
+	return track
+}
+
+// albumTravel is a helper function used to classify the track of an album into a travel mode.
+// Albums taken on foot are walking tours when most of their photos were taken in urban areas, and hikes otherwise.
+func albumTravel(album []locator.Location, modes TravelModes) Travel {
+	track := albumTrack(album, modes)
+	travel := Travel{Track: track}
+	switch {
+	case len(album) < 2:
+	case track.Flights >= modes.Flights:
+		travel.Mode = Flights
+	case track.Water >= modes.Water && track.Distance > 0:
+		travel.Mode = BoatTrip
+	case track.Distance >= modes.RoadTripDistance && track.Speed > modes.WalkingSpeed:
+		travel.Mode = RoadTrip
+	case track.Speed > modes.WalkingSpeed:
+	case urban(album) && track.Distance >= modes.WalkingDistance:
+		travel.Mode = WalkingTour
+	case !urban(album) && track.Distance >= modes.HikeDistance:
+		travel.Mode = Hike
+	}
+	return travel
+}
+
+// travelReason is a helper function used to describe the rule deciding the travel mode of an album
+func travelReason(travel Travel, modes TravelModes) string {
+	stats := fmt.Sprintf("the photos cover %.1f km, mostly at up to %.1f km/h", travel.Distance, travel.Speed)
+	switch travel.Mode {
+	case Flights:
+		return fmt.Sprintf("%s, with %d legs faster than %.0f km/h over at least %.0f km", stats, travel.Flights, modes.FlightSpeed, modes.FlightDistance)
+	case BoatTrip:
+		return fmt.Sprintf("%s, %.0f%% of the time on water", stats, travel.Water*100)
+	case RoadTrip:
+		return fmt.Sprintf("%s, over at least %.0f km faster than walking", stats, modes.RoadTripDistance)
+	case WalkingTour:
+		return fmt.Sprintf("%s, at walking pace in urban areas", stats)
+	case Hike:
+		return fmt.Sprintf("%s, at walking pace over at least %.0f km outside urban areas", stats, modes.HikeDistance)
+	}
+	return stats + ", matching no travel mode"
+}
+
+// onWater is a helper function used to tell whether a photo was taken on water
+func onWater(photo locator.Location) bool {
+	return photo.Type == "ocean" || photo.Type == "marinearea"
+}
+
+// urban is a helper function used to tell whether most photos of an album were taken in urban areas,
+// where the geolocation API knows the neighbourhood, street or venue
+func urban(album []locator.Location) bool {
+	var count int
+	for _, photo := range album {
+		switch {
+		case photo.Neighbourhood != "", photo.Type == "street", photo.Type == "address", photo.Type == "venue":
+			count++
+		}
+	}
+	return count*2 > len(album)
+}
@@ -0,0 +1,239 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/adrianos93/nomenclator/internal/i18n"
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_albumTrack(t *testing.T) {
+	for name, test := range map[string]struct {
+		album []locator.Location
+
+		expect Track
+	}{
+		"empty album": {},
+		"single photo": {
+			album:  []locator.Location{{Latitude: 40.851799, Longitude: 14.268120, Date: dateParser("2019-10-05T09:12:19")}},
+			expect: Track{},
+		},
+		"out of order photos": {
+			album: []locator.Location{
+				{Latitude: 40.634303, Longitude: 14.602580, Date: dateParser("2019-10-05T11:00:00")},
+				{Latitude: 40.627883, Longitude: 14.366858, Date: dateParser("2019-10-05T09:00:00")},
+			},
+			expect: Track{Distance: 19.9, MaxSpeed: 9.95, MedianSpeed: 9.95, Speed: 9.95, Straightness: 1},
+		},
+		"loop": {
+			album: []locator.Location{
+				{Latitude: 40.627883, Longitude: 14.366858, Date: dateParser("2019-10-05T09:00:00")},
+				{Latitude: 40.634303, Longitude: 14.602580, Date: dateParser("2019-10-05T10:00:00")},
+				{Latitude: 40.627883, Longitude: 14.366858, Date: dateParser("2019-10-05T12:00:00")},
+			},
+			expect: Track{Distance: 39.8, MaxSpeed: 19.9, MedianSpeed: 19.9, Speed: 19.9},
+		},
+		"on water": {
+			album: []locator.Location{
+				{Latitude: 40.553200, Longitude: 14.222200, Date: dateParser("2019-10-05T09:00:00"), Type: "marinearea"},
+				{Latitude: 40.590000, Longitude: 14.300000, Date: dateParser("2019-10-05T09:30:00"), Type: "marinearea"},
+				{Latitude: 40.626300, Longitude: 14.375700, Date: dateParser("2019-10-05T10:30:00"), Type: "street"},
+			},
+			expect: Track{Distance: 15.3, MaxSpeed: 15.5, MedianSpeed: 15.5, Speed: 15.5, Straightness: 1, Water: 1.0 / 3},
+		},
+		"jittery burst below the noise floor": {
+			album: []locator.Location{
+				{Latitude: 40.733600, Longitude: -74.002700, Date: dateParser("2019-10-05T14:00:00")},
+				{Latitude: 40.730800, Longitude: -73.997300, Date: dateParser("2019-10-05T14:30:00")},
+				{Latitude: 40.731160, Longitude: -73.997300, Date: dateParser("2019-10-05T14:30:05")},
+				{Latitude: 40.730800, Longitude: -73.997300, Date: dateParser("2019-10-05T14:30:10")},
+				{Latitude: 40.735900, Longitude: -73.991100, Date: dateParser("2019-10-05T15:00:00")},
+			},
+			expect: Track{Distance: 1.41, MaxSpeed: 1.53, MedianSpeed: 1.53, Speed: 1.53, Straightness: 0.72},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got := albumTrack(test.album, DefaultTravelModes)
+			require.InDelta(t, test.expect.Distance, got.Distance, test.expect.Distance*0.01+0.01)
+			require.InDelta(t, test.expect.MaxSpeed, got.MaxSpeed, test.expect.MaxSpeed*0.05+0.01)
+			require.InDelta(t, test.expect.MedianSpeed, got.MedianSpeed, test.expect.MedianSpeed*0.05+0.01)
+			require.InDelta(t, test.expect.Speed, got.Speed, test.expect.Speed*0.05+0.01)
+			require.InDelta(t, test.expect.Straightness, got.Straightness, 0.01)
+			require.InDelta(t, test.expect.Water, got.Water, 0.01)
+			require.Equal(t, test.expect.Flights, got.Flights)
+		})
+	}
+}
+
+func TestProcessor_ProcessAlbum_travel(t *testing.T) {
+	campania := func(locality string) locator.Location {
+		return locator.Location{City: "Campania", Locality: locality, Region: "Campania", Country: "Italy", Continent: "Europe"}
+	}
+	unitedStates := func(region string) locator.Location {
+		return locator.Location{City: region, Region: region, Country: "United States", Continent: "North America"}
+	}
+	roadTrip := []Metadata{
+		{Latitude: 40.851799, Longitude: 14.268120, Date: dateParser("2019-10-05T07:00:00")},
+		{Latitude: 40.682400, Longitude: 14.768100, Date: dateParser("2019-10-05T09:00:00")},
+		{Latitude: 40.914600, Longitude: 14.790600, Date: dateParser("2019-10-05T11:00:00")},
+		{Latitude: 41.129800, Longitude: 14.782600, Date: dateParser("2019-10-05T13:00:00")},
+		{Latitude: 41.072300, Longitude: 14.331100, Date: dateParser("2019-10-05T15:00:00")},
+	}
+	roadTripLocations := map[float64]locator.Location{
+		40.851799: campania("Naples"),
+		40.682400: campania("Salerno"),
+		40.914600: campania("Avellino"),
+		41.129800: campania("Benevento"),
+		41.072300: campania("Caserta"),
+	}
+	for name, test := range map[string]struct {
+		input      []Metadata
+		locations  map[float64]locator.Location
+		conditions string
+		options    []ProcessorOptions
+
+		expect     string
+		expectMode TravelMode
+	}{
+		"road trip": {
+			input:      roadTrip,
+			locations:  roadTripLocations,
+			conditions: "Rain",
+			options:    []ProcessorOptions{WithTravel()},
+			expect:     "A rainy road trip through Campania",
+			expectMode: RoadTrip,
+		},
+		"travel disabled": {
+			input:      roadTrip,
+			locations:  roadTripLocations,
+			conditions: "Rain",
+			expect:     "A rainy day in Campania",
+		},
+		"walking tour": {
+			input: []Metadata{
+				{Latitude: 40.733600, Longitude: -74.002700, Date: dateParser("2019-10-05T14:00:00")},
+				{Latitude: 40.730800, Longitude: -73.997300, Date: dateParser("2019-10-05T14:30:00")},
+				{Latitude: 40.735900, Longitude: -73.991100, Date: dateParser("2019-10-05T15:00:00")},
+				{Latitude: 40.729500, Longitude: -73.996500, Date: dateParser("2019-10-05T15:30:00")},
+			},
+			locations: map[float64]locator.Location{
+				40.733600: greenwichVillage,
+				40.730800: greenwichVillage,
+				40.735900: greenwichVillage,
+				40.729500: greenwichVillage,
+			},
+			options:    []ProcessorOptions{WithTravel()},
+			expect:     "A sunny walking tour through Greenwich Village",
+			expectMode: WalkingTour,
+		},
+		"walking tour with a jittery burst": {
+			input: []Metadata{
+				{Latitude: 40.733600, Longitude: -74.002700, Date: dateParser("2019-10-05T14:00:00")},
+				{Latitude: 40.730800, Longitude: -73.997300, Date: dateParser("2019-10-05T14:30:00")},
+				{Latitude: 40.735900, Longitude: -73.991100, Date: dateParser("2019-10-05T15:00:00")},
+				{Latitude: 40.736260, Longitude: -73.991100, Date: dateParser("2019-10-05T15:00:04")},
+				{Latitude: 40.735900, Longitude: -73.991500, Date: dateParser("2019-10-05T15:00:08")},
+				{Latitude: 40.729500, Longitude: -73.996500, Date: dateParser("2019-10-05T15:30:00")},
+			},
+			locations: map[float64]locator.Location{
+				40.733600: greenwichVillage,
+				40.730800: greenwichVillage,
+				40.735900: greenwichVillage,
+				40.736260: greenwichVillage,
+				40.729500: greenwichVillage,
+			},
+			options:    []ProcessorOptions{WithTravel()},
+			expect:     "A sunny walking tour through Greenwich Village",
+			expectMode: WalkingTour,
+		},
+		"hike": {
+			input: []Metadata{
+				{Latitude: 40.637000, Longitude: 14.540000, Date: dateParser("2019-10-05T08:00:00")},
+				{Latitude: 40.630100, Longitude: 14.532200, Date: dateParser("2019-10-05T09:00:00")},
+				{Latitude: 40.629000, Longitude: 14.501000, Date: dateParser("2019-10-05T10:30:00")},
+				{Latitude: 40.633000, Longitude: 14.498000, Date: dateParser("2019-10-05T11:30:00")},
+				{Latitude: 40.628100, Longitude: 14.484900, Date: dateParser("2019-10-05T12:30:00")},
+			},
+			locations: map[float64]locator.Location{
+				40.637000: positano,
+				40.630100: positano,
+				40.629000: positano,
+				40.633000: positano,
+				40.628100: positano,
+			},
+			options:    []ProcessorOptions{WithTravel(), WithCatalog(i18n.German)},
+			expect:     "Eine sonnige Wanderung durch Positano",
+			expectMode: Hike,
+		},
+		"boat trip": {
+			input: []Metadata{
+				{Latitude: 40.553200, Longitude: 14.222200, Date: dateParser("2019-10-05T09:00:00")},
+				{Latitude: 40.590000, Longitude: 14.300000, Date: dateParser("2019-10-05T09:30:00")},
+				{Latitude: 40.610000, Longitude: 14.340000, Date: dateParser("2019-10-05T10:00:00")},
+			},
+			locations: map[float64]locator.Location{
+				40.553200: {City: "Campania", Type: "marinearea", Region: "Campania", Country: "Italy"},
+				40.590000: {City: "Campania", Type: "marinearea", Region: "Campania", Country: "Italy"},
+				40.610000: {City: "Campania", Type: "marinearea", Region: "Campania", Country: "Italy"},
+			},
+			options:    []ProcessorOptions{WithTravel()},
+			expect:     "A sunny boat trip through Campania",
+			expectMode: BoatTrip,
+		},
+		"multi-flight journey": {
+			input: []Metadata{
+				{Latitude: 40.641300, Longitude: -73.778100, Date: dateParser("2019-10-05T12:00:00")},
+				{Latitude: 41.974200, Longitude: -87.907300, Date: dateParser("2019-10-05T15:00:00")},
+				{Latitude: 36.084000, Longitude: -115.153700, Date: dateParser("2019-10-05T21:00:00")},
+			},
+			locations: map[float64]locator.Location{
+				40.641300: unitedStates("New York"),
+				41.974200: unitedStates("Illinois"),
+				36.084000: unitedStates("Nevada"),
+			},
+			options:    []ProcessorOptions{WithTravel()},
+			expect:     "A sunny multi-flight journey through United States",
+			expectMode: Flights,
+		},
+		"no travel mode": {
+			input: []Metadata{
+				{Latitude: 40.627883, Longitude: 14.366858, Date: dateParser("2019-10-05T09:00:00")},
+				{Latitude: 40.627808, Longitude: 14.364933, Date: dateParser("2019-10-05T10:00:00")},
+			},
+			locations: map[float64]locator.Location{
+				40.627883: positano,
+				40.627808: positano,
+			},
+			options: []ProcessorOptions{WithTravel()},
+			expect:  "A sunny morning in Positano",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			locatorDouble := &mockLocator{}
+			locatorDouble.Test(t)
+			for latitude, location := range test.locations {
+				locatorDouble.On("Locate", latitude, mock.Anything).Return(location, nil)
+			}
+
+			conditions := test.conditions
+			if conditions == "" {
+				conditions = "Clear"
+			}
+			weathermanDouble := &mockWeatherman{}
+			weathermanDouble.Test(t)
+			weathermanDouble.On("CheckWeather", mock.Anything, mock.Anything, mock.Anything).Return(weatherman.Forecast{Conditions: conditions}, nil)
+
+			p := New(locatorDouble, weathermanDouble, test.options...)
+			got, errs := p.ProcessAlbum(test.input)
+			require.Empty(t, errs)
+			require.Equal(t, test.expect, got.Title)
+			if test.expectMode != "" {
+				require.NotNil(t, got.Travel)
+				require.Equal(t, test.expectMode, got.Travel.Mode)
+			}
+		})
+	}
+}
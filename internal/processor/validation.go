@@ -0,0 +1,103 @@
+package processor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/geo"
+)
+
+// Errors returned for photos rejected before any API quota is spent on them
+var (
+	ErrOutOfRange     = errors.New("coordinates out of range")
+	ErrNullIsland     = errors.New("placeholder 0,0 coordinates")
+	ErrFutureDate     = errors.New("date in the future")
+	ErrBeforeCoverage = errors.New("date before historical weather coverage")
+	ErrOutlier        = errors.New("photo far away from the rest of the album")
+)
+
+// Validation is a custom type used to configure which photos are rejected before being processed
+type Validation struct {
+	// Earliest is the first date covered by the weather provider's historical data.
+	Earliest time.Time
+	// OutlierDistance is how far, in kilometres, a photo must be from every other photo
+	// to be excluded from titling. Outlier detection is disabled when zero.
+	OutlierDistance float64
+}
+
+// DefaultValidation matches the coverage of the VisualCrossing historical weather data
+var DefaultValidation = Validation{
+	Earliest: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// nullIslandTolerance is how close to 0,0, in degrees, coordinates are considered a placeholder
+const nullIslandTolerance = 1e-4
+
+// WithValidation replaces the default Validation
+func WithValidation(v Validation) ProcessorOptions {
+	return func(p *Processor) {
+		p.validation = v
+	}
+}
+
+// WithOutlierDetection excludes from titling photos further than distance kilometres from every other photo
+func WithOutlierDetection(distance float64) ProcessorOptions {
+	return func(p *Processor) {
+		p.validation.OutlierDistance = distance
+	}
+}
+
+// ValidateCoordinates is used to return the reason a position must not be looked up, if any:
+// coordinates out of range or the 0,0 placeholder written by cameras without a GPS fix
+func ValidateCoordinates(latitude, longitude float64) error {
+	switch {
+	case math.IsNaN(latitude) || latitude < -90 || latitude > 90:
+		return fmt.Errorf("%w: latitude %f", ErrOutOfRange, latitude)
+	case math.IsNaN(longitude) || longitude < -180 || longitude > 180:
+		return fmt.Errorf("%w: longitude %f", ErrOutOfRange, longitude)
+	case math.Abs(latitude) < nullIslandTolerance && math.Abs(longitude) < nullIslandTolerance:
+		return ErrNullIsland
+	}
+	return nil
+}
+
+// validate is used to return the reason a photo must not be processed, if any
+func (p *Processor) validate(photo Metadata) error {
+	if err := ValidateCoordinates(photo.Latitude, photo.Longitude); err != nil {
+		return err
+	}
+	switch {
+	case photo.Date.After(p.now()):
+		return fmt.Errorf("%w: %s", ErrFutureDate, photo.Date.Format(time.RFC3339))
+	case photo.Date.Before(p.validation.Earliest):
+		return fmt.Errorf("%w: %s is before %s", ErrBeforeCoverage, photo.Date.Format(time.RFC3339), p.validation.Earliest.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// outliers is used to return the photos further than the outlier distance from every other photo.
+// Nothing is reported when most of the album is spread out, as there is no cluster for photos to stray from.
+func (p *Processor) outliers(album []Metadata) map[int]error {
+	if p.validation.OutlierDistance <= 0 || len(album) < 3 {
+		return nil
+	}
+	outliers := map[int]error{}
+	for i, photo := range album {
+		nearest := math.Inf(1)
+		for j, other := range album {
+			if i == j {
+				continue
+			}
+			nearest = math.Min(nearest, geo.Distance(photo.Latitude, photo.Longitude, other.Latitude, other.Longitude))
+		}
+		if nearest > p.validation.OutlierDistance {
+			outliers[i] = fmt.Errorf("%w: nearest photo is %.0f km away", ErrOutlier, nearest)
+		}
+	}
+	if len(outliers)*2 >= len(album) {
+		return nil
+	}
+	return outliers
+}
@@ -0,0 +1,136 @@
+package processor
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_validate(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	for name, test := range map[string]struct {
+		input Metadata
+
+		wantErr error
+	}{
+		"valid photo": {
+			input: Metadata{Latitude: 40.728808, Longitude: -73.996106, Date: dateParser("2020-03-30T14:12:19")},
+		},
+		"latitude out of range": {
+			input:   Metadata{Latitude: 999, Longitude: -73.996106, Date: dateParser("2020-03-30T14:12:19")},
+			wantErr: ErrOutOfRange,
+		},
+		"latitude not a number": {
+			input:   Metadata{Latitude: math.NaN(), Longitude: -73.996106, Date: dateParser("2020-03-30T14:12:19")},
+			wantErr: ErrOutOfRange,
+		},
+		"longitude out of range": {
+			input:   Metadata{Latitude: 40.728808, Longitude: -181, Date: dateParser("2020-03-30T14:12:19")},
+			wantErr: ErrOutOfRange,
+		},
+		"null island": {
+			input:   Metadata{Latitude: 0, Longitude: 0.00001, Date: dateParser("2020-03-30T14:12:19")},
+			wantErr: ErrNullIsland,
+		},
+		"equator is not null island": {
+			input: Metadata{Latitude: 0, Longitude: 32.5, Date: dateParser("2020-03-30T14:12:19")},
+		},
+		"future date": {
+			input:   Metadata{Latitude: 40.728808, Longitude: -73.996106, Date: dateParser("2021-06-02T14:12:19")},
+			wantErr: ErrFutureDate,
+		},
+		"before coverage": {
+			input:   Metadata{Latitude: 40.728808, Longitude: -73.996106, Date: dateParser("1969-07-20T20:17:00")},
+			wantErr: ErrBeforeCoverage,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := New(&mockLocator{}, &mockWeatherman{})
+			p.now = func() time.Time { return now }
+			err := p.validate(test.input)
+			if test.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.True(t, errors.Is(err, test.wantErr), "got %v, want %v", err, test.wantErr)
+		})
+	}
+}
+
+func TestProcessor_outliers(t *testing.T) {
+	newYork := Metadata{Latitude: 40.728808, Longitude: -73.996106}
+	brooklyn := Metadata{Latitude: 40.678178, Longitude: -73.944158}
+	positano := Metadata{Latitude: 40.627883, Longitude: 14.366858}
+	lasVegas := Metadata{Latitude: 36.102825, Longitude: -115.173813}
+	for name, test := range map[string]struct {
+		input    []Metadata
+		distance float64
+
+		want []int
+	}{
+		"disabled": {
+			input: []Metadata{newYork, brooklyn, positano},
+		},
+		"single far away photo": {
+			input:    []Metadata{newYork, positano, brooklyn, newYork},
+			distance: 5000,
+			want:     []int{1},
+		},
+		"closer than the distance": {
+			input:    []Metadata{newYork, lasVegas, brooklyn, newYork},
+			distance: 5000,
+		},
+		"spread out album": {
+			input:    []Metadata{newYork, positano, lasVegas},
+			distance: 3000,
+		},
+		"too few photos": {
+			input:    []Metadata{newYork, positano},
+			distance: 5000,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := New(&mockLocator{}, &mockWeatherman{}, WithOutlierDetection(test.distance))
+			got := p.outliers(test.input)
+			indexes := []int{}
+			for i, err := range got {
+				require.True(t, errors.Is(err, ErrOutlier))
+				indexes = append(indexes, i)
+			}
+			require.ElementsMatch(t, test.want, indexes)
+		})
+	}
+}
+
+func TestProcessor_ProcessMetadata_validation(t *testing.T) {
+	locatorDouble := &mockLocator{}
+	locatorDouble.Test(t)
+	defer locatorDouble.AssertExpectations(t)
+	weathermanDouble := &mockWeatherman{}
+	weathermanDouble.Test(t)
+	defer weathermanDouble.AssertExpectations(t)
+
+	locatorDouble.On("Locate", 40.728808, -73.996106).Return(locator.Location{City: "New York", Country: "USA"}, nil).Times(3)
+	weathermanDouble.On("CheckWeather", 40.728808, -73.996106, dateParser("2020-03-30T14:12:19")).Return(weatherman.Forecast{Conditions: "Rain"}, nil).Times(3)
+
+	p := New(locatorDouble, weathermanDouble, WithOutlierDetection(5000))
+	photo := Metadata{Latitude: 40.728808, Longitude: -73.996106, Date: dateParser("2020-03-30T14:12:19")}
+	got, errs := p.ProcessMetadata([]Metadata{
+		photo,
+		{Latitude: 999, Longitude: -73.996106, Date: dateParser("2020-03-30T14:12:19")},
+		photo,
+		{Latitude: 40.627883, Longitude: 14.366858, Date: dateParser("2020-03-30T14:12:19")},
+		{Latitude: 0, Longitude: 0, Date: dateParser("2020-03-30T14:12:19")},
+		photo,
+	})
+	require.Len(t, errs, 3)
+	require.True(t, errors.Is(errs[0], ErrOutOfRange))
+	require.True(t, errors.Is(errs[1], ErrNullIsland))
+	require.True(t, errors.Is(errs[2], ErrOutlier))
+	require.Equal(t, "A rainy morning in New York", got)
+}
@@ -0,0 +1,280 @@
+package processor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/geo"
+	"github.com/adrianos93/nomenclator/internal/locator"
+)
+
+// WeatherRule is a custom type used to map the weather conditions returned by the provider to a title adjective
+type WeatherRule struct {
+	Adjective string `json:"adjective"`
+	// Priority decides which adjective wins when a photo matches several rules,
+	// and which one is used when adjectives are tied across the album. Higher wins.
+	Priority int `json:"priority"`
+	// Match lists case insensitive regular expressions matched against each condition string or code.
+	Match []string `json:"match"`
+
+	patterns []*regexp.Regexp
+}
+
+// WeatherRules is a custom type used to classify weather conditions using a table of rules
+type WeatherRules struct {
+	rules []WeatherRule
+	// fallback is the adjective used for conditions no rule matches
+	fallback string
+}
+
+// weatherRulesConfig is used to unmarshal weather rules from a config file
+type weatherRulesConfig struct {
+	Default string        `json:"default"`
+	Rules   []WeatherRule `json:"rules"`
+}
+
+// minPriority ranks adjectives that no rule produces below every rule
+const minPriority = -1 << 31
+
+// DefaultWeatherRules is the rule table used unless overridden
+var DefaultWeatherRules = mustWeatherRules(NewWeatherRules([]WeatherRule{
+	{Adjective: "stormy", Priority: 60, Match: []string{`storm`, `thunder`, `tornado`, `hurricane`, `squall`}},
+	{Adjective: "snowy", Priority: 50, Match: []string{`snow`, `blizzard`, `flurr`}},
+	{Adjective: "chilly", Priority: 40, Match: []string{`\bice\b`, `icy`, `freezing`, `sleet`, `hail`}},
+	{Adjective: "rainy", Priority: 30, Match: []string{`rain`, `drizzle`, `shower`}},
+	{Adjective: "foggy", Priority: 20, Match: []string{`mist`, `overcast`, `fog`, `haze`}},
+	{Adjective: "sunny", Priority: 10, Match: []string{`clear`, `sunny`, `fair`}},
+}, "sunny"))
+
+// NewWeatherRules returns new WeatherRules, compiling the patterns of every rule
+func NewWeatherRules(rules []WeatherRule, fallback string) (*WeatherRules, error) {
+	if fallback == "" {
+		return nil, errors.New("weather rules need a default adjective")
+	}
+	compiled := make([]WeatherRule, 0, len(rules))
+	for i, rule := range rules {
+		if rule.Adjective == "" {
+			return nil, fmt.Errorf("weather rule %d has no adjective", i)
+		}
+		rule.patterns = make([]*regexp.Regexp, 0, len(rule.Match))
+		for _, match := range rule.Match {
+			pattern, err := regexp.Compile("(?i)" + match)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern in weather rule %q: %w", rule.Adjective, err)
+			}
+			rule.patterns = append(rule.patterns, pattern)
+		}
+		compiled = append(compiled, rule)
+	}
+	return &WeatherRules{rules: compiled, fallback: fallback}, nil
+}
+
+// LoadWeatherRules is used to read WeatherRules from a JSON config file, e.g.
+//
+//	{"default": "sunny", "rules": [{"adjective": "rainy", "priority": 30, "match": ["rain", "drizzle"]}]}
+func LoadWeatherRules(r io.Reader) (*WeatherRules, error) {
+	config := weatherRulesConfig{}
+	if err := json.NewDecoder(r).Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to decode weather rules: %w", err)
+	}
+	return NewWeatherRules(config.Rules, config.Default)
+}
+
+// WithWeatherRules replaces the default weather classification rules
+func WithWeatherRules(rules *WeatherRules) ProcessorOptions {
+	return func(p *Processor) {
+		p.weatherRules = rules
+	}
+}
+
+// Classify is used to return the adjective for the conditions reported for a photo. Multiple conditions,
+// e.g. "Rain, Overcast", are matched separately and the highest priority rule wins, the earliest rule in
+// the table winning ties.
+func (w *WeatherRules) Classify(conditions string) string {
+	adjective, _ := w.classify(conditions)
+	return adjective
+}
+
+// classify is a helper function used to return the adjective for conditions along with the pattern
+// of the rule it was decided by, empty when no rule matched and the fallback adjective was used
+func (w *WeatherRules) classify(conditions string) (string, string) {
+	best, match := -1, ""
+	for _, condition := range strings.Split(conditions, ",") {
+		condition = strings.TrimSpace(condition)
+		if condition == "" {
+			continue
+		}
+		for i, rule := range w.rules {
+			pattern, found := rule.match(condition)
+			if !found {
+				continue
+			}
+			if best < 0 || rule.Priority > w.rules[best].Priority {
+				best, match = i, pattern
+			}
+		}
+	}
+	if best < 0 {
+		return w.fallback, ""
+	}
+	return w.rules[best].Adjective, match
+}
+
+// priority is used to return the priority of an adjective, the fallback adjective ranking below every rule
+func (w *WeatherRules) priority(adjective string) int {
+	for _, rule := range w.rules {
+		if rule.Adjective == adjective {
+			return rule.Priority
+		}
+	}
+	return minPriority
+}
+
+// match is a helper function used to return the first of the rule patterns matching condition, if any
+func (r WeatherRule) match(condition string) (string, bool) {
+	for i, pattern := range r.patterns {
+		if pattern.MatchString(condition) {
+			return r.Match[i], true
+		}
+	}
+	return "", false
+}
+
+// mustWeatherRules is a helper function used to build the default rules, panicking if they are invalid
+func mustWeatherRules(rules *WeatherRules, err error) *WeatherRules {
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// WeatherAggregation is a custom type used to select how the photos of an album vote for its weather
+type WeatherAggregation string
+
+const (
+	// PerPhoto gives every photo one vote
+	PerPhoto WeatherAggregation = "photo"
+	// PerHour gives every hour with photos one vote, shared between the photos taken during it
+	PerHour WeatherAggregation = "hour"
+	// PerDay gives every day with photos one vote, shared between the photos taken during it
+	PerDay WeatherAggregation = "day"
+	// TimeWeighted weighs every photo by the time elapsed around it, so bursts of photos count as little as the time they cover
+	TimeWeighted WeatherAggregation = "time"
+)
+
+// maxWeightedGap caps the time a single photo can stand for when time weighting, so that nights
+// and other long breaks do not hand the whole vote to the last photo before them
+const maxWeightedGap = 2 * time.Hour
+
+// WithWeatherAggregation sets how photos vote for the album weather
+func WithWeatherAggregation(aggregation WeatherAggregation) ProcessorOptions {
+	return func(p *Processor) {
+		p.aggregation = aggregation
+	}
+}
+
+// ParseWeatherAggregation is used to return the WeatherAggregation named s
+func ParseWeatherAggregation(s string) (WeatherAggregation, error) {
+	switch aggregation := WeatherAggregation(s); aggregation {
+	case PerPhoto, PerHour, PerDay, TimeWeighted:
+		return aggregation, nil
+	}
+	return "", fmt.Errorf("unknown weather aggregation %q, use one of %s, %s, %s, %s", s, PerPhoto, PerHour, PerDay, TimeWeighted)
+}
+
+// weatherConditions is a helper function used to analyse the data returned by Weatherman and translate it to something more title friendly.
+// The adjective with the most votes wins, ties are broken by rule priority and then alphabetically so the result never depends on photo order.
+func weatherConditions(album []locator.Location, rules *WeatherRules, aggregation WeatherAggregation) string {
+	return topAdjective(weatherVotes(album, rules, aggregation), rules)
+}
+
+// weatherVotes is a helper function used to tally the votes of every adjective in an album
+func weatherVotes(album []locator.Location, rules *WeatherRules, aggregation WeatherAggregation) map[string]float64 {
+	weights := voteWeights(album, aggregation)
+	m := make(map[string]float64, len(album))
+	for i, photo := range album {
+		m[rules.Classify(photo.Weather)] += weights[i]
+	}
+	return m
+}
+
+// voteWeights is a helper function used to return how much each photo of an album weighs in the weather vote
+func voteWeights(album []locator.Location, aggregation WeatherAggregation) []float64 {
+	weights := make([]float64, len(album))
+	switch aggregation {
+	case PerHour, PerDay:
+		layout := "2006-01-02T15"
+		if aggregation == PerDay {
+			layout = "2006-01-02"
+		}
+		buckets := make(map[string]int, len(album))
+		keys := make([]string, len(album))
+		for i, photo := range album {
+			keys[i] = geo.LocalTime(photo.Date, photo.Longitude).Format(layout)
+			buckets[keys[i]]++
+		}
+		for i, key := range keys {
+			weights[i] = 1 / float64(buckets[key])
+		}
+	case TimeWeighted:
+		order := make([]int, len(album))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool { return album[order[i]].Date.Before(album[order[j]].Date) })
+		var total float64
+		for n, i := range order {
+			if n > 0 {
+				weights[i] += cappedGap(album[order[n-1]].Date, album[i].Date) / 2
+			}
+			if n < len(order)-1 {
+				weights[i] += cappedGap(album[i].Date, album[order[n+1]].Date) / 2
+			}
+			total += weights[i]
+		}
+		if total > 0 {
+			break
+		}
+		fallthrough
+	default:
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	return weights
+}
+
+// cappedGap is a helper function used to return the hours between two photos, capped to maxWeightedGap
+func cappedGap(from, to time.Time) float64 {
+	gap := to.Sub(from)
+	if gap > maxWeightedGap {
+		gap = maxWeightedGap
+	}
+	return gap.Hours()
+}
+
+// voteTolerance is how close two vote tallies must be to be considered tied
+const voteTolerance = 1e-9
+
+// topAdjective is a helper function used to return the adjective with the most votes, breaking ties deterministically
+func topAdjective(votes map[string]float64, rules *WeatherRules) string {
+	var weather string
+	var count float64
+	for adjective, n := range votes {
+		tied := math.Abs(n-count) < voteTolerance
+		switch {
+		case !tied && n > count,
+			tied && rules.priority(adjective) > rules.priority(weather),
+			tied && rules.priority(adjective) == rules.priority(weather) && adjective < weather:
+			weather, count = adjective, n
+		}
+	}
+	return weather
+}
@@ -0,0 +1,196 @@
+package processor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeatherRules_Classify(t *testing.T) {
+	for conditions, expect := range map[string]string{
+		// every pattern of the default rules
+		"Thunderstorm":          "stormy",
+		"Thunder":               "stormy",
+		"Tornado":               "stormy",
+		"Hurricane":             "stormy",
+		"Squalls":               "stormy",
+		"Snow":                  "snowy",
+		"Blizzard":              "snowy",
+		"Snow Flurries":         "snowy",
+		"Ice":                   "chilly",
+		"Icy":                   "chilly",
+		"Freezing Drizzle/Rain": "chilly",
+		"Sleet":                 "chilly",
+		"Hail":                  "chilly",
+		"Rain":                  "rainy",
+		"Light Drizzle":         "rainy",
+		"Rain Showers":          "rainy",
+		"Mist":                  "foggy",
+		"Overcast":              "foggy",
+		"Fog":                   "foggy",
+		"Smoke Or Haze":         "foggy",
+		"Clear":                 "sunny",
+		"Sunny":                 "sunny",
+		"Fair":                  "sunny",
+		"Partially cloudy":      "sunny",
+		"":                      "sunny",
+		// multiple conditions pick the highest priority
+		"Rain, Overcast":         "rainy",
+		"Overcast, Rain":         "rainy",
+		"Rain,Snow":              "snowy",
+		"Clear, Thunderstorm":    "stormy",
+		"Partially cloudy, Mist": "foggy",
+		"Rain, Ice":              "chilly",
+		// ice is not matched inside other words
+		"Rain, Police": "rainy",
+	} {
+		t.Run(conditions, func(t *testing.T) {
+			require.Equal(t, expect, DefaultWeatherRules.Classify(conditions))
+		})
+	}
+}
+
+func TestWeatherRules_NewWeatherRules(t *testing.T) {
+	for name, test := range map[string]struct {
+		rules    []WeatherRule
+		fallback string
+
+		wantErr bool
+	}{
+		"valid rules": {
+			rules:    []WeatherRule{{Adjective: "wet", Match: []string{"rain"}}},
+			fallback: "dry",
+		},
+		"no default": {
+			rules:   []WeatherRule{{Adjective: "wet", Match: []string{"rain"}}},
+			wantErr: true,
+		},
+		"no adjective": {
+			rules:    []WeatherRule{{Match: []string{"rain"}}},
+			fallback: "dry",
+			wantErr:  true,
+		},
+		"invalid pattern": {
+			rules:    []WeatherRule{{Adjective: "wet", Match: []string{"(rain"}}},
+			fallback: "dry",
+			wantErr:  true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewWeatherRules(test.rules, test.fallback)
+			if (err != nil) != test.wantErr {
+				t.Errorf("NewWeatherRules() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestWeatherRules_LoadWeatherRules(t *testing.T) {
+	rules, err := LoadWeatherRules(strings.NewReader(`{
+		"default": "pleasant",
+		"rules": [
+			{"adjective": "wet", "priority": 10, "match": ["rain", "^type_21$"]},
+			{"adjective": "white", "priority": 20, "match": ["snow"]}
+		]
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, "wet", rules.Classify("Rain"))
+	require.Equal(t, "wet", rules.Classify("type_21"))
+	require.Equal(t, "white", rules.Classify("Rain, Snow"))
+	require.Equal(t, "pleasant", rules.Classify("Clear"))
+
+	_, err = LoadWeatherRules(strings.NewReader(`what`))
+	require.Error(t, err)
+}
+
+func TestProcessor_weatherConditions(t *testing.T) {
+	album := func(conditions ...string) []locator.Location {
+		photos := make([]locator.Location, 0, len(conditions))
+		for _, condition := range conditions {
+			photos = append(photos, locator.Location{Weather: condition})
+		}
+		return photos
+	}
+	for name, test := range map[string]struct {
+		album []locator.Location
+
+		expect string
+	}{
+		"most common wins": {
+			album:  album("Rain", "Overcast", "Fog"),
+			expect: "foggy",
+		},
+		"ties are broken by priority": {
+			album:  album("Fog", "Rain"),
+			expect: "rainy",
+		},
+		"ties do not depend on order": {
+			album:  album("Rain", "Fog"),
+			expect: "rainy",
+		},
+		"icy votes are counted": {
+			album:  album("Ice", "Ice", "Clear", "Rain"),
+			expect: "chilly",
+		},
+		"empty album": {
+			expect: "",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expect, weatherConditions(test.album, DefaultWeatherRules, PerPhoto))
+		})
+	}
+}
+
+func TestProcessor_weatherConditions_aggregation(t *testing.T) {
+	// a 40 photo burst during a five minute sunny spell, on an otherwise rainy day in New York
+	burst := make([]locator.Location, 0, 46)
+	for i := 0; i < 40; i++ {
+		burst = append(burst, locator.Location{Longitude: -73.99, Weather: "Clear", Date: dateParser("2020-03-30T17:00:00").Add(time.Duration(i) * 7 * time.Second)})
+	}
+	for _, date := range []string{"2020-03-30T13:00:00", "2020-03-30T14:30:00", "2020-03-30T16:00:00", "2020-03-30T19:00:00", "2020-03-30T20:30:00", "2020-03-30T22:00:00"} {
+		burst = append(burst, locator.Location{Longitude: -73.99, Weather: "Rain", Date: dateParser(date)})
+	}
+	// a rainy first day with many photos, then two sunny days with few
+	trip := []locator.Location{}
+	for i := 0; i < 10; i++ {
+		trip = append(trip, locator.Location{Longitude: -73.99, Weather: "Rain", Date: dateParser("2020-03-28T15:00:00").Add(time.Duration(i) * time.Hour)})
+	}
+	trip = append(trip,
+		locator.Location{Longitude: -73.99, Weather: "Clear", Date: dateParser("2020-03-29T15:00:00")},
+		locator.Location{Longitude: -73.99, Weather: "Clear", Date: dateParser("2020-03-30T15:00:00")},
+	)
+	for name, test := range map[string]struct {
+		album       []locator.Location
+		aggregation WeatherAggregation
+
+		expect string
+	}{
+		"burst per photo":         {album: burst, aggregation: PerPhoto, expect: "sunny"},
+		"burst per hour":          {album: burst, aggregation: PerHour, expect: "rainy"},
+		"burst per day":           {album: burst, aggregation: PerDay, expect: "sunny"},
+		"burst time weighted":     {album: burst, aggregation: TimeWeighted, expect: "rainy"},
+		"trip per photo":          {album: trip, aggregation: PerPhoto, expect: "rainy"},
+		"trip per day":            {album: trip, aggregation: PerDay, expect: "sunny"},
+		"single photo time":       {album: burst[:1], aggregation: TimeWeighted, expect: "sunny"},
+		"simultaneous photos":     {album: []locator.Location{burst[0], burst[0], {Weather: "Rain", Date: burst[0].Date}}, aggregation: TimeWeighted, expect: "sunny"},
+		"unknown falls to photos": {album: trip, aggregation: "", expect: "rainy"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expect, weatherConditions(test.album, DefaultWeatherRules, test.aggregation))
+		})
+	}
+}
+
+func TestProcessor_ParseWeatherAggregation(t *testing.T) {
+	for _, name := range []string{"photo", "hour", "day", "time"} {
+		got, err := ParseWeatherAggregation(name)
+		require.NoError(t, err)
+		require.Equal(t, WeatherAggregation(name), got)
+	}
+	_, err := ParseWeatherAggregation("minute")
+	require.Error(t, err)
+}
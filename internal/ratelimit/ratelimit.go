@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter is a custom type used to space out calls shared by several goroutines, e.g. to stay within the quota of an API
+type Limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+	sleep    func(time.Duration)
+	now      func() time.Time
+}
+
+// New returns a new Limiter letting through up to perSecond calls a second, or any number of them when perSecond
+// is not positive
+func New(perSecond float64) *Limiter {
+	limiter := &Limiter{sleep: time.Sleep, now: time.Now}
+	if perSecond > 0 {
+		limiter.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+	return limiter
+}
+
+// Wait is used to block until the next call is let through. A nil Limiter lets every call through.
+func (l *Limiter) Wait() {
+	if l == nil || l.interval == 0 {
+		return
+	}
+	l.mu.Lock()
+	now := l.now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+	if wait := slot.Sub(now); wait > 0 {
+		l.sleep(wait)
+	}
+}
@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_Wait(t *testing.T) {
+	for name, test := range map[string]struct {
+		perSecond float64
+		calls     int
+		elapsed   time.Duration
+
+		expect []time.Duration
+	}{
+		"spaces out calls": {
+			perSecond: 4,
+			calls:     3,
+			expect:    []time.Duration{250 * time.Millisecond, 500 * time.Millisecond},
+		},
+		"lets calls through after a pause": {
+			perSecond: 4,
+			calls:     2,
+			elapsed:   time.Second,
+			expect:    nil,
+		},
+		"unlimited": {
+			calls:  3,
+			expect: nil,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			start := time.Date(2019, 7, 4, 12, 0, 0, 0, time.UTC)
+			now := start
+			var slept []time.Duration
+			l := New(test.perSecond)
+			l.now = func() time.Time { return now }
+			l.sleep = func(d time.Duration) { slept = append(slept, d) }
+			for i := 0; i < test.calls; i++ {
+				l.Wait()
+				now = now.Add(test.elapsed)
+			}
+			require.Equal(t, test.expect, slept)
+		})
+	}
+}
+
+func TestLimiter_Wait_nil(t *testing.T) {
+	var l *Limiter
+	require.NotPanics(t, l.Wait)
+}
@@ -0,0 +1,256 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: nomenclator.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// NomenclatorClient is the client API for Nomenclator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NomenclatorClient interface {
+	// TitleAlbum titles an album sent in a single request.
+	TitleAlbum(ctx context.Context, in *TitleAlbumRequest, opts ...grpc.CallOption) (*Album, error)
+	// Locate looks up the place at a position with the geolocation API.
+	Locate(ctx context.Context, in *LocateRequest, opts ...grpc.CallOption) (*Location, error)
+	// CheckWeather looks up the weather on the day of a date at a position with the weather API.
+	CheckWeather(ctx context.Context, in *CheckWeatherRequest, opts ...grpc.CallOption) (*Forecast, error)
+	// StreamPhotos titles an album whose photos are sent over several requests, once the client closes the stream.
+	StreamPhotos(ctx context.Context, opts ...grpc.CallOption) (Nomenclator_StreamPhotosClient, error)
+}
+
+type nomenclatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNomenclatorClient(cc grpc.ClientConnInterface) NomenclatorClient {
+	return &nomenclatorClient{cc}
+}
+
+func (c *nomenclatorClient) TitleAlbum(ctx context.Context, in *TitleAlbumRequest, opts ...grpc.CallOption) (*Album, error) {
+	out := new(Album)
+	err := c.cc.Invoke(ctx, "/nomenclator.v1.Nomenclator/TitleAlbum", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nomenclatorClient) Locate(ctx context.Context, in *LocateRequest, opts ...grpc.CallOption) (*Location, error) {
+	out := new(Location)
+	err := c.cc.Invoke(ctx, "/nomenclator.v1.Nomenclator/Locate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nomenclatorClient) CheckWeather(ctx context.Context, in *CheckWeatherRequest, opts ...grpc.CallOption) (*Forecast, error) {
+	out := new(Forecast)
+	err := c.cc.Invoke(ctx, "/nomenclator.v1.Nomenclator/CheckWeather", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nomenclatorClient) StreamPhotos(ctx context.Context, opts ...grpc.CallOption) (Nomenclator_StreamPhotosClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Nomenclator_ServiceDesc.Streams[0], "/nomenclator.v1.Nomenclator/StreamPhotos", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nomenclatorStreamPhotosClient{stream}
+	return x, nil
+}
+
+type Nomenclator_StreamPhotosClient interface {
+	Send(*StreamPhotosRequest) error
+	CloseAndRecv() (*Album, error)
+	grpc.ClientStream
+}
+
+type nomenclatorStreamPhotosClient struct {
+	grpc.ClientStream
+}
+
+func (x *nomenclatorStreamPhotosClient) Send(m *StreamPhotosRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *nomenclatorStreamPhotosClient) CloseAndRecv() (*Album, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Album)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NomenclatorServer is the server API for Nomenclator service.
+// All implementations must embed UnimplementedNomenclatorServer
+// for forward compatibility
+type NomenclatorServer interface {
+	// TitleAlbum titles an album sent in a single request.
+	TitleAlbum(context.Context, *TitleAlbumRequest) (*Album, error)
+	// Locate looks up the place at a position with the geolocation API.
+	Locate(context.Context, *LocateRequest) (*Location, error)
+	// CheckWeather looks up the weather on the day of a date at a position with the weather API.
+	CheckWeather(context.Context, *CheckWeatherRequest) (*Forecast, error)
+	// StreamPhotos titles an album whose photos are sent over several requests, once the client closes the stream.
+	StreamPhotos(Nomenclator_StreamPhotosServer) error
+	mustEmbedUnimplementedNomenclatorServer()
+}
+
+// UnimplementedNomenclatorServer must be embedded to have forward compatible implementations.
+type UnimplementedNomenclatorServer struct {
+}
+
+func (UnimplementedNomenclatorServer) TitleAlbum(context.Context, *TitleAlbumRequest) (*Album, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TitleAlbum not implemented")
+}
+func (UnimplementedNomenclatorServer) Locate(context.Context, *LocateRequest) (*Location, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Locate not implemented")
+}
+func (UnimplementedNomenclatorServer) CheckWeather(context.Context, *CheckWeatherRequest) (*Forecast, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckWeather not implemented")
+}
+func (UnimplementedNomenclatorServer) StreamPhotos(Nomenclator_StreamPhotosServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPhotos not implemented")
+}
+func (UnimplementedNomenclatorServer) mustEmbedUnimplementedNomenclatorServer() {}
+
+// UnsafeNomenclatorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NomenclatorServer will
+// result in compilation errors.
+type UnsafeNomenclatorServer interface {
+	mustEmbedUnimplementedNomenclatorServer()
+}
+
+func RegisterNomenclatorServer(s grpc.ServiceRegistrar, srv NomenclatorServer) {
+	s.RegisterService(&Nomenclator_ServiceDesc, srv)
+}
+
+func _Nomenclator_TitleAlbum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TitleAlbumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NomenclatorServer).TitleAlbum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nomenclator.v1.Nomenclator/TitleAlbum",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NomenclatorServer).TitleAlbum(ctx, req.(*TitleAlbumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Nomenclator_Locate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NomenclatorServer).Locate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nomenclator.v1.Nomenclator/Locate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NomenclatorServer).Locate(ctx, req.(*LocateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Nomenclator_CheckWeather_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckWeatherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NomenclatorServer).CheckWeather(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nomenclator.v1.Nomenclator/CheckWeather",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NomenclatorServer).CheckWeather(ctx, req.(*CheckWeatherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Nomenclator_StreamPhotos_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NomenclatorServer).StreamPhotos(&nomenclatorStreamPhotosServer{stream})
+}
+
+type Nomenclator_StreamPhotosServer interface {
+	SendAndClose(*Album) error
+	Recv() (*StreamPhotosRequest, error)
+	grpc.ServerStream
+}
+
+type nomenclatorStreamPhotosServer struct {
+	grpc.ServerStream
+}
+
+func (x *nomenclatorStreamPhotosServer) SendAndClose(m *Album) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *nomenclatorStreamPhotosServer) Recv() (*StreamPhotosRequest, error) {
+	m := new(StreamPhotosRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Nomenclator_ServiceDesc is the grpc.ServiceDesc for Nomenclator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Nomenclator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "nomenclator.v1.Nomenclator",
+	HandlerType: (*NomenclatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TitleAlbum",
+			Handler:    _Nomenclator_TitleAlbum_Handler,
+		},
+		{
+			MethodName: "Locate",
+			Handler:    _Nomenclator_Locate_Handler,
+		},
+		{
+			MethodName: "CheckWeather",
+			Handler:    _Nomenclator_CheckWeather_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPhotos",
+			Handler:       _Nomenclator_StreamPhotos_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "nomenclator.proto",
+}
@@ -0,0 +1,261 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/processor"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+)
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative nomenclator.proto
+
+// DefaultMaxPhotos is the largest number of photos an album can be titled from, unless set with WithMaxPhotos
+const DefaultMaxPhotos = 100000
+
+// NewProcessor is used to build the Processor titling the album of a request, looking up its photos with l and w
+type NewProcessor func(l processor.Locator, w processor.Weatherman) (*processor.Processor, error)
+
+// Server is a custom type used to serve the Nomenclator gRPC service, titling albums with a Processor and
+// looking up places and forecasts with the same APIs it uses
+type Server struct {
+	UnimplementedNomenclatorServer
+	processor  NewProcessor
+	locator    processor.Locator
+	weatherman processor.Weatherman
+	titled     func(context.Context, batch.Result)
+	maxPhotos  int
+}
+
+type ServerOptions func(*Server)
+
+// WithTitled calls titled with the result of every album titled by the server, e.g. to notify a webhook
+func WithTitled(titled func(context.Context, batch.Result)) ServerOptions {
+	return func(s *Server) {
+		s.titled = titled
+	}
+}
+
+// WithMaxPhotos sets the largest number of photos an album can be titled from, so that streamed albums are not held
+// in memory without bound. Larger albums are refused with ResourceExhausted, and 0 disables the limit.
+func WithMaxPhotos(n int) ServerOptions {
+	return func(s *Server) {
+		s.maxPhotos = n
+	}
+}
+
+// NewServer returns a new Server titling albums with the processors built by p, answering Locate with l and CheckWeather with w.
+// Every album is titled by its own processor, looking up its photos with l and w until the request is cancelled.
+func NewServer(p NewProcessor, l processor.Locator, w processor.Weatherman, options ...ServerOptions) *Server {
+	server := &Server{processor: p, locator: l, weatherman: w, maxPhotos: DefaultMaxPhotos}
+	for _, option := range options {
+		option(server)
+	}
+	return server
+}
+
+// TitleAlbum is used to title an album sent in a single request
+func (s *Server) TitleAlbum(ctx context.Context, req *TitleAlbumRequest) (*Album, error) {
+	if err := s.checkPhotos(len(req.GetPhotos())); err != nil {
+		return nil, err
+	}
+	photos, err := metadata(req.GetPhotos())
+	if err != nil {
+		return nil, err
+	}
+	return s.title(ctx, req.GetName(), photos)
+}
+
+// StreamPhotos is used to title an album whose photos are sent over a stream, once the client closes it.
+// The stream fails as soon as more photos than allowed are sent.
+func (s *Server) StreamPhotos(stream Nomenclator_StreamPhotosServer) error {
+	var name string
+	var photos []processor.Metadata
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			name = req.GetName()
+		}
+		if err := s.checkPhotos(len(photos) + len(req.GetPhotos())); err != nil {
+			return err
+		}
+		received, err := metadata(req.GetPhotos())
+		if err != nil {
+			return err
+		}
+		photos = append(photos, received...)
+	}
+	album, err := s.title(stream.Context(), name, photos)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(album)
+}
+
+// Locate is used to look up the place at a position with the geolocation API
+func (s *Server) Locate(ctx context.Context, req *LocateRequest) (*Location, error) {
+	if err := processor.ValidateCoordinates(req.GetLatitude(), req.GetLongitude()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	location, err := s.locator.Locate(req.GetLatitude(), req.GetLongitude())
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return &Location{
+		City:          location.City,
+		Type:          location.Type,
+		Neighbourhood: location.Neighbourhood,
+		Locality:      location.Locality,
+		County:        location.County,
+		Region:        location.Region,
+		Country:       location.Country,
+		Continent:     location.Continent,
+	}, nil
+}
+
+// CheckWeather is used to look up the weather on the day of a date at a position with the weather API
+func (s *Server) CheckWeather(ctx context.Context, req *CheckWeatherRequest) (*Forecast, error) {
+	if err := processor.ValidateCoordinates(req.GetLatitude(), req.GetLongitude()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.GetDate() == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing date")
+	}
+	if err := req.GetDate().CheckValid(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	forecast, err := s.weatherman.CheckWeather(req.GetLatitude(), req.GetLongitude(), req.GetDate().AsTime())
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return &Forecast{Conditions: forecast.Conditions, CloudCover: forecast.CloudCover}, nil
+}
+
+// title is a helper function used to title the photos of an album, answering with the fields of the batch report.
+// Photos are no longer looked up once ctx is done, and the request fails with the error of ctx.
+func (s *Server) title(ctx context.Context, name string, photos []processor.Metadata) (*Album, error) {
+	if len(photos) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no photos found")
+	}
+	albumProcessor, err := s.processor(&contextLocator{ctx: ctx, api: s.locator}, &contextWeatherman{ctx: ctx, api: s.weatherman})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	album, errs := albumProcessor.ProcessAlbum(photos)
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	result := batch.NewResult(name, photos, album, errs)
+	if s.titled != nil && result.Title != "" {
+		s.titled(ctx, result)
+	}
+	reply := &Album{
+		Name:   result.Folder,
+		Title:  result.Title,
+		Photos: int32(result.Photos),
+		Read:   int32(result.Read),
+		Errors: result.Errors,
+	}
+	if result.Start != nil {
+		reply.Start, reply.End = timestamppb.New(*result.Start), timestamppb.New(*result.End)
+	}
+	for _, suggestion := range album.Suggestions {
+		reply.Suggestions = append(reply.Suggestions, &Suggestion{Title: suggestion.Title, Score: suggestion.Score})
+	}
+	if travel := album.Travel; travel != nil {
+		reply.Travel = &Travel{
+			Mode:         string(travel.Mode),
+			Distance:     travel.Distance,
+			MaxSpeed:     travel.MaxSpeed,
+			MedianSpeed:  travel.MedianSpeed,
+			Speed:        travel.Speed,
+			Straightness: travel.Straightness,
+			Water:        travel.Water,
+			Flights:      int32(travel.Flights),
+		}
+	}
+	if sky := album.Sky; sky != nil {
+		reply.Sky = &Sky{Moon: sky.Moon, Illumination: sky.Illumination, MoonUp: sky.MoonUp, CloudCover: sky.CloudCover, Facet: sky.Facet}
+	}
+	if altitude := album.Altitude; altitude != nil {
+		reply.Altitude = &Altitude{Min: altitude.Min, Max: altitude.Max}
+	}
+	if album.Explanation != nil {
+		for _, decision := range album.Explanation.Decisions {
+			reply.Decisions = append(reply.Decisions, &Decision{Facet: decision.Facet, Value: decision.Value, Reason: decision.Reason})
+		}
+	}
+	return reply, nil
+}
+
+// checkPhotos is a helper function used to refuse albums of more than the photos allowed
+func (s *Server) checkPhotos(n int) error {
+	if s.maxPhotos > 0 && n > s.maxPhotos {
+		return status.Errorf(codes.ResourceExhausted, "albums are limited to %d photos", s.maxPhotos)
+	}
+	return nil
+}
+
+// metadata is a helper function used to map photos to the Metadata custom type, failing for photos without a valid date
+// or a position
+func metadata(photos []*Photo) ([]processor.Metadata, error) {
+	album := make([]processor.Metadata, 0, len(photos))
+	for _, photo := range photos {
+		if photo.GetDate() == nil {
+			return nil, status.Error(codes.InvalidArgument, "photo without a date")
+		}
+		if err := photo.GetDate().CheckValid(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if photo.Latitude == nil || photo.Longitude == nil || math.IsNaN(photo.GetLatitude()) || math.IsNaN(photo.GetLongitude()) {
+			return nil, status.Error(codes.InvalidArgument, "photo without a position")
+		}
+		album = append(album, processor.Metadata{
+			Latitude:  photo.GetLatitude(),
+			Longitude: photo.GetLongitude(),
+			Date:      photo.GetDate().AsTime(),
+		})
+	}
+	return album, nil
+}
+
+// contextLocator is a custom type used to fail the lookups of a request without calling the geolocation API once it is cancelled
+type contextLocator struct {
+	ctx context.Context
+	api processor.Locator
+}
+
+func (l *contextLocator) Locate(latitude, longitude float64) (locator.Location, error) {
+	if err := l.ctx.Err(); err != nil {
+		return locator.Location{}, err
+	}
+	return l.api.Locate(latitude, longitude)
+}
+
+// contextWeatherman is a custom type used to fail the lookups of a request without calling the weather API once it is cancelled
+type contextWeatherman struct {
+	ctx context.Context
+	api processor.Weatherman
+}
+
+func (w *contextWeatherman) CheckWeather(latitude, longitude float64, date time.Time) (weatherman.Forecast, error) {
+	if err := w.ctx.Err(); err != nil {
+		return weatherman.Forecast{}, err
+	}
+	return w.api.CheckWeather(latitude, longitude, date)
+}
@@ -0,0 +1,349 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/adrianos93/nomenclator/internal/batch"
+	"github.com/adrianos93/nomenclator/internal/elevation"
+	"github.com/adrianos93/nomenclator/internal/locator"
+	"github.com/adrianos93/nomenclator/internal/processor"
+	"github.com/adrianos93/nomenclator/internal/weatherman"
+)
+
+type mockLocator struct {
+	mock.Mock
+}
+
+func (l *mockLocator) Locate(latitude, longitude float64) (locator.Location, error) {
+	args := l.Called(latitude, longitude)
+	return args.Get(0).(locator.Location), args.Error(1)
+}
+
+type mockWeatherman struct {
+	mock.Mock
+}
+
+func (w *mockWeatherman) CheckWeather(latitude, longitude float64, date time.Time) (weatherman.Forecast, error) {
+	args := w.Called(latitude, longitude, date)
+	return args.Get(0).(weatherman.Forecast), args.Error(1)
+}
+
+var (
+	saturday = time.Date(2020, 3, 28, 14, 32, 2, 0, time.UTC)
+	sunday   = time.Date(2020, 3, 29, 14, 20, 10, 0, time.UTC)
+	monday   = time.Date(2020, 3, 30, 14, 12, 19, 0, time.UTC)
+)
+
+// dial is a helper function used to serve s over an in-process listener, returning a client connected to it
+func dial(t *testing.T, s *Server) NomenclatorClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	RegisterNomenclatorServer(server, s)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return NewNomenclatorClient(conn)
+}
+
+// newYork is a helper function used to return a Server whose APIs place every photo in a foggy New York
+func newYork(options ...ServerOptions) *Server {
+	l := &mockLocator{}
+	w := &mockWeatherman{}
+	l.On("Locate", mock.Anything, mock.Anything).Return(locator.Location{City: "New York", Country: "USA"}, nil)
+	w.On("CheckWeather", mock.Anything, mock.Anything, saturday).Return(weatherman.Forecast{Conditions: "Fog"}, nil)
+	w.On("CheckWeather", mock.Anything, mock.Anything, sunday).Return(weatherman.Forecast{Conditions: "Overcast"}, nil)
+	w.On("CheckWeather", mock.Anything, mock.Anything, monday).Return(weatherman.Forecast{Conditions: "Rain"}, nil)
+	return NewServer(newProcessor, l, w, options...)
+}
+
+// newProcessor is a helper function used to build a Processor with the default options
+func newProcessor(l processor.Locator, w processor.Weatherman) (*processor.Processor, error) {
+	return processor.New(l, w), nil
+}
+
+func photo(date time.Time, latitude, longitude float64) *Photo {
+	return &Photo{Date: timestamppb.New(date), Latitude: proto.Float64(latitude), Longitude: proto.Float64(longitude)}
+}
+
+func TestServer_TitleAlbum(t *testing.T) {
+	foggyWeekend := &Album{
+		Name: "new-york.csv", Title: "A foggy weekend in New York", Photos: 3, Read: 3,
+		Start: timestamppb.New(saturday), End: timestamppb.New(monday),
+	}
+	for name, test := range map[string]struct {
+		input *TitleAlbumRequest
+
+		expect     *Album
+		expectCode codes.Code
+	}{
+		"titled": {
+			input: &TitleAlbumRequest{Name: "new-york.csv", Photos: []*Photo{
+				photo(monday, 40.728808, -73.996106),
+				photo(sunday, 40.728656, -73.998790),
+				photo(saturday, 40.727160, -73.996044),
+			}},
+			expect: foggyWeekend,
+		},
+		"invalid photos are reported": {
+			input: &TitleAlbumRequest{Name: "new-york.csv", Photos: []*Photo{
+				photo(monday, 40.728808, -73.996106),
+				photo(sunday, 140, -73.998790),
+			}},
+			expect: &Album{
+				Name: "new-york.csv", Title: "A rainy morning in New York", Photos: 1, Read: 2,
+				Start: timestamppb.New(sunday), End: timestamppb.New(monday),
+				Errors: []string{"invalid photo: coordinates out of range: latitude 140.000000"},
+			},
+		},
+		"no photos": {
+			input:      &TitleAlbumRequest{Name: "empty.csv"},
+			expectCode: codes.InvalidArgument,
+		},
+		"photo without a date": {
+			input:      &TitleAlbumRequest{Photos: []*Photo{{Latitude: proto.Float64(40.728808), Longitude: proto.Float64(-73.996106)}}},
+			expectCode: codes.InvalidArgument,
+		},
+		"photo without a position": {
+			input:      &TitleAlbumRequest{Photos: []*Photo{{Date: timestamppb.New(monday)}}},
+			expectCode: codes.InvalidArgument,
+		},
+		"photo without a longitude": {
+			input:      &TitleAlbumRequest{Photos: []*Photo{{Date: timestamppb.New(monday), Latitude: proto.Float64(40.728808)}}},
+			expectCode: codes.InvalidArgument,
+		},
+		"photos at 0,0 are reported": {
+			input: &TitleAlbumRequest{Name: "new-york.csv", Photos: []*Photo{
+				photo(monday, 40.728808, -73.996106),
+				photo(sunday, 0, 0),
+			}},
+			expect: &Album{
+				Name: "new-york.csv", Title: "A rainy morning in New York", Photos: 1, Read: 2,
+				Start: timestamppb.New(sunday), End: timestamppb.New(monday),
+				Errors: []string{"invalid photo: placeholder 0,0 coordinates"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := dial(t, newYork())
+			got, err := client.TitleAlbum(context.Background(), test.input)
+			require.Equal(t, test.expectCode, status.Code(err), err)
+			if test.expect != nil {
+				require.True(t, proto.Equal(test.expect, got), "expected %v, got %v", test.expect, got)
+			}
+		})
+	}
+}
+
+func TestServer_TitleAlbum_details(t *testing.T) {
+	l := &mockLocator{}
+	w := &mockWeatherman{}
+	l.On("Locate", mock.Anything, mock.Anything).Return(locator.Location{City: "New York", Country: "USA"}, nil)
+	w.On("CheckWeather", mock.Anything, mock.Anything, mock.Anything).Return(weatherman.Forecast{Conditions: "Fog"}, nil)
+	detailed := func(l processor.Locator, w processor.Weatherman) (*processor.Processor, error) {
+		return processor.New(l, w,
+			processor.WithSuggestions(2),
+			processor.WithTravel(),
+			processor.WithElevation(elevation.Fake{40.728808: 10, 40.728656: 12, 40.727160: 8}),
+			processor.WithExplain(),
+		), nil
+	}
+	client := dial(t, NewServer(detailed, l, w))
+
+	got, err := client.TitleAlbum(context.Background(), &TitleAlbumRequest{Name: "new-york.csv", Photos: []*Photo{
+		photo(monday, 40.728808, -73.996106),
+		photo(sunday, 40.728656, -73.998790),
+		photo(saturday, 40.727160, -73.996044),
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "A foggy weekend in New York", got.GetTitle())
+	require.NotEmpty(t, got.GetSuggestions())
+	require.Equal(t, "A foggy weekend in New York", got.GetSuggestions()[0].GetTitle())
+	require.NotNil(t, got.GetTravel())
+	require.InDelta(t, 0.51, got.GetTravel().GetDistance(), 0.01)
+	require.True(t, proto.Equal(&Altitude{Min: 8, Max: 12}, got.GetAltitude()), got.GetAltitude())
+	require.Nil(t, got.GetSky())
+	require.NotEmpty(t, got.GetDecisions())
+	require.Equal(t, "weather", got.GetDecisions()[0].GetFacet())
+}
+
+func TestServer_TitleAlbum_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	l := &mockLocator{}
+	w := &mockWeatherman{}
+	l.On("Locate", mock.Anything, mock.Anything).Return(locator.Location{City: "New York", Country: "USA"}, nil).Run(func(mock.Arguments) { cancel() })
+	w.On("CheckWeather", mock.Anything, mock.Anything, mock.Anything).Return(weatherman.Forecast{Conditions: "Fog"}, nil)
+	var titled []batch.Result
+	s := NewServer(newProcessor, l, w, WithTitled(func(_ context.Context, result batch.Result) { titled = append(titled, result) }))
+
+	_, err := s.TitleAlbum(ctx, &TitleAlbumRequest{Name: "new-york.csv", Photos: []*Photo{
+		photo(monday, 40.728808, -73.996106),
+		photo(sunday, 40.728656, -73.998790),
+		photo(saturday, 40.727160, -73.996044),
+	}})
+	require.Equal(t, codes.Canceled, status.Code(err), err)
+	l.AssertNumberOfCalls(t, "Locate", 1)
+	w.AssertNumberOfCalls(t, "CheckWeather", 0)
+	require.Empty(t, titled)
+}
+
+func TestServer_StreamPhotos(t *testing.T) {
+	var titled []batch.Result
+	client := dial(t, newYork(WithTitled(func(_ context.Context, result batch.Result) { titled = append(titled, result) })))
+
+	stream, err := client.StreamPhotos(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&StreamPhotosRequest{Name: "new-york.csv", Photos: []*Photo{photo(monday, 40.728808, -73.996106)}}))
+	require.NoError(t, stream.Send(&StreamPhotosRequest{Name: "ignored.csv", Photos: []*Photo{
+		photo(sunday, 40.728656, -73.998790),
+		photo(saturday, 40.727160, -73.996044),
+	}}))
+	got, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+	require.Equal(t, "new-york.csv", got.GetName())
+	require.Equal(t, "A foggy weekend in New York", got.GetTitle())
+	require.Equal(t, int32(3), got.GetPhotos())
+	require.Len(t, titled, 1)
+	require.Equal(t, "A foggy weekend in New York", titled[0].Title)
+
+	stream, err = client.StreamPhotos(context.Background())
+	require.NoError(t, err)
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_StreamPhotos_maxPhotos(t *testing.T) {
+	var titled []batch.Result
+	client := dial(t, newYork(WithMaxPhotos(2), WithTitled(func(_ context.Context, result batch.Result) { titled = append(titled, result) })))
+
+	stream, err := client.StreamPhotos(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&StreamPhotosRequest{Name: "new-york.csv", Photos: []*Photo{photo(monday, 40.728808, -73.996106)}}))
+	// the server may fail the stream before the last photos are sent, which is reported by CloseAndRecv
+	_ = stream.Send(&StreamPhotosRequest{Photos: []*Photo{
+		photo(sunday, 40.728656, -73.998790),
+		photo(saturday, 40.727160, -73.996044),
+	}})
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err), err)
+	require.Empty(t, titled)
+
+	_, err = client.TitleAlbum(context.Background(), &TitleAlbumRequest{Name: "new-york.csv", Photos: []*Photo{
+		photo(monday, 40.728808, -73.996106),
+		photo(sunday, 40.728656, -73.998790),
+		photo(saturday, 40.727160, -73.996044),
+	}})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err), err)
+}
+
+func TestServer_Locate(t *testing.T) {
+	l := &mockLocator{}
+	l.On("Locate", 40.728808, -73.996106).Return(locator.Location{City: "New York", Neighbourhood: "Greenwich Village", Country: "USA"}, nil)
+	l.On("Locate", 51.5072, -0.1276).Return(locator.Location{}, errors.New("quota exceeded"))
+	for name, test := range map[string]struct {
+		input *LocateRequest
+
+		expect     *Location
+		expectCode codes.Code
+	}{
+		"located": {
+			input:  &LocateRequest{Latitude: 40.728808, Longitude: -73.996106},
+			expect: &Location{City: "New York", Neighbourhood: "Greenwich Village", Country: "USA"},
+		},
+		"latitude out of range": {
+			input:      &LocateRequest{Latitude: 140, Longitude: -73.996106},
+			expectCode: codes.InvalidArgument,
+		},
+		"longitude not a number": {
+			input:      &LocateRequest{Latitude: 40.728808, Longitude: math.NaN()},
+			expectCode: codes.InvalidArgument,
+		},
+		"null island": {
+			input:      &LocateRequest{},
+			expectCode: codes.InvalidArgument,
+		},
+		"api error": {
+			input:      &LocateRequest{Latitude: 51.5072, Longitude: -0.1276},
+			expectCode: codes.Unavailable,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := dial(t, NewServer(newProcessor, l, nil))
+			got, err := client.Locate(context.Background(), test.input)
+			require.Equal(t, test.expectCode, status.Code(err), err)
+			if test.expect != nil {
+				require.True(t, proto.Equal(test.expect, got), "expected %v, got %v", test.expect, got)
+			}
+		})
+	}
+	l.AssertNumberOfCalls(t, "Locate", 2)
+}
+
+func TestServer_CheckWeather(t *testing.T) {
+	cloudy := 87.5
+	w := &mockWeatherman{}
+	w.On("CheckWeather", 40.728808, -73.996106, monday).Return(weatherman.Forecast{Conditions: "Rain", CloudCover: &cloudy}, nil)
+	w.On("CheckWeather", 40.728808, -73.996106, sunday).Return(weatherman.Forecast{Conditions: "Clear"}, nil)
+	w.On("CheckWeather", 51.5072, -0.1276, monday).Return(weatherman.Forecast{}, errors.New("quota exceeded"))
+	for name, test := range map[string]struct {
+		input *CheckWeatherRequest
+
+		expect     *Forecast
+		expectCode codes.Code
+	}{
+		"with cloud cover": {
+			input:  &CheckWeatherRequest{Latitude: 40.728808, Longitude: -73.996106, Date: timestamppb.New(monday)},
+			expect: &Forecast{Conditions: "Rain", CloudCover: &cloudy},
+		},
+		"without cloud cover": {
+			input:  &CheckWeatherRequest{Latitude: 40.728808, Longitude: -73.996106, Date: timestamppb.New(sunday)},
+			expect: &Forecast{Conditions: "Clear"},
+		},
+		"missing date": {
+			input:      &CheckWeatherRequest{Latitude: 40.728808, Longitude: -73.996106},
+			expectCode: codes.InvalidArgument,
+		},
+		"longitude out of range": {
+			input:      &CheckWeatherRequest{Latitude: 40.728808, Longitude: -181, Date: timestamppb.New(monday)},
+			expectCode: codes.InvalidArgument,
+		},
+		"null island": {
+			input:      &CheckWeatherRequest{Date: timestamppb.New(monday)},
+			expectCode: codes.InvalidArgument,
+		},
+		"api error": {
+			input:      &CheckWeatherRequest{Latitude: 51.5072, Longitude: -0.1276, Date: timestamppb.New(monday)},
+			expectCode: codes.Unavailable,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := dial(t, NewServer(newProcessor, nil, w))
+			got, err := client.CheckWeather(context.Background(), test.input)
+			require.Equal(t, test.expectCode, status.Code(err), err)
+			if test.expect != nil {
+				require.True(t, proto.Equal(test.expect, got), "expected %v, got %v", test.expect, got)
+			}
+		})
+	}
+}
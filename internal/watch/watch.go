@@ -0,0 +1,133 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Watcher is a custom type used to find out the album folders whose files changed below a root folder, by polling it,
+// so that it works on every file system, including network drives without change notifications
+type Watcher struct {
+	root     string
+	album    func(file string) bool
+	interval time.Duration
+	debounce time.Duration
+	now      func() time.Time
+	files    map[string]state
+	pending  map[string]time.Time
+}
+
+// state is used to tell whether a file changed between two scans
+type state struct {
+	size    int64
+	modTime time.Time
+}
+
+type WatcherOptions func(*Watcher)
+
+// WithInterval sets how often the root folder is scanned, every 2 seconds unless set
+func WithInterval(interval time.Duration) WatcherOptions {
+	return func(w *Watcher) {
+		w.interval = interval
+	}
+}
+
+// WithDebounce sets how long the files of a folder have to stay unchanged before it is reported, so that a burst
+// of changes, e.g. photos being copied, is reported once. 5 seconds unless set.
+func WithDebounce(debounce time.Duration) WatcherOptions {
+	return func(w *Watcher) {
+		w.debounce = debounce
+	}
+}
+
+// New returns a new Watcher of the folders below root directly holding files matching album, e.g. CSV files or images.
+// Hidden folders and files are skipped. Every album folder found by the first scan is reported as changed.
+func New(root string, album func(file string) bool, options ...WatcherOptions) *Watcher {
+	watcher := &Watcher{
+		root:     root,
+		album:    album,
+		interval: 2 * time.Second,
+		debounce: 5 * time.Second,
+		now:      time.Now,
+		files:    map[string]state{},
+		pending:  map[string]time.Time{},
+	}
+	for _, option := range options {
+		option(watcher)
+	}
+	return watcher
+}
+
+// Scan is used to scan the root folder once, returning the album folders whose files were added, modified or removed
+// and have not changed since for the debounce period, in lexical order
+func (w *Watcher) Scan() ([]string, error) {
+	now := w.now()
+	files := make(map[string]state, len(w.files))
+	err := filepath.WalkDir(w.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != w.root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(entry.Name(), ".") || !w.album(path) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		files[path] = state{size: info.Size(), modTime: info.ModTime()}
+		if previous, found := w.files[path]; !found || previous != files[path] {
+			w.pending[filepath.Dir(path)] = now
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", w.root, err)
+	}
+	for path := range w.files {
+		if _, found := files[path]; !found {
+			w.pending[filepath.Dir(path)] = now
+		}
+	}
+	w.files = files
+
+	var settled []string
+	for folder, changed := range w.pending {
+		if now.Sub(changed) >= w.debounce {
+			settled = append(settled, folder)
+			delete(w.pending, folder)
+		}
+	}
+	sort.Strings(settled)
+	return settled, nil
+}
+
+// Run is used to scan the root folder every interval until ctx is cancelled, calling changed with the album folders
+// reported by every scan. Scan errors, e.g. a folder removed while scanned, are passed to failed, and scanning goes on.
+func (w *Watcher) Run(ctx context.Context, changed func(folders []string), failed func(err error)) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		folders, err := w.Scan()
+		if err != nil {
+			failed(err)
+		} else if len(folders) > 0 {
+			changed(folders)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher_Scan(t *testing.T) {
+	csv := func(file string) bool { return strings.HasSuffix(file, ".csv") }
+	for name, test := range map[string]struct {
+		// steps are applied one after the other, each followed by a scan after elapsed
+		steps []struct {
+			write   map[string]string
+			remove  []string
+			elapsed time.Duration
+			expect  []string
+		}
+	}{
+		"reports existing folders once settled": {
+			steps: []struct {
+				write   map[string]string
+				remove  []string
+				elapsed time.Duration
+				expect  []string
+			}{
+				{write: map[string]string{"amalfi/1.csv": "a", "rome/1.csv": "a", "rome/notes.txt": "a"}},
+				{elapsed: 5 * time.Second, expect: []string{"amalfi", "rome"}},
+				{elapsed: 5 * time.Second},
+			},
+		},
+		"debounces a burst of changes": {
+			steps: []struct {
+				write   map[string]string
+				remove  []string
+				elapsed time.Duration
+				expect  []string
+			}{
+				{write: map[string]string{"amalfi/1.csv": "a"}},
+				{elapsed: 5 * time.Second, expect: []string{"amalfi"}},
+				{write: map[string]string{"amalfi/2.csv": "a"}, elapsed: time.Second},
+				{write: map[string]string{"amalfi/3.csv": "a"}, elapsed: 3 * time.Second},
+				{write: map[string]string{"amalfi/3.csv": "ab"}, elapsed: 3 * time.Second},
+				{elapsed: 3 * time.Second},
+				{elapsed: 2 * time.Second, expect: []string{"amalfi"}},
+			},
+		},
+		"reports removed files and ignores other files": {
+			steps: []struct {
+				write   map[string]string
+				remove  []string
+				elapsed time.Duration
+				expect  []string
+			}{
+				{write: map[string]string{"amalfi/1.csv": "a", "rome/1.csv": "a"}},
+				{elapsed: 5 * time.Second, expect: []string{"amalfi", "rome"}},
+				{remove: []string{"rome/1.csv"}, write: map[string]string{"amalfi/notes.txt": "a", "amalfi/.journal.csv": "a", ".trash/1.csv": "a"}, elapsed: 5 * time.Second},
+				{elapsed: 5 * time.Second, expect: []string{"rome"}},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			now := time.Date(2019, 7, 4, 12, 0, 0, 0, time.UTC)
+			w := New(root, csv)
+			w.now = func() time.Time { return now }
+			for i, step := range test.steps {
+				for file, content := range step.write {
+					path := filepath.Join(root, filepath.FromSlash(file))
+					require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+					require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+				}
+				for _, file := range step.remove {
+					require.NoError(t, os.Remove(filepath.Join(root, filepath.FromSlash(file))))
+				}
+				now = now.Add(step.elapsed)
+				got, err := w.Scan()
+				require.NoError(t, err)
+				var expect []string
+				for _, folder := range step.expect {
+					expect = append(expect, filepath.Join(root, folder))
+				}
+				require.Equal(t, expect, got, "step %d", i)
+			}
+		})
+	}
+}
+
+func TestWatcher_Run(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "1.csv"), []byte("a"), 0o644))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var got []string
+	w := New(root, func(file string) bool { return strings.HasSuffix(file, ".csv") }, WithInterval(time.Millisecond), WithDebounce(0))
+	w.Run(ctx, func(folders []string) {
+		got = append(got, folders...)
+		cancel()
+	}, func(err error) {
+		t.Errorf("unexpected scan error: %v", err)
+	})
+	require.Equal(t, []string{root}, got)
+}
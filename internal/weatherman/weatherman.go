@@ -28,14 +28,17 @@ func WithFilter(filters []string) WeatherOptions {
 // Forecast is a custom type used to communicate weather data to other packages.
 type Forecast struct {
 	Conditions string
+	// CloudCover is the percentage of the sky covered by clouds, nil when the API did not report it
+	CloudCover *float64
 }
 
 // apiData struct is used for unmarshalling JSON returned by the API into a type this program can parse.
 type apiData struct {
 	Days []struct {
-		Date       string `json:"datetime"`
-		UnixEpoch  int    `json:"datetimeEpoch"`
-		Conditions string `json:"conditions"`
+		Date       string   `json:"datetime"`
+		UnixEpoch  int      `json:"datetimeEpoch"`
+		Conditions string   `json:"conditions"`
+		CloudCover *float64 `json:"cloudcover"`
 	} `json:"days"`
 }
 
@@ -77,6 +80,7 @@ func (w *Weatherman) CheckWeather(latitude, longitude float64, date time.Time) (
 
 	return Forecast{
 		Conditions: weatherData.Days[0].Conditions,
+		CloudCover: weatherData.Days[0].CloudCover,
 	}, nil
 }
 
@@ -11,6 +11,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var overcast = 87.5
+
 type fakeWeatherAPI struct {
 	T *testing.T
 }
@@ -20,12 +22,14 @@ func (f *fakeWeatherAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case strings.Contains(r.URL.Path, "services/timeline"):
 		_ = json.NewEncoder(w).Encode(apiData{
 			Days: []struct {
-				Date       string "json:\"datetime\""
-				UnixEpoch  int    "json:\"datetimeEpoch\""
-				Conditions string "json:\"conditions\""
+				Date       string   "json:\"datetime\""
+				UnixEpoch  int      "json:\"datetimeEpoch\""
+				Conditions string   "json:\"conditions\""
+				CloudCover *float64 "json:\"cloudcover\""
 			}{
 				{
 					Conditions: "Rain,Overcast",
+					CloudCover: &overcast,
 				},
 			},
 		})
@@ -77,6 +81,7 @@ func TestWeatherman_CheckWeather(t *testing.T) {
 			date:      time.Now(),
 			want: Forecast{
 				Conditions: "Rain,Overcast",
+				CloudCover: &overcast,
 			},
 		},
 		"request failed": {
@@ -112,6 +117,7 @@ func TestWeatherman_CheckWeather(t *testing.T) {
 				t.Errorf("Weatherman.CheckWeather() error = %v, wantErr = %v", err, test.wantErr)
 			}
 			require.Equal(t, got.Conditions, test.want.Conditions)
+			require.Equal(t, got.CloudCover, test.want.CloudCover)
 		})
 	}
 }